@@ -4,12 +4,11 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 
-	"github.com/amacneil/dbmate/v2/pkg/dbmate"
-	_ "github.com/amacneil/dbmate/v2/pkg/driver/sqlite"
 	envcfg "github.com/caarlos0/env/v11"
 	"github.com/doug-martin/goqu/v9"
 	"github.com/jmoiron/sqlx"
@@ -62,7 +61,7 @@ func run(args []string) error {
 
 	switch args[1] {
 	case "server":
-		return runServer(logger, cfg)
+		return runServer(logger, cfg, args[2:])
 	case "seed":
 		return runSeed(logger, cfg)
 	}
@@ -70,24 +69,42 @@ func run(args []string) error {
 	return fmt.Errorf("unknown command %q", args[1])
 }
 
-func runServer(logger *slog.Logger, cfg env.Config) error {
+func runServer(logger *slog.Logger, cfg env.Config, args []string) error {
 	ctx := context.Background()
 
-	db, err := sqlitestore.Connect(ctx, cfg.DatabaseURL.String())
-	if err != nil {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	ephemeral := flags.Bool(
+		"ephemeral",
+		false,
+		"keep all the data in memory using the embedded word lists, nothing is persisted across restarts",
+	)
+	if err := flags.Parse(args); err != nil {
 		return err
 	}
 
-	defer db.Close()
+	var (
+		st  *storage
+		err error
+	)
+	if *ephemeral {
+		logger.Warn("running in ephemeral mode, all data will be lost when the server stops")
+		st, err = openEphemeralStorage()
+	} else {
+		st, err = openStorage(ctx, logger, cfg.DatabaseURL)
+	}
+	if err != nil {
+		return err
+	}
 
-	dbm := dbmate.New(cfg.DatabaseURL)
-	dbm.AutoDumpSchema = false
-	dbm.FS = embed.MigrationsFS
+	defer st.close()
 
-	logger.Info("applying migrations...")
-	if err := dbm.Migrate(); err != nil {
-		return fmt.Errorf("failed to apply migrations: %w", err)
+	if !*ephemeral {
+		logger.Info("applying migrations...")
+		if err := st.migrate(cfg.DatabaseURL); err != nil {
+			return err
+		}
 	}
+
 	assetsCfg := vite.AssetsConfig{
 		RootURL:          cfg.AssetsRootURL.String(),
 		UseManifest:      cfg.AssetsUseManifest,
@@ -111,21 +128,54 @@ func runServer(logger *slog.Logger, cfg env.Config) error {
 		}
 	}
 
-	pairStore := sqlitestore.NewPairStore(db)
-	bucketStore := sqlitestore.NewBucketStore(logger, db)
+	generator := serverplate.NewGenerator(st.pairStore)
+
+	events := serverplate.NewEventBus()
+	auditor := serverplate.NewAuditor(st.auditStore)
+
+	runner, err := bg.NewRunner(
+		logger,
+		st.bucketStore,
+		st.jobRunStore,
+		st.jobLockStore,
+		st.leaseStore,
+		events,
+		auditor,
+		cfg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set up the background jobs: %w", err)
+	}
+	runner.Start()
 
-	generator := serverplate.NewGenerator(pairStore)
+	filler, err := bg.NewFiller(logger.With("service", "filler"), generator, st.bucketStore, events, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to set up the bucket filler: %w", err)
+	}
 
-	runner := bg.NewRunner(logger, bucketStore)
-	runner.Start()
+	buckets := serverplate.NewBucketService(
+		generator,
+		st.bucketStore,
+		st.leaseStore,
+		filler,
+		events,
+		auditor,
+		cfg.LeaseTTL,
+		cfg.LeaseMaxTTL,
+	)
 
 	s := server.New(&server.Services{
-		Logger:      logger.With("service", "server"),
-		Config:      cfg,
-		Assets:      assets,
-		Generator:   generator,
-		PairStore:   pairStore,
-		BucketStore: bucketStore,
+		Logger:        logger.With("service", "server"),
+		Config:        cfg,
+		Assets:        assets,
+		Generator:     generator,
+		PairStore:     st.pairStore,
+		BucketStore:   st.bucketStore,
+		BucketService: buckets,
+		JobRunStore:   st.jobRunStore,
+		JobRunner:     runner,
+		Events:        events,
+		AuditStore:    st.auditStore,
 	})
 
 	logger.Info("starting http server", "addr", s.Addr)
@@ -139,12 +189,12 @@ func runServer(logger *slog.Logger, cfg env.Config) error {
 func runSeed(logger *slog.Logger, cfg env.Config) error {
 	ctx := context.Background()
 
-	db, err := sqlitestore.Connect(ctx, cfg.DatabaseURL.String())
+	st, err := openStorage(ctx, logger, cfg.DatabaseURL)
 	if err != nil {
 		return err
 	}
 
-	defer db.Close()
+	defer st.close()
 
 	logger.Info("running seed")
 	tables := []string{
@@ -154,7 +204,7 @@ func runSeed(logger *slog.Logger, cfg env.Config) error {
 
 	for _, t := range tables {
 		logger.Info("seeding table", "table", t)
-		if err := seedByTable(ctx, logger, db.Write().DB, t); err != nil {
+		if err := seedByTable(ctx, logger, st.seedDB, t); err != nil {
 			logger.Error("failure running seed", "error", err, "table", t)
 		}
 	}
@@ -202,7 +252,7 @@ func seedByTable(ctx context.Context, logger *slog.Logger, db *sqlx.DB, table st
 		}
 
 		const insTemplSQL = `INSERT INTO %s (value, from_seed)
-							 SELECT value, 1 AS from_seed FROM %s WHERE 1
+							 SELECT value, 1 AS from_seed FROM %s WHERE true
 							 ON CONFLICT(value) DO NOTHING`
 		insSQL := fmt.Sprintf(insTemplSQL, table, tempTable)
 		insResult, err := tx.ExecContext(ctx, insSQL)
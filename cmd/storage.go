@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/amacneil/dbmate/v2/pkg/dbmate"
+	_ "github.com/amacneil/dbmate/v2/pkg/driver/postgres"
+	_ "github.com/amacneil/dbmate/v2/pkg/driver/sqlite"
+	"github.com/jmoiron/sqlx"
+
+	embed "github.com/davidonium/serverplate"
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+	"github.com/davidonium/serverplate/internal/store/pgstore"
+	"github.com/davidonium/serverplate/internal/store/sqlitestore"
+)
+
+// storage groups the stores of the backend selected through the scheme of the database url.
+type storage struct {
+	pairStore     serverplate.PairStore
+	bucketStore   serverplate.BucketStore
+	jobRunStore   serverplate.JobRunStore
+	jobLockStore  serverplate.JobLockStore
+	leaseStore    serverplate.LeaseStore
+	auditStore    serverplate.AuditStore
+	seedDB        *sqlx.DB
+	migrationsDir string
+	close         func() error
+}
+
+func openStorage(ctx context.Context, logger *slog.Logger, u *url.URL) (*storage, error) {
+	if u == nil {
+		return nil, errors.New("DATABASE_URL is required unless the server runs in ephemeral mode")
+	}
+
+	switch u.Scheme {
+	case "sqlite":
+		db, err := sqlitestore.Connect(ctx, u.String())
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage{
+			pairStore:     sqlitestore.NewPairStore(db),
+			bucketStore:   sqlitestore.NewBucketStore(logger, db),
+			jobRunStore:   sqlitestore.NewJobRunStore(db),
+			jobLockStore:  sqlitestore.NewJobLockStore(db),
+			leaseStore:    sqlitestore.NewLeaseStore(db),
+			auditStore:    sqlitestore.NewAuditStore(db),
+			seedDB:        db.Write().DB,
+			migrationsDir: "./db/migrations",
+			close:         db.Close,
+		}, nil
+	case "postgres", "postgresql":
+		db, err := pgstore.Connect(ctx, u.String())
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage{
+			pairStore:     pgstore.NewPairStore(db),
+			bucketStore:   pgstore.NewBucketStore(logger, db),
+			jobRunStore:   pgstore.NewJobRunStore(db),
+			jobLockStore:  pgstore.NewJobLockStore(db),
+			leaseStore:    pgstore.NewLeaseStore(db),
+			auditStore:    pgstore.NewAuditStore(db),
+			seedDB:        db.DB,
+			migrationsDir: "./db/migrations/postgres",
+			close:         db.Close,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"unsupported database url scheme %q, expected one of 'sqlite', 'postgres' or 'postgresql'",
+		u.Scheme,
+	)
+}
+
+// openEphemeralStorage returns in-memory stores filled with the word lists embedded in the binary.
+func openEphemeralStorage() (*storage, error) {
+	adjectives, err := embed.SeedFS.Open("db/seed/adjectives.txt")
+	if err != nil {
+		return nil, err
+	}
+	defer adjectives.Close()
+
+	nouns, err := embed.SeedFS.Open("db/seed/nouns.txt")
+	if err != nil {
+		return nil, err
+	}
+	defer nouns.Close()
+
+	words, err := memstore.ReadWords(adjectives, nouns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the embedded word lists: %w", err)
+	}
+
+	bucketStore := memstore.NewBucketStore(words)
+	return &storage{
+		pairStore:    memstore.NewPairStore(words),
+		bucketStore:  bucketStore,
+		jobRunStore:  memstore.NewJobRunStore(),
+		jobLockStore: memstore.NewJobLockStore(),
+		leaseStore:   memstore.NewLeaseStore(bucketStore),
+		auditStore:   memstore.NewAuditStore(),
+		close:        func() error { return nil },
+	}, nil
+}
+
+func (s *storage) migrate(u *url.URL) error {
+	dbm := dbmate.New(u)
+	dbm.AutoDumpSchema = false
+	dbm.FS = embed.MigrationsFS
+	dbm.MigrationsDir = []string{s.migrationsDir}
+
+	if err := dbm.Migrate(); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return nil
+}
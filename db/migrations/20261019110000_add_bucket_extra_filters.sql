@@ -0,0 +1,17 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN filter_min_length INTEGER DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN filter_adjective_initial TEXT DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN filter_noun_initial TEXT DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN filter_alliterative INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE buckets ADD COLUMN filter_prefix TEXT DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN filter_suffix TEXT DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN filter_excluded_chars TEXT DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN filter_min_length;
+ALTER TABLE buckets DROP COLUMN filter_adjective_initial;
+ALTER TABLE buckets DROP COLUMN filter_noun_initial;
+ALTER TABLE buckets DROP COLUMN filter_alliterative;
+ALTER TABLE buckets DROP COLUMN filter_prefix;
+ALTER TABLE buckets DROP COLUMN filter_suffix;
+ALTER TABLE buckets DROP COLUMN filter_excluded_chars;
@@ -0,0 +1,5 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN retention_seconds INTEGER DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN retention_seconds;
@@ -0,0 +1,9 @@
+-- migrate:up
+CREATE TABLE job_locks (
+    job TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+
+-- migrate:down
+DROP TABLE job_locks;
@@ -0,0 +1,7 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN expires_at DATETIME DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN archive_when_exhausted INTEGER NOT NULL DEFAULT 0;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN archive_when_exhausted;
+ALTER TABLE buckets DROP COLUMN expires_at;
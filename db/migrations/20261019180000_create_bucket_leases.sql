@@ -0,0 +1,15 @@
+-- migrate:up
+CREATE TABLE bucket_leases (
+    id TEXT PRIMARY KEY,
+    bucket_id INTEGER NOT NULL,
+    value_id INTEGER NOT NULL,
+    confirmed_at DATETIME DEFAULT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE,
+    FOREIGN KEY (value_id) REFERENCES bucket_values(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_bucket_leases_expires_at ON bucket_leases(expires_at);
+
+-- migrate:down
+DROP TABLE bucket_leases;
@@ -0,0 +1,7 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN lazy_position INTEGER DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN lazy_remaining INTEGER DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN lazy_remaining;
+ALTER TABLE buckets DROP COLUMN lazy_position;
@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN fill_total INTEGER DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN fill_done INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE buckets ADD COLUMN fill_error TEXT DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN fill_error;
+ALTER TABLE buckets DROP COLUMN fill_done;
+ALTER TABLE buckets DROP COLUMN fill_total;
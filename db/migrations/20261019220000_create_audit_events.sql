@@ -0,0 +1,27 @@
+-- migrate:up
+CREATE TABLE audit_events (
+    id INTEGER PRIMARY KEY,
+    action TEXT NOT NULL,
+    bucket_id INTEGER NOT NULL,
+    bucket_name TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    source TEXT NOT NULL,
+    ip TEXT DEFAULT NULL,
+    request_id TEXT DEFAULT NULL,
+    before_state TEXT NOT NULL,
+    after_state TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_audit_events_bucket_id ON audit_events(bucket_id, id);
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+
+-- migrate:down
+DROP TABLE audit_events;
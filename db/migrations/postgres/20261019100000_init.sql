@@ -0,0 +1,53 @@
+-- migrate:up
+CREATE TABLE nouns (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    value TEXT NOT NULL,
+    from_seed INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE TABLE adjectives (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    value TEXT NOT NULL,
+    from_seed INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_unique_value_nouns ON nouns(value);
+
+CREATE UNIQUE INDEX idx_unique_value_adjectives ON adjectives(value);
+
+CREATE TABLE buckets (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    cursor INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT NULL,
+    archived_at TIMESTAMPTZ,
+    filter_length_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    filter_length_mode TEXT DEFAULT 'upto',
+    filter_length_value INTEGER DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_unique_name_buckets ON buckets(name);
+
+CREATE TABLE bucket_values (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bucket_id INTEGER NOT NULL,
+    order_id INTEGER NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT NULL,
+    FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_bucket_values_bucket_id ON bucket_values(bucket_id, order_id);
+
+-- migrate:down
+DROP TABLE bucket_values;
+DROP TABLE buckets;
+DROP TABLE nouns;
+DROP TABLE adjectives;
@@ -0,0 +1,5 @@
+-- migrate:up
+ALTER TABLE bucket_values ADD COLUMN popped_at TIMESTAMPTZ DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE bucket_values DROP COLUMN popped_at;
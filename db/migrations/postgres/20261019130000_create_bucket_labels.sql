@@ -0,0 +1,12 @@
+-- migrate:up
+CREATE TABLE bucket_labels (
+    bucket_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (bucket_id, key),
+    FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_bucket_labels_key_value ON bucket_labels(key, value);
+
+-- migrate:down
+DROP TABLE bucket_labels;
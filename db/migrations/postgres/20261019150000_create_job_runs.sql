@@ -0,0 +1,13 @@
+-- migrate:up
+CREATE TABLE job_runs (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    job TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ NOT NULL,
+    error TEXT DEFAULT NULL,
+    affected_rows BIGINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_job_runs_job_started_at ON job_runs(job, started_at);
+
+-- migrate:down
+DROP TABLE job_runs;
@@ -0,0 +1,7 @@
+-- migrate:up
+ALTER TABLE buckets ADD COLUMN seed BIGINT DEFAULT NULL;
+ALTER TABLE buckets ADD COLUMN words_version TEXT DEFAULT NULL;
+
+-- migrate:down
+ALTER TABLE buckets DROP COLUMN words_version;
+ALTER TABLE buckets DROP COLUMN seed;
@@ -0,0 +1,29 @@
+-- migrate:up
+CREATE TABLE audit_events (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    action TEXT NOT NULL,
+    bucket_id INTEGER NOT NULL,
+    bucket_name TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    source TEXT NOT NULL,
+    ip TEXT DEFAULT NULL,
+    request_id TEXT DEFAULT NULL,
+    before_state JSONB NOT NULL,
+    after_state JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_audit_events_bucket_id ON audit_events(bucket_id, id);
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE FUNCTION audit_events_append_only() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are append-only';
+END;
+$$;
+CREATE TRIGGER audit_events_no_change BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+CREATE TRIGGER audit_events_no_truncate BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
+
+-- migrate:down
+DROP TABLE audit_events;
+DROP FUNCTION audit_events_append_only();
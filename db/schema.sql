@@ -23,7 +23,7 @@ CREATE TABLE buckets (
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT NULL,
     archived_at DATETIME
-, filter_length_enabled INTEGER NOT NULL DEFAULT 0, filter_length_mode TEXT DEFAULT 'upto', filter_length_value INTEGER DEFAULT NULL);
+, filter_length_enabled INTEGER NOT NULL DEFAULT 0, filter_length_mode TEXT DEFAULT 'upto', filter_length_value INTEGER DEFAULT NULL, filter_min_length INTEGER DEFAULT NULL, filter_adjective_initial TEXT DEFAULT NULL, filter_noun_initial TEXT DEFAULT NULL, filter_alliterative INTEGER NOT NULL DEFAULT 0, filter_prefix TEXT DEFAULT NULL, filter_suffix TEXT DEFAULT NULL, filter_excluded_chars TEXT DEFAULT NULL, retention_seconds INTEGER DEFAULT NULL, expires_at DATETIME DEFAULT NULL, archive_when_exhausted INTEGER NOT NULL DEFAULT 0, seed INTEGER DEFAULT NULL, words_version TEXT DEFAULT NULL, lazy_position INTEGER DEFAULT NULL, lazy_remaining INTEGER DEFAULT NULL, fill_total INTEGER DEFAULT NULL, fill_done INTEGER NOT NULL DEFAULT 0, fill_error TEXT DEFAULT NULL);
 CREATE UNIQUE INDEX idx_unique_name_buckets ON buckets(name);
 CREATE TABLE bucket_values (
     id INTEGER PRIMARY KEY,
@@ -31,11 +31,79 @@ CREATE TABLE bucket_values (
     order_id INTEGER NOT NULL,
     value TEXT NOT NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-    updated_at DATETIME DEFAULT NULL,
+    updated_at DATETIME DEFAULT NULL, popped_at DATETIME DEFAULT NULL,
     FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE
 );
 CREATE INDEX idx_bucket_values_bucket_id ON bucket_values(bucket_id, order_id);
+CREATE TABLE bucket_labels (
+    bucket_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (bucket_id, key),
+    FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_bucket_labels_key_value ON bucket_labels(key, value);
+CREATE TABLE job_runs (
+    id INTEGER PRIMARY KEY,
+    job TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NOT NULL,
+    error TEXT DEFAULT NULL,
+    affected_rows INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_job_runs_job_started_at ON job_runs(job, started_at);
+CREATE TABLE job_locks (
+    job TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+CREATE TABLE bucket_leases (
+    id TEXT PRIMARY KEY,
+    bucket_id INTEGER NOT NULL,
+    value_id INTEGER NOT NULL,
+    confirmed_at DATETIME DEFAULT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (bucket_id) REFERENCES buckets(id) ON DELETE CASCADE,
+    FOREIGN KEY (value_id) REFERENCES bucket_values(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_bucket_leases_expires_at ON bucket_leases(expires_at);
+CREATE TABLE audit_events (
+    id INTEGER PRIMARY KEY,
+    action TEXT NOT NULL,
+    bucket_id INTEGER NOT NULL,
+    bucket_name TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    source TEXT NOT NULL,
+    ip TEXT DEFAULT NULL,
+    request_id TEXT DEFAULT NULL,
+    before_state TEXT NOT NULL,
+    after_state TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_audit_events_bucket_id ON audit_events(bucket_id, id);
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
 -- Dbmate schema migrations
 INSERT INTO "schema_migrations" (version) VALUES
   ('20240609195352'),
-  ('20260106101541');
+  ('20260106101541'),
+  ('20261019110000'),
+  ('20261019120000'),
+  ('20261019130000'),
+  ('20261019140000'),
+  ('20261019150000'),
+  ('20261019160000'),
+  ('20261019170000'),
+  ('20261019180000'),
+  ('20261019190000'),
+  ('20261019200000'),
+  ('20261019210000'),
+  ('20261019220000');
@@ -5,5 +5,8 @@ import "embed"
 //go:embed "db/migrations/*"
 var MigrationsFS embed.FS
 
+//go:embed "db/seed/*.txt"
+var SeedFS embed.FS
+
 //go:embed "frontend/dist/*"
 var FrontendFS embed.FS
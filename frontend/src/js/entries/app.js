@@ -1,9 +1,69 @@
 import "../../css/app.css";
 import "vite/modulepreload-polyfill";
-import "htmx.org";
+import htmx from "htmx.org";
 import u from "umbrellajs";
 import { writeTextToClipboard } from "~/lib/clipboard";
 
+// forms rejected by the validation are answered with a 422 holding the form and its errors, which is swapped in
+htmx.config.responseHandling = [
+  { code: "204", swap: false },
+  { code: "[23]..", swap: true },
+  { code: "422", swap: true },
+  { code: "[45]..", swap: false, error: true },
+];
+
+// the bucket event streams by the element that opened them, htmx:load fires on every swap and the element is
+// searched from the document, so an element only opens its stream once.
+const bucketEventSources = new Map();
+
+// Bucket details page live remaining pairs, the page reloads when someone else archives or recovers the bucket
+const openBucketEvents = (eventsEl) => {
+  if (bucketEventSources.has(eventsEl)) {
+    return;
+  }
+
+  const source = new EventSource(eventsEl.dataset.eventsUrl);
+  bucketEventSources.set(eventsEl, source);
+
+  const updateRemaining = (ev) => {
+    const data = JSON.parse(ev.data);
+    // looked up on every event, pops swap the element out of band
+    u("#bucket-remaining-pairs").text(data.remaining.toLocaleString("en-US"));
+  };
+
+  ["popped", "reserved", "released", "remaining"].forEach((type) => {
+    source.addEventListener(type, updateRemaining);
+  });
+  ["archived", "recovered"].forEach((type) => {
+    source.addEventListener(type, () => {
+      closeBucketEvents(eventsEl);
+      window.location.reload();
+    });
+  });
+};
+
+const closeBucketEvents = (eventsEl) => {
+  const source = bucketEventSources.get(eventsEl);
+  if (source) {
+    source.close();
+    bucketEventSources.delete(eventsEl);
+  }
+};
+
+// the streams are closed along with the elements htmx removes and when leaving the page, a page restored from the
+// back-forward cache opens them again.
+u(document).on("htmx:beforeCleanupElement", (ev) => {
+  closeBucketEvents(ev.detail.elt);
+});
+u(window).on("pagehide", () => {
+  bucketEventSources.forEach((_, eventsEl) => closeBucketEvents(eventsEl));
+});
+u(window).on("pageshow", (ev) => {
+  if (ev.persisted) {
+    u(".js-bucket-events").each(openBucketEvents);
+  }
+});
+
 u(document).on("htmx:load", (ev) => {
   const el = u(ev.currentTarget);
   el.find(".js-copy").on("click", (ev) => {
@@ -56,6 +116,8 @@ u(document).on("htmx:load", (ev) => {
     u(ev.currentTarget).closest("dialog").first().close();
   });
 
+  el.find(".js-bucket-events").each(openBucketEvents);
+
   // Bucket create page filter controls
   const filterLengthToggle = el.find(".js-filter-length-toggle").first();
   const filterLengthControls = el.find(".js-filter-length-controls").first();
@@ -0,0 +1,88 @@
+package bg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// autoArchiveBucketsTask archives the buckets whose expiry is over and the exhausted buckets that asked to be archived
+// once every name was popped. A bucket with pending leases is not exhausted, their names may come back to it. It
+// returns the amount of buckets archived.
+func autoArchiveBucketsTask(
+	logger *slog.Logger,
+	bucketStore serverplate.BucketStore,
+	leaseStore serverplate.LeaseStore,
+	events *serverplate.EventBus,
+	auditor *serverplate.Auditor,
+) func(context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		buckets, err := bucketStore.List(ctx, serverplate.ListOptions{IncludeRemaining: true})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list the active buckets: %w", err)
+		}
+
+		now := time.Now()
+
+		var archivedCount int64
+		for _, b := range buckets {
+			var reason string
+			switch {
+			case b.Expired(now):
+				reason = "expired"
+			case b.ArchiveWhenExhausted && !b.Filling() && b.RemainingValues == 0:
+				pending, err := leaseStore.CountPending(ctx, b.ID)
+				if err != nil {
+					return archivedCount, fmt.Errorf("failed to count the pending leases of the bucket %q: %w", b.Name, err)
+				}
+				if pending > 0 {
+					continue
+				}
+				reason = "exhausted"
+			default:
+				continue
+			}
+
+			// only the archival is written, the bucket may have been edited since it was listed.
+			err := bucketStore.Archive(ctx, b.ID, now)
+			if errors.Is(err, serverplate.ErrBucketNotFound) {
+				// archived or removed since it was listed.
+				continue
+			}
+			if err != nil {
+				return archivedCount, fmt.Errorf("failed to archive the bucket %q: %w", b.Name, err)
+			}
+
+			archived, err := bucketStore.OneByID(ctx, b.ID)
+			if err != nil {
+				return archivedCount, fmt.Errorf("failed to reload the archived bucket %q: %w", b.Name, err)
+			}
+			before := archived
+			before.ArchivedAt = nil
+
+			logger.Info("archived bucket",
+				slog.Int("bucket.id", int(archived.ID)),
+				slog.String("bucket.name", archived.Name),
+				slog.String("reason", reason),
+			)
+			events.Publish(serverplate.BucketEvent{Type: serverplate.BucketEventArchived, BucketID: archived.ID})
+			archivedCount++
+			err = auditor.Record(
+				ctx,
+				serverplate.AuditBucketArchived,
+				archived,
+				serverplate.SnapshotBucket(before),
+				serverplate.SnapshotBucket(archived),
+			)
+			if err != nil {
+				return archivedCount, err
+			}
+		}
+
+		return archivedCount, nil
+	}
+}
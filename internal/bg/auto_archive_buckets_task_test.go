@@ -0,0 +1,145 @@
+package bg
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+)
+
+func TestAutoArchiveBucketsTask(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave"}, []string{"river"})
+	bucketStore := memstore.NewBucketStore(words)
+	leaseStore := memstore.NewLeaseStore(bucketStore)
+
+	create := func(b serverplate.Bucket, pops int) serverplate.Bucket {
+		t.Helper()
+
+		if err := bucketStore.Create(ctx, &b); err != nil {
+			t.Fatalf("Create() = unexpected error: %v", err)
+		}
+		if err := bucketStore.FillBucketValues(ctx, b, b.Filters()); err != nil {
+			t.Fatalf("FillBucketValues() = unexpected error: %v", err)
+		}
+		for range pops {
+			if _, err := bucketStore.PopName(ctx, b); err != nil {
+				t.Fatalf("PopName() = unexpected error: %v", err)
+			}
+		}
+
+		return b
+	}
+
+	expired := create(serverplate.Bucket{Name: "expired", ExpiresAt: new(time.Now().Add(-time.Minute))}, 0)
+	exhausted := create(serverplate.Bucket{Name: "exhausted", ArchiveWhenExhausted: true}, 1)
+	create(serverplate.Bucket{Name: "not-expired", ExpiresAt: new(time.Now().Add(time.Hour))}, 0)
+	create(serverplate.Bucket{Name: "not-exhausted", ArchiveWhenExhausted: true}, 0)
+	create(serverplate.Bucket{Name: "exhausted-kept"}, 1)
+
+	// the name of a pending lease may come back to its bucket, so the bucket is not exhausted yet.
+	leased := create(serverplate.Bucket{Name: "leased", ArchiveWhenExhausted: true}, 0)
+	if _, err := leaseStore.Reserve(ctx, leased, time.Minute); err != nil {
+		t.Fatalf("Reserve() = unexpected error: %v", err)
+	}
+
+	// a bucket whose names are still being written has none remaining but is not exhausted.
+	filling := serverplate.Bucket{Name: "filling", ArchiveWhenExhausted: true, Fill: &serverplate.BucketFill{}}
+	if err := bucketStore.Create(ctx, &filling); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	auditStore := memstore.NewAuditStore()
+	auditor := serverplate.NewAuditor(auditStore)
+
+	archived, err := autoArchiveBucketsTask(logger, bucketStore, leaseStore, serverplate.NewEventBus(), auditor)(ctx)
+	if err != nil {
+		t.Fatalf("autoArchiveBucketsTask() = unexpected error: %v", err)
+	}
+
+	if archived != 2 {
+		t.Errorf("autoArchiveBucketsTask() = got %d archived buckets, want 2", archived)
+	}
+
+	buckets, err := bucketStore.List(ctx, serverplate.ListOptions{ArchivedOnly: true})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, b := range buckets {
+		names[b.Name] = true
+	}
+
+	if len(names) != 2 || !names[expired.Name] || !names[exhausted.Name] {
+		t.Errorf("List() = got archived buckets %v, want %q and %q", names, expired.Name, exhausted.Name)
+	}
+
+	audited, err := auditStore.List(ctx, serverplate.AuditListOptions{Action: serverplate.AuditBucketArchived})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	if len(audited) != 2 {
+		t.Errorf("List() = got %d archived audit events, want 2", len(audited))
+	}
+}
+
+// staleListStore lists the buckets as they were before the last changes, like a list read right before an edit.
+type staleListStore struct {
+	serverplate.BucketStore
+	listed []serverplate.Bucket
+}
+
+func (s *staleListStore) List(context.Context, serverplate.ListOptions) ([]serverplate.Bucket, error) {
+	return s.listed, nil
+}
+
+func TestAutoArchiveBucketsTaskKeepsEdits(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave"}, []string{"river"})
+	bucketStore := memstore.NewBucketStore(words)
+	leaseStore := memstore.NewLeaseStore(bucketStore)
+
+	b := serverplate.Bucket{Name: "expired", ExpiresAt: new(time.Now().Add(-time.Minute))}
+	if err := bucketStore.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	store := &staleListStore{BucketStore: bucketStore, listed: []serverplate.Bucket{b}}
+
+	renamed := b
+	renamed.Name = "renamed"
+	renamed.Description = "edited after the list"
+	if err := bucketStore.Save(ctx, &renamed); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	auditor := serverplate.NewAuditor(memstore.NewAuditStore())
+	archived, err := autoArchiveBucketsTask(logger, store, leaseStore, serverplate.NewEventBus(), auditor)(ctx)
+	if err != nil || archived != 1 {
+		t.Fatalf("autoArchiveBucketsTask() = got %d archived buckets and err %v, want 1", archived, err)
+	}
+
+	got, err := bucketStore.OneByID(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("OneByID() = unexpected error: %v", err)
+	}
+	if !got.Archived() || got.Name != "renamed" || got.Description != "edited after the list" {
+		t.Errorf("OneByID() = got %q %q archived %v, want the edits kept and archived",
+			got.Name,
+			got.Description,
+			got.Archived(),
+		)
+	}
+
+	// a bucket archived since it was listed is skipped.
+	archived, err = autoArchiveBucketsTask(logger, store, leaseStore, serverplate.NewEventBus(), auditor)(ctx)
+	if err != nil || archived != 0 {
+		t.Errorf("autoArchiveBucketsTask() = got %d archived buckets and err %v, want 0", archived, err)
+	}
+}
@@ -0,0 +1,26 @@
+package bg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// failStalledFillsTask fails the bucket fills that made no progress for longer than the timeout, the buckets of a
+// server instance that stopped while filling them would never be usable otherwise. It returns the amount of fills
+// failed.
+func failStalledFillsTask(
+	bucketStore serverplate.BucketStore,
+	timeout time.Duration,
+) func(context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		failed, err := bucketStore.FailStalledFills(ctx, time.Now().Add(-timeout))
+		if err != nil {
+			return 0, fmt.Errorf("failed to fail the stalled fills: %w", err)
+		}
+
+		return failed, nil
+	}
+}
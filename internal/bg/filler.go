@@ -0,0 +1,148 @@
+package bg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/env"
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// Filler writes the values of the buckets created with a Fill in the background, a few buckets at a time and in
+// batches so the other writes of the server are not blocked while a large bucket fills.
+type Filler struct {
+	logger      *slog.Logger
+	generator   *serverplate.Generator
+	bucketStore serverplate.BucketStore
+	events      *serverplate.EventBus
+	batchSize   int
+	// heartbeat is how often a fill records it is still queued or running, well within the stall timeout so the job
+	// failing the stalled fills never fails it.
+	heartbeat time.Duration
+	// workers holds a token for every fill running, the fills started while it is full wait for their turn.
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewFiller(
+	logger *slog.Logger,
+	generator *serverplate.Generator,
+	bucketStore serverplate.BucketStore,
+	events *serverplate.EventBus,
+	cfg env.Config,
+) (*Filler, error) {
+	if cfg.FillWorkers <= 0 {
+		return nil, fmt.Errorf("the fill workers must be positive, got %d", cfg.FillWorkers)
+	}
+	if cfg.FillBatchSize <= 0 {
+		return nil, fmt.Errorf("the fill batch size must be positive, got %d", cfg.FillBatchSize)
+	}
+	if cfg.FillStallTimeout <= 0 {
+		return nil, fmt.Errorf("the fill stall timeout must be positive, got %s", cfg.FillStallTimeout)
+	}
+
+	return &Filler{
+		logger:      logger,
+		generator:   generator,
+		bucketStore: bucketStore,
+		events:      events,
+		batchSize:   cfg.FillBatchSize,
+		heartbeat:   cfg.FillStallTimeout / 3,
+		workers:     make(chan struct{}, cfg.FillWorkers),
+	}, nil
+}
+
+// Fill starts writing the values of b in the background, a fill that cannot finish is recorded on the bucket.
+func (f *Filler) Fill(b serverplate.Bucket) {
+	f.wg.Go(func() {
+		ctx := context.Background()
+		logger := f.logger.With(slog.Int("bucket.id", int(b.ID)), slog.String("bucket.name", b.Name))
+
+		// the fill writes no progress while it waits for a worker, it is kept alive until it ends instead.
+		stop := f.keepFilling(b, logger)
+		defer stop()
+
+		f.workers <- struct{}{}
+		defer func() { <-f.workers }()
+
+		err := f.fill(ctx, b)
+		if errors.Is(err, serverplate.ErrBucketNotFilling) {
+			// the bucket was removed or its fill was failed as stalled in the meantime.
+			logger.Warn("stopped filling bucket", slog.Any("err", err))
+			return
+		}
+		if err != nil {
+			logger.Error("failure filling bucket", slog.Any("err", err))
+			if err := f.bucketStore.FailFill(ctx, b, err.Error()); err != nil {
+				logger.Error("failure recording the failed fill", slog.Any("err", err))
+			}
+			return
+		}
+
+		f.events.Publish(serverplate.BucketEvent{Type: serverplate.BucketEventRemaining, BucketID: b.ID})
+		logger.Info("filled bucket")
+	})
+}
+
+func (f *Filler) fill(ctx context.Context, b serverplate.Bucket) error {
+	total, names, err := f.generator.BucketNames(ctx, b)
+	if err != nil {
+		return fmt.Errorf("failed to build the names of the bucket: %w", err)
+	}
+
+	if err := f.bucketStore.StartFill(ctx, b, int64(total)); err != nil {
+		return err
+	}
+
+	batch := make([]string, 0, min(total, f.batchSize))
+	for name := range names {
+		batch = append(batch, name)
+		if len(batch) < f.batchSize {
+			continue
+		}
+		if err := f.bucketStore.AppendFillValues(ctx, b, batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return f.bucketStore.AppendFillValues(ctx, b, batch)
+}
+
+// keepFilling records every heartbeat that the fill of b is still queued or running until stop is called.
+func (f *Filler) keepFilling(b serverplate.Bucket, logger *slog.Logger) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(f.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := f.bucketStore.KeepFilling(context.Background(), b); err != nil {
+					logger.Warn("failure keeping the fill alive", slog.Any("err", err))
+				}
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// Wait blocks until every fill started has finished.
+func (f *Filler) Wait() {
+	f.wg.Wait()
+}
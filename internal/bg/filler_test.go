@@ -0,0 +1,123 @@
+package bg
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/env"
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+)
+
+func TestFiller(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave", "calm", "eager"}, []string{"river", "mountain"})
+	bucketStore := memstore.NewBucketStore(words)
+
+	filler, err := NewFiller(
+		slog.New(slog.DiscardHandler),
+		serverplate.NewGenerator(memstore.NewPairStore(words)),
+		bucketStore,
+		serverplate.NewEventBus(),
+		env.Config{FillWorkers: 1, FillBatchSize: 4, FillStallTimeout: time.Minute},
+	)
+	if err != nil {
+		t.Fatalf("NewFiller() = unexpected error: %v", err)
+	}
+
+	b := serverplate.Bucket{Name: "filling", Fill: &serverplate.BucketFill{}}
+	b.SetFilters(serverplate.RandomPairFilters{NounInitial: "r"})
+	if err := bucketStore.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	filler.Fill(b)
+	filler.Wait()
+
+	b, err = bucketStore.OneByID(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("OneByID() = unexpected error: %v", err)
+	}
+	if b.Filling() {
+		t.Fatalf("OneByID() = got fill %+v once filled, want none", b.Fill)
+	}
+
+	var names []string
+	for range 3 {
+		name, err := bucketStore.PopName(ctx, b)
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	if want := []string{"brave-river", "calm-river", "eager-river"}; !slices.Equal(names, want) {
+		t.Errorf("PopName() = got %v want %v", names, want)
+	}
+}
+
+func TestFillerKeepsQueuedFills(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave", "calm", "eager"}, []string{"river", "mountain"})
+	bucketStore := memstore.NewBucketStore(words)
+
+	filler, err := NewFiller(
+		slog.New(slog.DiscardHandler),
+		serverplate.NewGenerator(memstore.NewPairStore(words)),
+		bucketStore,
+		serverplate.NewEventBus(),
+		env.Config{FillWorkers: 1, FillBatchSize: 4, FillStallTimeout: 30 * time.Millisecond},
+	)
+	if err != nil {
+		t.Fatalf("NewFiller() = unexpected error: %v", err)
+	}
+
+	b := serverplate.Bucket{Name: "queued", Fill: &serverplate.BucketFill{}}
+	if err := bucketStore.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	// takes the only worker so the fill stays queued for longer than the stall timeout.
+	filler.workers <- struct{}{}
+	filler.Fill(b)
+	time.Sleep(100 * time.Millisecond)
+
+	count, err := bucketStore.FailStalledFills(ctx, time.Now().Add(-30*time.Millisecond))
+	if err != nil {
+		t.Fatalf("FailStalledFills() = unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("FailStalledFills() = got %d fills failed, want none while the fill is queued", count)
+	}
+
+	<-filler.workers
+	filler.Wait()
+
+	b, err = bucketStore.OneByID(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("OneByID() = unexpected error: %v", err)
+	}
+	if b.Filling() {
+		t.Fatalf("OneByID() = got fill %+v once filled, want none", b.Fill)
+	}
+	if total, err := bucketStore.RemainingValuesTotal(ctx, b); err != nil || total != 6 {
+		t.Errorf("RemainingValuesTotal() = got %d, %v want 6", total, err)
+	}
+}
+
+func TestNewFillerInvalidConfig(t *testing.T) {
+	for _, cfg := range []env.Config{
+		{FillWorkers: 0, FillBatchSize: 10, FillStallTimeout: time.Minute},
+		{FillWorkers: 1, FillBatchSize: 0, FillStallTimeout: time.Minute},
+		{FillWorkers: 1, FillBatchSize: 10, FillStallTimeout: 0},
+	} {
+		if _, err := NewFiller(slog.New(slog.DiscardHandler), nil, nil, nil, cfg); err == nil {
+			t.Errorf("NewFiller() = got no error for %d workers, batches of %d and a stall timeout of %s",
+				cfg.FillWorkers, cfg.FillBatchSize, cfg.FillStallTimeout)
+		}
+	}
+}
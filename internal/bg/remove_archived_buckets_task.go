@@ -2,28 +2,49 @@ package bg
 
 import (
 	"context"
-	"log/slog"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/davidonium/serverplate/internal/serverplate"
 )
 
+// removeArchivedBucketsTask removes the archived buckets whose retention is over, the buckets without a retention of
+// their own use defaultRetention. A zero retention keeps the buckets forever. It returns the amount of buckets removed.
 func removeArchivedBucketsTask(
-	logger *slog.Logger,
 	bucketStore serverplate.BucketStore,
-) func(context.Context) error {
-	return func(ctx context.Context) error {
-		removedCount, err := bucketStore.RemoveBucketsArchivedForMoreThan(ctx, 3*24*time.Hour)
+	auditor *serverplate.Auditor,
+	defaultRetention time.Duration,
+) func(context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		buckets, err := bucketStore.List(ctx, serverplate.ListOptions{ArchivedOnly: true})
 		if err != nil {
-			return err
+			return 0, fmt.Errorf("failed to list the archived buckets: %w", err)
 		}
 
-		if removedCount > 0 {
-			logger.Info("removed buckets", slog.Int64("amount", removedCount))
-		} else {
-			logger.Info("no buckets were removed")
+		now := time.Now()
+
+		var removedCount int64
+		for _, b := range buckets {
+			removeAt, ok := b.RemovalTime(defaultRetention)
+			if !ok || removeAt.After(now) {
+				continue
+			}
+
+			if err := bucketStore.DeleteArchived(ctx, b.ID); err != nil {
+				if errors.Is(err, serverplate.ErrBucketNotFound) {
+					// recovered or removed since it was listed.
+					continue
+				}
+				return removedCount, fmt.Errorf("failed to remove the bucket %q: %w", b.Name, err)
+			}
+
+			removedCount++
+			if err := auditor.Record(ctx, serverplate.AuditBucketDeleted, b, serverplate.SnapshotBucket(b), nil); err != nil {
+				return removedCount, err
+			}
 		}
 
-		return nil
+		return removedCount, nil
 	}
 }
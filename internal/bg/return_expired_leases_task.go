@@ -0,0 +1,54 @@
+package bg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// returnExpiredLeasesTask puts the names reserved by leases that expired without being confirmed back into their
+// buckets. It returns the amount of names returned.
+func returnExpiredLeasesTask(
+	bucketStore serverplate.BucketStore,
+	leaseStore serverplate.LeaseStore,
+	events *serverplate.EventBus,
+	auditor *serverplate.Auditor,
+) func(context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		returned, err := leaseStore.ReturnExpired(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to return the expired leases: %w", err)
+		}
+
+		buckets := map[int32]serverplate.Bucket{}
+		for _, l := range returned {
+			events.Publish(serverplate.BucketEvent{
+				Type:     serverplate.BucketEventReleased,
+				BucketID: l.BucketID,
+				Name:     l.Name,
+			})
+
+			b, ok := buckets[l.BucketID]
+			if !ok {
+				b, err = bucketStore.OneByID(ctx, l.BucketID)
+				switch {
+				case errors.Is(err, serverplate.ErrBucketNotFound):
+					// removed since, the event is recorded without its name.
+					b = serverplate.Bucket{ID: l.BucketID}
+				case err != nil:
+					return int64(len(returned)), fmt.Errorf("failed to get the bucket of the lease %s: %w", l.ID, err)
+				}
+				buckets[l.BucketID] = b
+			}
+
+			released := serverplate.AuditedName{Name: l.Name, LeaseID: l.ID}
+			if err := auditor.Record(ctx, serverplate.AuditLeaseReleased, b, released, nil); err != nil {
+				return int64(len(returned)), err
+			}
+		}
+
+		return int64(len(returned)), nil
+	}
+}
@@ -2,13 +2,18 @@ package bg
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 
+	"github.com/davidonium/serverplate/internal/env"
 	"github.com/davidonium/serverplate/internal/serverplate"
 )
 
@@ -16,48 +21,262 @@ type Runner struct {
 	cron        *cron.Cron
 	logger      *slog.Logger
 	bucketStore serverplate.BucketStore
+	jobRunStore serverplate.JobRunStore
+	// jobLockStore makes sure a single instance runs each job when several share the same storage.
+	jobLockStore serverplate.JobLockStore
+	leaseStore   serverplate.LeaseStore
+	events       *serverplate.EventBus
+	auditor      *serverplate.Auditor
+	// owner identifies this runner on the job locks.
+	owner string
+	cfg   env.Config
+	// jobs holds the registered jobs in the order they were added.
+	jobs []*job
 }
 
-func NewRunner(logger *slog.Logger, bucketStore serverplate.BucketStore) *Runner {
+type job struct {
+	name     string
+	schedule string
+	entryID  cron.EntryID
+	// run executes the job, manual is true when it runs outside its schedule.
+	run func(manual bool)
+}
+
+func NewRunner(
+	logger *slog.Logger,
+	bucketStore serverplate.BucketStore,
+	jobRunStore serverplate.JobRunStore,
+	jobLockStore serverplate.JobLockStore,
+	leaseStore serverplate.LeaseStore,
+	events *serverplate.EventBus,
+	auditor *serverplate.Auditor,
+	cfg env.Config,
+) (*Runner, error) {
+	if cfg.JobsLockTTL <= 0 {
+		return nil, fmt.Errorf("the jobs lock ttl must be positive, got %s", cfg.JobsLockTTL)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
 	r := &Runner{
 		cron: cron.New(
 			cron.WithLogger(&cronLogger{Logger: logger.With(slog.String("service", "cron"))}),
 		),
-		logger:      logger,
-		bucketStore: bucketStore,
+		logger:       logger,
+		bucketStore:  bucketStore,
+		jobRunStore:  jobRunStore,
+		jobLockStore: jobLockStore,
+		leaseStore:   leaseStore,
+		events:       events,
+		auditor:      auditor,
+		owner:        hostname + "-" + rand.Text(),
+		cfg:          cfg,
+	}
+	if err := r.setup(); err != nil {
+		return nil, err
 	}
-	r.setup()
 
-	return r
+	return r, nil
 }
 
-func (r *Runner) setup() {
-	r.cron.AddFunc(
-		"0 * * * *",
-		r.task("remove_archived_buckets", removeArchivedBucketsTask(r.logger, r.bucketStore)),
+func (r *Runner) setup() error {
+	if err := r.register(
+		"remove_archived_buckets",
+		r.cfg.JobsRemoveArchivedBucketsSchedule,
+		removeArchivedBucketsTask(r.bucketStore, r.auditor, r.cfg.ArchivedBucketsRetention),
+	); err != nil {
+		return err
+	}
+
+	if err := r.register(
+		"auto_archive_buckets",
+		r.cfg.JobsAutoArchiveBucketsSchedule,
+		autoArchiveBucketsTask(r.logger, r.bucketStore, r.leaseStore, r.events, r.auditor),
+	); err != nil {
+		return err
+	}
+
+	if err := r.register(
+		"return_expired_leases",
+		r.cfg.JobsReturnExpiredLeasesSchedule,
+		returnExpiredLeasesTask(r.bucketStore, r.leaseStore, r.events, r.auditor),
+	); err != nil {
+		return err
+	}
+
+	return r.register(
+		"fail_stalled_fills",
+		r.cfg.JobsFailStalledFillsSchedule,
+		failStalledFillsTask(r.bucketStore, r.cfg.FillStallTimeout),
 	)
 }
 
-func (r *Runner) task(name string, f func(context.Context) error) func() {
+func (r *Runner) register(name, schedule string, f func(context.Context) (int64, error)) error {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q for the %s job: %w", schedule, name, err)
+	}
+
+	run := r.task(name, sched, f)
+	id := r.cron.Schedule(sched, cron.FuncJob(func() { run(false) }))
+
+	r.jobs = append(r.jobs, &job{name: name, schedule: schedule, entryID: id, run: run})
+	return nil
+}
+
+// task wraps f to log and record every run of it. A run is skipped while the previous one has not finished or while
+// another instance holds the lock of the job.
+func (r *Runner) task(name string, schedule cron.Schedule, f func(context.Context) (int64, error)) func(bool) {
 	if !isSnakeCase(name) {
 		panic(fmt.Sprintf("invalid cron task name '%s', task names must be snake_case.", name))
 	}
 
-	return func() {
+	var running sync.Mutex
+	return func(manual bool) {
+		if !running.TryLock() {
+			r.logger.Warn("skipping task, the previous run has not finished", slog.String("name", name))
+			return
+		}
+		defer running.Unlock()
+
+		// the changes made by the job are audited as made by it.
+		ctx := serverplate.NewContextWithAuditOrigin(context.Background(), serverplate.AuditOrigin{
+			Actor:  name,
+			Source: serverplate.AuditSourceJob,
+		})
+		ttl := r.lockTTL(schedule)
+		acquired, err := r.jobLockStore.Acquire(ctx, name, r.owner, ttl)
+		if err != nil {
+			r.logger.Error("failure acquiring task lock", slog.Any("err", err), slog.String("task", name))
+			return
+		}
+		if !acquired {
+			r.logger.Info("skipping task, another instance holds its lock", slog.String("name", name))
+			return
+		}
+
+		if manual {
+			// scheduled runs keep the lock until it expires so the other instances skip the same tick, manual runs
+			// give it back right away to not delay them.
+			defer func() {
+				if err := r.jobLockStore.Release(ctx, name, r.owner); err != nil {
+					r.logger.Error("failure releasing task lock", slog.Any("err", err), slog.String("task", name))
+				}
+			}()
+		}
+
+		stop := r.keepLock(name, ttl)
+		defer stop()
+
 		r.logger.Info("starting task", slog.String("name", name))
-		now := time.Now()
-		defer func() {
-			r.logger.Info("ending task",
-				slog.String("name", name),
-				slog.Duration("elapsed", time.Since(now)),
-			)
-		}()
-
-		ctx := context.Background()
-		if err := f(ctx); err != nil {
+		run := serverplate.JobRun{
+			Job:       name,
+			StartedAt: time.Now(),
+		}
+
+		affected, err := f(ctx)
+		run.EndedAt = time.Now()
+		run.AffectedRows = affected
+		if err != nil {
+			run.Error = err.Error()
 			r.logger.Error("failure running task", slog.Any("err", err), slog.String("task", name))
 		}
+
+		r.logger.Info("ending task",
+			slog.String("name", name),
+			slog.Duration("elapsed", run.Duration()),
+			slog.Int64("affected_rows", affected),
+		)
+
+		if err := r.jobRunStore.Record(ctx, &run); err != nil {
+			r.logger.Error("failure recording task run", slog.Any("err", err), slog.String("task", name))
+		}
+	}
+}
+
+// lockTTL returns how long a run of the job on schedule keeps its lock: JobsLockTTL at most and half the time until
+// the next run, so the lock taken on a tick is free again by the next one even on a schedule shorter than the ttl.
+func (r *Runner) lockTTL(schedule cron.Schedule) time.Duration {
+	now := time.Now()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return r.cfg.JobsLockTTL
 	}
+
+	return min(r.cfg.JobsLockTTL, next.Sub(now)/2)
+}
+
+// keepLock extends the lock of the job every half of ttl until stop is called, so that a run taking longer than the
+// ttl is not taken over by another instance.
+func (r *Runner) keepLock(name string, ttl time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				acquired, err := r.jobLockStore.Acquire(context.Background(), name, r.owner, ttl)
+				if err != nil {
+					r.logger.Error("failure extending task lock", slog.Any("err", err), slog.String("task", name))
+				} else if !acquired {
+					r.logger.Warn("task lock was taken over by another instance", slog.String("task", name))
+				}
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// Jobs returns the registered jobs with their next and last runs.
+func (r *Runner) Jobs(ctx context.Context) ([]serverplate.Job, error) {
+	jobs := make([]serverplate.Job, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		sj := serverplate.Job{
+			Name:     j.name,
+			Schedule: j.schedule,
+		}
+
+		if next := r.cron.Entry(j.entryID).Next; !next.IsZero() {
+			sj.NextRunAt = &next
+		}
+
+		last, err := r.jobRunStore.Last(ctx, j.name)
+		switch {
+		case errors.Is(err, serverplate.ErrJobRunNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to get the last run of the %s job: %w", j.name, err)
+		default:
+			sj.LastRun = &last
+		}
+
+		jobs = append(jobs, sj)
+	}
+
+	return jobs, nil
+}
+
+// RunNow starts the job in the background without waiting for its schedule.
+func (r *Runner) RunNow(name string) error {
+	for _, j := range r.jobs {
+		if j.name == name {
+			go j.run(true)
+			return nil
+		}
+	}
+
+	return serverplate.ErrJobNotFound
 }
 
 func (r *Runner) Start() {
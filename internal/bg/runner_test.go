@@ -0,0 +1,141 @@
+package bg
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/davidonium/serverplate/internal/env"
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+)
+
+// newTestRunner returns a runner sharing the job stores with the other runners built from them, like the instances
+// of a server sharing the same database.
+func newTestRunner(
+	t *testing.T,
+	jobRuns serverplate.JobRunStore,
+	jobLocks serverplate.JobLockStore,
+	lockTTL time.Duration,
+) *Runner {
+	t.Helper()
+
+	words := memstore.NewWords([]string{"brave"}, []string{"river"})
+	bucketStore := memstore.NewBucketStore(words)
+	r, err := NewRunner(
+		slog.New(slog.DiscardHandler),
+		bucketStore,
+		jobRuns,
+		jobLocks,
+		memstore.NewLeaseStore(bucketStore),
+		serverplate.NewEventBus(),
+		serverplate.NewAuditor(memstore.NewAuditStore()),
+		env.Config{
+			ArchivedBucketsRetention:          72 * time.Hour,
+			JobsRemoveArchivedBucketsSchedule: "0 * * * *",
+			JobsAutoArchiveBucketsSchedule:    "*/15 * * * *",
+			JobsReturnExpiredLeasesSchedule:   "* * * * *",
+			JobsFailStalledFillsSchedule:      "*/5 * * * *",
+			JobsLockTTL:                       lockTTL,
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewRunner() = unexpected error: %v", err)
+	}
+
+	return r
+}
+
+func assertRunCount(t *testing.T, jobRuns serverplate.JobRunStore, want int) {
+	t.Helper()
+
+	runs, err := jobRuns.Recent(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Recent() = unexpected error: %v", err)
+	}
+
+	if len(runs) != want {
+		t.Errorf("Recent() = got %d runs, want %d", len(runs), want)
+	}
+}
+
+func TestRunnerSingleRunPerTick(t *testing.T) {
+	jobRuns := memstore.NewJobRunStore()
+	jobLocks := memstore.NewJobLockStore()
+	a := newTestRunner(t, jobRuns, jobLocks, time.Minute)
+	b := newTestRunner(t, jobRuns, jobLocks, time.Minute)
+
+	a.jobs[0].run(false)
+	b.jobs[0].run(false)
+	assertRunCount(t, jobRuns, 1)
+
+	// the scheduled run of a keeps the lock, so a manual run of b is skipped until a gives it back.
+	b.jobs[0].run(true)
+	assertRunCount(t, jobRuns, 1)
+
+	a.jobs[0].run(true)
+	b.jobs[0].run(true)
+	assertRunCount(t, jobRuns, 3)
+}
+
+func TestRunnerStaleLockHandover(t *testing.T) {
+	jobRuns := memstore.NewJobRunStore()
+	jobLocks := memstore.NewJobLockStore()
+	a := newTestRunner(t, jobRuns, jobLocks, 50*time.Millisecond)
+	b := newTestRunner(t, jobRuns, jobLocks, 50*time.Millisecond)
+
+	a.jobs[0].run(false)
+	time.Sleep(100 * time.Millisecond)
+	b.jobs[0].run(false)
+
+	assertRunCount(t, jobRuns, 2)
+}
+
+func TestRunnerLockTTL(t *testing.T) {
+	r := newTestRunner(t, memstore.NewJobRunStore(), memstore.NewJobLockStore(), 5*time.Minute)
+
+	cases := []struct {
+		Schedule string
+		Max      time.Duration
+	}{
+		// the lock of a job running every minute is free again by its next run.
+		{Schedule: "* * * * *", Max: 30 * time.Second},
+		{Schedule: "@every 4m", Max: 2 * time.Minute},
+		// schedules longer than twice the ttl keep the lock for the ttl.
+		{Schedule: "0 * * * *", Max: 5 * time.Minute},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Schedule, func(t *testing.T) {
+			sched, err := cron.ParseStandard(tt.Schedule)
+			if err != nil {
+				t.Fatalf("ParseStandard() = unexpected error: %v", err)
+			}
+
+			if got := r.lockTTL(sched); got <= 0 || got > tt.Max {
+				t.Errorf("lockTTL() = got %s, want a positive ttl up to %s", got, tt.Max)
+			}
+		})
+	}
+}
+
+func TestNewRunnerInvalidSchedule(t *testing.T) {
+	words := memstore.NewWords([]string{"brave"}, []string{"river"})
+	bucketStore := memstore.NewBucketStore(words)
+	_, err := NewRunner(
+		slog.New(slog.DiscardHandler),
+		bucketStore,
+		memstore.NewJobRunStore(),
+		memstore.NewJobLockStore(),
+		memstore.NewLeaseStore(bucketStore),
+		serverplate.NewEventBus(),
+		serverplate.NewAuditor(memstore.NewAuditStore()),
+		env.Config{JobsRemoveArchivedBucketsSchedule: "every hour", JobsLockTTL: time.Minute},
+	)
+	if err == nil {
+		t.Error("NewRunner() = expected an invalid schedule to fail")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/amacneil/dbmate/v2/pkg/dbmate"
 	// import sqlite specific driver for running migrations in integration testing.
 	_ "github.com/amacneil/dbmate/v2/pkg/driver/sqlite"
+	"github.com/jmoiron/sqlx"
 
 	embed "github.com/davidonium/serverplate"
 
@@ -17,6 +18,13 @@ import (
 
 func Run(t *testing.T, f func(*testing.T, *sqlitestore.DBPool)) {
 	t.Helper()
+
+	f(t, NewSQLite(t))
+}
+
+// NewSQLite creates a migrated sqlite database in a temporary directory that is closed once the test finishes.
+func NewSQLite(t *testing.T) *sqlitestore.DBPool {
+	t.Helper()
 	if testing.Short() {
 		t.Skip("database tests are skipped for short testing")
 	}
@@ -56,5 +64,24 @@ func Run(t *testing.T, f func(*testing.T, *sqlitestore.DBPool)) {
 		t.Fatalf("failed to apply migrations: %v", err)
 	}
 
-	f(t, pool)
+	return pool
+}
+
+// SeedWords inserts the given adjectives and nouns as if they came from the seed files.
+func SeedWords(t *testing.T, db *sqlx.DB, adjectives, nouns []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	tables := map[string][]string{
+		"adjectives": adjectives,
+		"nouns":      nouns,
+	}
+	for table, values := range tables {
+		query := db.Rebind("INSERT INTO " + table + " (value, from_seed) VALUES (?, 1)")
+		for _, v := range values {
+			if _, err := db.ExecContext(ctx, query, v); err != nil {
+				t.Fatalf("failed to seed %s with %q: %v", table, v, err)
+			}
+		}
+	}
 }
@@ -1,10 +1,13 @@
 package env
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 type Config struct {
 	ListenAddr             string   `env:"LISTEN_ADDR"              envDefault:":8080"`
-	DatabaseURL            *url.URL `env:"DATABASE_URL,required"`
+	DatabaseURL            *url.URL `env:"DATABASE_URL"`
 	Debug                  bool     `env:"DEBUG"                    envDefault:"false"`
 	LogFormat              string   `env:"LOG_FORMAT"               envDefault:"text"`
 	LogLevel               string   `env:"LOG_LEVEL"                envDefault:"info"`
@@ -13,4 +16,35 @@ type Config struct {
 	AssetsWatch            bool     `env:"ASSETS_MANIFEST_WATCH"    envDefault:"false"`
 	AssetsManifestLocation string   `env:"ASSETS_MANIFEST_LOCATION"`
 	AssetsManifestFS       string   `env:"ASSETS_MANIFEST_FS"       envDefault:"os"`
+	// ArchivedBucketsRetention is how long archived buckets are kept before being removed, unless they have a
+	// retention of their own. Zero keeps them forever.
+	ArchivedBucketsRetention time.Duration `env:"ARCHIVED_BUCKETS_RETENTION" envDefault:"72h"`
+	// JobsRemoveArchivedBucketsSchedule is the cron expression the job removing the archived buckets runs on.
+	JobsRemoveArchivedBucketsSchedule string `env:"JOBS_REMOVE_ARCHIVED_BUCKETS_SCHEDULE" envDefault:"0 * * * *"`
+	// JobsAutoArchiveBucketsSchedule is the cron expression the job archiving the expired and exhausted buckets runs on.
+	JobsAutoArchiveBucketsSchedule string `env:"JOBS_AUTO_ARCHIVE_BUCKETS_SCHEDULE" envDefault:"*/15 * * * *"`
+	// JobsReturnExpiredLeasesSchedule is the cron expression the job returning the names of the expired leases runs on.
+	JobsReturnExpiredLeasesSchedule string `env:"JOBS_RETURN_EXPIRED_LEASES_SCHEDULE" envDefault:"* * * * *"`
+	// JobsFailStalledFillsSchedule is the cron expression the job failing the bucket fills that stopped making
+	// progress runs on.
+	JobsFailStalledFillsSchedule string `env:"JOBS_FAIL_STALLED_FILLS_SCHEDULE" envDefault:"*/5 * * * *"`
+	// JobsLockTTL is the longest an instance keeps the lock of a job it ran on schedule, so the other instances sharing
+	// the storage skip the same tick. Each job keeps it at most half the time until its next run, so the lock is free
+	// again by then even on schedules shorter than the ttl. It must be longer than the clock drift between instances.
+	JobsLockTTL time.Duration `env:"JOBS_LOCK_TTL" envDefault:"5m"`
+	// LeaseTTL is how long a reserved name waits to be confirmed when the reservation does not ask for a ttl.
+	LeaseTTL time.Duration `env:"LEASE_TTL" envDefault:"5m"`
+	// LeaseMaxTTL is the longest ttl a reservation can ask for.
+	LeaseMaxTTL time.Duration `env:"LEASE_MAX_TTL" envDefault:"24h"`
+	// FillWorkers is how many buckets are filled at the same time, the others wait for their turn.
+	FillWorkers int `env:"FILL_WORKERS" envDefault:"2"`
+	// FillBatchSize is how many values a fill writes in each transaction, the progress is recorded between them.
+	FillBatchSize int `env:"FILL_BATCH_SIZE" envDefault:"10000"`
+	// FillStallTimeout is how long a fill can go without progress before the job failing the stalled fills fails it,
+	// like the fills of an instance that stopped. The fills record they are alive a few times within it, also while
+	// they wait for a worker.
+	FillStallTimeout time.Duration `env:"FILL_STALL_TIMEOUT" envDefault:"15m"`
+	// TrustProxyHeaders takes the actor, client ip and request id recorded by the audit log from the X-Forwarded-User,
+	// X-Forwarded-For and X-Request-ID headers. Only enable it behind a proxy that sets them, clients can forge them.
+	TrustProxyHeaders bool `env:"TRUST_PROXY_HEADERS" envDefault:"false"`
 }
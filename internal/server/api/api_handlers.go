@@ -4,21 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/davidonium/serverplate/internal/serverplate"
 )
 
-var ErrArchived = errors.New("the bucket is archived")
+// BaseURL is the path the API is served under.
+const BaseURL = "/api"
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
 
 type Handlers struct {
-	generator   *serverplate.Generator
+	generator *serverplate.Generator
+	// buckets makes every change to the buckets, bucketStore is only used to read them.
+	buckets     *serverplate.BucketService
 	bucketStore serverplate.BucketStore
+	events      *serverplate.EventBus
+	auditStore  serverplate.AuditStore
+	jobRunner   serverplate.JobRunner
+	// archivedRetention is how long archived buckets without a retention of their own are kept.
+	archivedRetention time.Duration
 }
 
-func New(generator *serverplate.Generator, bucketStore serverplate.BucketStore) *Handlers {
+func New(
+	generator *serverplate.Generator,
+	buckets *serverplate.BucketService,
+	bucketStore serverplate.BucketStore,
+	events *serverplate.EventBus,
+	auditStore serverplate.AuditStore,
+	jobRunner serverplate.JobRunner,
+	archivedRetention time.Duration,
+) *Handlers {
 	return &Handlers{
-		generator:   generator,
-		bucketStore: bucketStore,
+		generator:         generator,
+		buckets:           buckets,
+		bucketStore:       bucketStore,
+		events:            events,
+		auditStore:        auditStore,
+		jobRunner:         jobRunner,
+		archivedRetention: archivedRetention,
 	}
 }
 
@@ -44,6 +73,189 @@ func bucketArchived() ProblemDetail {
 	}
 }
 
+// bucketExhausted returns a ProblemDetail for 409 "bucket exhausted" conflicts.
+// The return value can be type-converted to any *409JSONResponse type.
+func bucketExhausted() ProblemDetail {
+	return ProblemDetail{
+		Status: 409,
+		Type:   "bucket_exhausted",
+		Title:  "Operation conflict. Bucket is exhausted.",
+		Detail: new("Every name of the bucket has already been popped."),
+	}
+}
+
+// bucketFilling returns a ProblemDetail for 409 conflicts caused by a bucket whose names are still being written or
+// failed to be. The return value can be type-converted to any *409JSONResponse type.
+func bucketFilling(b serverplate.Bucket) ProblemDetail {
+	if b.Fill.Failed() {
+		return ProblemDetail{
+			Status: 409,
+			Type:   "bucket_fill_failed",
+			Title:  "Operation conflict. Bucket fill failed.",
+			Detail: new(fmt.Sprintf("The names of the bucket could not be written: %s", b.Fill.Error)),
+		}
+	}
+
+	return ProblemDetail{
+		Status: 409,
+		Type:   "bucket_filling",
+		Title:  "Operation conflict. Bucket is filling.",
+		Detail: new(fmt.Sprintf(
+			"The names of the bucket are being written, %d of %d done.",
+			b.Fill.Done,
+			b.Fill.Total,
+		)),
+	}
+}
+
+// bucketNameTaken returns a ProblemDetail for 409 errors caused by a name used by another bucket.
+// The return value can be type-converted to any *409JSONResponse type.
+func bucketNameTaken() ProblemDetail {
+	return ProblemDetail{
+		Status: 409,
+		Type:   "name_taken",
+		Title:  "Operation conflict. Bucket name is taken.",
+		Detail: new("Another bucket already has the requested name."),
+	}
+}
+
+// validationFailed returns a ProblemDetail for 400 errors caused by values rejected by the validation, see
+// serverplate.IsInvalidInput. Every rejected field is listed in its errors.
+// The return value can be type-converted to any *400JSONResponse type.
+func validationFailed(err error) ProblemDetail {
+	problem := ProblemDetail{
+		Status: 400,
+		Type:   "validation_error",
+		Title:  "Validation failed",
+		Detail: new(err.Error()),
+	}
+
+	if fields := serverplate.FieldErrors(err); len(fields) > 0 {
+		params := make([]InvalidParam, 0, len(fields))
+		for _, f := range fields {
+			params = append(params, InvalidParam{Name: f.Field, Reason: f.Reason})
+		}
+		problem.Errors = &params
+	}
+
+	return problem
+}
+
+// wordsVersionMismatch returns a ProblemDetail for 409 errors caused by seeded names requested for other word lists.
+// The return value can be type-converted to any *409JSONResponse type.
+func wordsVersionMismatch(err error) ProblemDetail {
+	return ProblemDetail{
+		Status: 409,
+		Type:   "words_version_mismatch",
+		Title:  "Operation conflict. Word lists changed.",
+		Detail: new(err.Error()),
+	}
+}
+
+// generateOptions maps the filters of a request to the options used to look for names.
+func generateOptions(f *Filters) serverplate.GenerateOptions {
+	opts := serverplate.GenerateOptions{
+		AdjectiveInitial: deref(f.AdjectiveInitial),
+		NounInitial:      deref(f.NounInitial),
+		Alliterative:     deref(f.Alliterative),
+		Prefix:           deref(f.Prefix),
+		Suffix:           deref(f.Suffix),
+		ExcludedChars:    deref(f.ExcludedChars),
+		MinLength:        deref(f.MinLength),
+	}
+
+	if f.LengthEnabled != nil && *f.LengthEnabled {
+		opts.LengthEnabled = true
+		opts.LengthValue = deref(f.Length)
+		opts.LengthMode = serverplate.LengthModeUpto
+		if f.LengthMode != nil {
+			opts.LengthMode = serverplate.LengthMode(*f.LengthMode)
+		}
+	}
+
+	return opts
+}
+
+// bucketFilters maps the filters of a bucket to their API representation, unset filters are returned as null.
+func bucketFilters(b serverplate.Bucket) BucketFilters {
+	filters := BucketFilters{
+		LengthEnabled:    b.FilterLengthEnabled,
+		Alliterative:     b.FilterAlliterative,
+		MinLength:        nonZero(b.FilterMinLength),
+		AdjectiveInitial: nonZero(b.FilterAdjectiveInitial),
+		NounInitial:      nonZero(b.FilterNounInitial),
+		Prefix:           nonZero(b.FilterPrefix),
+		Suffix:           nonZero(b.FilterSuffix),
+		ExcludedChars:    nonZero(b.FilterExcludedChars),
+	}
+
+	if b.FilterLengthEnabled {
+		filters.Length = &b.FilterLengthValue
+		filters.LengthMode = new(BucketFiltersLengthMode(b.FilterLengthMode))
+	}
+
+	return filters
+}
+
+// bucketFill maps the progress of a bucket fill, nil once every name is written.
+func bucketFill(b serverplate.Bucket) *BucketFill {
+	if b.Fill == nil {
+		return nil
+	}
+
+	fill := &BucketFill{
+		State: Filling,
+		Total: b.Fill.Total,
+		Done:  b.Fill.Done,
+	}
+	if b.Fill.Failed() {
+		fill.State = Failed
+		fill.Error = new(b.Fill.Error)
+	}
+
+	return fill
+}
+
+// bucketLabels returns the labels of the bucket, never nil so they are always encoded as an object.
+func bucketLabels(b serverplate.Bucket) Labels {
+	if b.Labels == nil {
+		return Labels{}
+	}
+	return b.Labels
+}
+
+// bucketRetention returns the retention of the bucket as a duration string, nil when it uses the server default.
+func bucketRetention(b serverplate.Bucket) *string {
+	if b.Retention == nil {
+		return nil
+	}
+	return new(serverplate.FormatRetention(*b.Retention))
+}
+
+// removalAt returns when the archived bucket will be removed, nil when it is not archived or kept forever.
+func (s *Handlers) removalAt(b serverplate.Bucket) *time.Time {
+	if at, ok := b.RemovalTime(s.archivedRetention); ok {
+		return &at
+	}
+	return nil
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+func nonZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
 func (s *Handlers) GenerateName(
 	ctx context.Context,
 	request GenerateNameRequestObject,
@@ -51,28 +263,16 @@ func (s *Handlers) GenerateName(
 	opts := serverplate.GenerateOptions{}
 
 	if request.Body != nil && request.Body.Filters != nil {
-		filters := request.Body.Filters
-
-		if filters.LengthEnabled != nil && *filters.LengthEnabled {
-			opts.LengthEnabled = true
-
-			if filters.Length == nil {
-				return GenerateName400JSONResponse{
-					Status: 400,
-					Type:   "validation_error",
-					Title:  "Validation failed",
-					Detail: new("length is required when length_enabled is true"),
-				}, nil
-			}
+		opts = generateOptions(request.Body.Filters)
+	}
 
-			opts.LengthValue = *filters.Length
+	if request.Body != nil {
+		opts.Seed = request.Body.Seed
+		opts.WordsVersion = deref(request.Body.WordsVersion)
+	}
 
-			if filters.LengthMode != nil {
-				opts.LengthMode = serverplate.LengthMode(*filters.LengthMode)
-			} else {
-				opts.LengthMode = serverplate.LengthModeUpto
-			}
-		}
+	if err := serverplate.ValidateFilters(opts.Filters()); err != nil {
+		return GenerateName400JSONResponse(validationFailed(err)), nil
 	}
 
 	res, err := s.generator.Generate(ctx, opts)
@@ -83,119 +283,369 @@ func (s *Handlers) GenerateName(
 				Type:   "no_matches",
 				Title:  "No names match the specified filters",
 				Detail: new(
-					"The length constraints are too restrictive. No adjective-noun combinations match the criteria.",
+					"The filters are too restrictive. No adjective-noun combinations match the criteria.",
 				),
 			}, nil
 		}
+		if errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+			return GenerateName409JSONResponse(wordsVersionMismatch(err)), nil
+		}
 		return nil, err
 	}
 
 	return GenerateName200JSONResponse{
-		Name: res.Name,
+		Name:         res.Name,
+		WordsVersion: nonZero(res.WordsVersion),
 	}, nil
 }
 
-func (s *Handlers) CreateBucket(
+func (s *Handlers) DeriveName(
 	ctx context.Context,
-	request CreateBucketRequestObject,
-) (CreateBucketResponseObject, error) {
+	request DeriveNameRequestObject,
+) (DeriveNameResponseObject, error) {
 	if request.Body == nil {
 		return nil, fmt.Errorf("request body is required")
 	}
 
-	b := serverplate.Bucket{
-		Name: request.Body.Name,
+	opts := serverplate.DeriveOptions{
+		Key:          request.Body.Key,
+		Namespace:    deref(request.Body.Namespace),
+		SuffixLength: deref(request.Body.SuffixLength),
+		WordsVersion: deref(request.Body.WordsVersion),
 	}
 
-	if request.Body.Description != nil {
-		b.Description = *request.Body.Description
+	if request.Body.Filters != nil {
+		opts.Filters = generateOptions(request.Body.Filters).Filters()
 	}
 
-	if request.Body.Filters != nil {
-		if request.Body.Filters.LengthEnabled != nil {
-			b.FilterLengthEnabled = *request.Body.Filters.LengthEnabled
+	if err := serverplate.ValidateDeriveOptions(opts); err != nil {
+		return DeriveName400JSONResponse(validationFailed(err)), nil
+	}
+
+	res, err := s.generator.Derive(ctx, opts)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrNoMatchingPairs) {
+			return DeriveName400JSONResponse{
+				Status: 400,
+				Type:   "no_matches",
+				Title:  "No names match the specified filters",
+				Detail: new(
+					"The filters are too restrictive. No adjective-noun combinations match the criteria.",
+				),
+			}, nil
+		}
+		if errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+			return DeriveName409JSONResponse(wordsVersionMismatch(err)), nil
 		}
+		return nil, err
+	}
 
-		if b.FilterLengthEnabled {
-			if request.Body.Filters.Length != nil {
-				b.FilterLengthValue = *request.Body.Filters.Length
-			}
-			if request.Body.Filters.LengthMode != nil {
-				b.FilterLengthMode = serverplate.LengthMode(*request.Body.Filters.LengthMode)
-			} else {
-				b.FilterLengthMode = serverplate.LengthModeUpto
-			}
+	return DeriveName200JSONResponse{
+		Name:         res.Name,
+		WordsVersion: res.WordsVersion,
+	}, nil
+}
+
+// defaultSamples is the number of names picked by SampleNames when the request does not say.
+const defaultSamples = 1000
+
+func (s *Handlers) SampleNames(
+	ctx context.Context,
+	request SampleNamesRequestObject,
+) (SampleNamesResponseObject, error) {
+	samples := defaultSamples
+	var f serverplate.RandomPairFilters
+
+	if request.Body != nil {
+		if request.Body.Samples != nil {
+			samples = *request.Body.Samples
+		}
+
+		if request.Body.Filters != nil {
+			f = generateOptions(request.Body.Filters).Filters()
+		}
+	}
+
+	if err := serverplate.ValidateFilters(f); err != nil {
+		return SampleNames400JSONResponse(validationFailed(err)), nil
+	}
+
+	stats, err := s.generator.Sample(ctx, f, samples)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrInvalidSamples) {
+			return SampleNames400JSONResponse(validationFailed(err)), nil
+		}
+		if errors.Is(err, serverplate.ErrNoMatchingPairs) {
+			return SampleNames400JSONResponse{
+				Status: 400,
+				Type:   "no_matches",
+				Title:  "No names match the specified filters",
+				Detail: new(
+					"The filters are too restrictive. No adjective-noun combinations match the criteria.",
+				),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return SampleNames200JSONResponse{
+		Samples:       stats.Samples,
+		PairCount:     stats.PairCount,
+		DistinctPairs: stats.DistinctPairs,
+		Adjectives:    wordDistribution(stats.Adjectives),
+		Nouns:         wordDistribution(stats.Nouns),
+	}, nil
+}
+
+func wordDistribution(d serverplate.WordDistribution) WordDistribution {
+	words := make([]WordFrequency, len(d.Words))
+	for i, w := range d.Words {
+		words[i] = WordFrequency{
+			Word:     w.Word,
+			Count:    w.Count,
+			Expected: w.Expected,
+		}
+	}
+
+	return WordDistribution{
+		Words:            words,
+		ChiSquare:        d.ChiSquare,
+		DegreesOfFreedom: d.DegreesOfFreedom,
+	}
+}
+
+// defaultPreviewSamples is the number of sample names returned by PreviewBucket when the request does not say.
+const defaultPreviewSamples = 10
+
+func (s *Handlers) PreviewBucket(
+	ctx context.Context,
+	request PreviewBucketRequestObject,
+) (PreviewBucketResponseObject, error) {
+	samples := defaultPreviewSamples
+	var f serverplate.RandomPairFilters
+
+	if request.Body != nil {
+		if request.Body.Samples != nil {
+			samples = *request.Body.Samples
 		}
+		if request.Body.Filters != nil {
+			f = generateOptions(request.Body.Filters).Filters()
+		}
+	}
+
+	if err := serverplate.ValidateFilters(f); err != nil {
+		return PreviewBucket400JSONResponse(validationFailed(err)), nil
 	}
 
-	if err := s.bucketStore.Create(ctx, &b); err != nil {
+	preview, err := s.generator.Preview(ctx, f, samples)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrInvalidSamples) {
+			return PreviewBucket400JSONResponse(validationFailed(err)), nil
+		}
 		return nil, err
 	}
 
-	if err := s.bucketStore.FillBucketValues(ctx, b, b.Filters()); err != nil {
+	lengths := make([]LengthCount, len(preview.Lengths))
+	for i, l := range preview.Lengths {
+		lengths[i] = LengthCount{Length: l.Length, Count: l.Count}
+	}
+
+	return PreviewBucket200JSONResponse{
+		PairCount: preview.PairCount,
+		Samples:   preview.Samples,
+		Lengths:   lengths,
+	}, nil
+}
+
+func (s *Handlers) CreateBucket(
+	ctx context.Context,
+	request CreateBucketRequestObject,
+) (CreateBucketResponseObject, error) {
+	if request.Body == nil {
+		return nil, fmt.Errorf("request body is required")
+	}
+
+	opts := serverplate.CreateBucketOptions{
+		Name:                 request.Body.Name,
+		Description:          deref(request.Body.Description),
+		Retention:            deref(request.Body.Retention),
+		ExpiresAt:            deref(request.Body.ExpiresAt),
+		ArchiveWhenExhausted: deref(request.Body.ArchiveWhenExhausted),
+		Lazy:                 deref(request.Body.Lazy),
+		Seed:                 request.Body.Seed,
+		WordsVersion:         deref(request.Body.WordsVersion),
+	}
+	if request.Body.Filters != nil {
+		opts.Filters = generateOptions(request.Body.Filters).Filters()
+	}
+	if request.Body.Labels != nil {
+		opts.Labels = *request.Body.Labels
+	}
+
+	b, err := s.buckets.Create(ctx, opts)
+	if err != nil {
+		switch {
+		case serverplate.IsInvalidInput(err):
+			return CreateBucket400JSONResponse(validationFailed(err)), nil
+		case errors.Is(err, serverplate.ErrBucketNameTaken):
+			return CreateBucket409JSONResponse(bucketNameTaken()), nil
+		case errors.Is(err, serverplate.ErrWordsVersionMismatch):
+			return CreateBucket409JSONResponse(wordsVersionMismatch(err)), nil
+		case errors.Is(err, serverplate.ErrNoMatchingPairs):
+			return CreateBucket422JSONResponse{
+				Status: 422,
+				Type:   "no_matches",
+				Title:  "No names match the specified filters",
+				Detail: new("No adjective-noun combinations match the filters, the bucket would be empty."),
+			}, nil
+		}
 		return nil, err
 	}
 
+	// the names of a lazy bucket are ready right away, the others are still being written.
+	if !b.Lazy {
+		return CreateBucket202JSONResponse(s.bucketDetails(b, 0)), nil
+	}
+
 	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
 	}
 
-	response := CreateBucket201JSONResponse{
-		Id:             b.ID,
-		Name:           b.Name,
-		Description:    b.Description,
-		CreatedAt:      b.CreatedAt,
-		UpdatedAt:      b.UpdatedAt,
-		ArchivedAt:     b.ArchivedAt,
-		RemainingPairs: remaining,
-	}
+	return CreateBucket201JSONResponse(s.bucketDetails(b, remaining)), nil
+}
 
-	response.Filters.LengthEnabled = b.FilterLengthEnabled
-	if b.FilterLengthEnabled {
-		response.Filters.Length = &b.FilterLengthValue
-		lengthMode := BucketDetailsFiltersLengthMode(b.FilterLengthMode)
-		response.Filters.LengthMode = &lengthMode
+func (s *Handlers) bucketDetails(b serverplate.Bucket, remaining int64) BucketDetails {
+	return BucketDetails{
+		Id:                   b.ID,
+		Name:                 b.Name,
+		Description:          b.Description,
+		CreatedAt:            b.CreatedAt,
+		UpdatedAt:            b.UpdatedAt,
+		ArchivedAt:           b.ArchivedAt,
+		RemainingPairs:       remaining,
+		Filters:              bucketFilters(b),
+		Labels:               bucketLabels(b),
+		Retention:            bucketRetention(b),
+		RemovalAt:            s.removalAt(b),
+		ExpiresAt:            b.ExpiresAt,
+		ArchiveWhenExhausted: b.ArchiveWhenExhausted,
+		Seed:                 b.Seed,
+		WordsVersion:         nonZero(b.WordsVersion),
+		Lazy:                 b.Lazy,
+		Fill:                 bucketFill(b),
 	}
-
-	return response, nil
 }
 
 func (s *Handlers) ListBuckets(
 	ctx context.Context,
 	request ListBucketsRequestObject,
 ) (ListBucketsResponseObject, error) {
-	archived := request.Params.Archived != nil
+	params := request.Params
+
+	opts := serverplate.ListOptions{
+		ArchivedOnly:     params.Archived != nil,
+		Search:           deref(params.Q),
+		SortBy:           serverplate.BucketSortField(deref(params.Sort)),
+		SortDesc:         deref(params.Order) == Desc,
+		Limit:            defaultListLimit,
+		Offset:           deref(params.Offset),
+		IncludeRemaining: deref(params.IncludeRemaining),
+	}
+	if params.Limit != nil {
+		opts.Limit = *params.Limit
+	}
+
+	if params.Selector != nil {
+		selector, err := serverplate.ParseLabels(*params.Selector)
+		if err != nil {
+			return ListBuckets400JSONResponse(validationFailed(err)), nil
+		}
+		opts.LabelSelector = selector
+	}
 
-	buckets, err := s.bucketStore.List(ctx, serverplate.ListOptions{
-		ArchivedOnly: archived,
-	})
+	if opts.Limit < 1 || opts.Limit > maxListLimit || opts.Offset < 0 || !opts.SortBy.Valid() {
+		return ListBuckets400JSONResponse{
+			Status: 400,
+			Type:   "validation_error",
+			Title:  "Validation failed",
+			Detail: new(fmt.Sprintf(
+				"limit must be between 1 and %d, offset must not be negative and sort must be a known field",
+				maxListLimit,
+			)),
+		}, nil
+	}
+
+	// one more bucket than requested is listed to know whether there is a next page.
+	limit := opts.Limit
+	opts.Limit++
+
+	buckets, err := s.bucketStore.List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var items []BucketListItem
+	var next *string
+	if len(buckets) > limit {
+		buckets = buckets[:limit]
+		next = new(nextListLink(params, limit, opts.Offset+limit))
+	}
+
+	items := []BucketListItem{}
 	for _, b := range buckets {
-		items = append(items, BucketListItem{
+		item := BucketListItem{
 			Id:          b.ID,
 			Name:        b.Name,
 			Description: b.Description,
 			CreatedAt:   b.CreatedAt,
 			UpdatedAt:   b.UpdatedAt,
 			ArchivedAt:  b.ArchivedAt,
-		})
+			Labels:      bucketLabels(b),
+		}
+		if opts.IncludeRemaining {
+			item.RemainingPairs = &b.RemainingValues
+		}
+		items = append(items, item)
 	}
 
 	return ListBuckets200JSONResponse{
 		Buckets: items,
+		Next:    next,
 	}, nil
 }
 
+// nextListLink returns the link to the page of buckets starting at offset, keeping the rest of the parameters.
+func nextListLink(params ListBucketsParams, limit, offset int) string {
+	q := url.Values{}
+	if params.Archived != nil {
+		q.Set("archived", *params.Archived)
+	}
+	if params.Q != nil {
+		q.Set("q", *params.Q)
+	}
+	if params.Selector != nil {
+		q.Set("selector", *params.Selector)
+	}
+	if params.Sort != nil {
+		q.Set("sort", string(*params.Sort))
+	}
+	if params.Order != nil {
+		q.Set("order", string(*params.Order))
+	}
+	if params.IncludeRemaining != nil {
+		q.Set("include_remaining", strconv.FormatBool(*params.IncludeRemaining))
+	}
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+
+	return BaseURL + "/v1alpha1/buckets?" + q.Encode()
+}
+
 func (s *Handlers) GetBucketDetails(
 	ctx context.Context,
 	request GetBucketDetailsRequestObject,
 ) (GetBucketDetailsResponseObject, error) {
-	b, err := s.bucketStore.OneByID(ctx, request.Id)
+	b, err := s.buckets.Find(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, serverplate.ErrBucketNotFound) {
 			return GetBucketDetails404JSONResponse(bucketNotFound()), nil
@@ -208,31 +658,14 @@ func (s *Handlers) GetBucketDetails(
 		return nil, err
 	}
 
-	response := GetBucketDetails200JSONResponse{
-		Id:             b.ID,
-		Name:           b.Name,
-		Description:    b.Description,
-		CreatedAt:      b.CreatedAt,
-		UpdatedAt:      b.UpdatedAt,
-		ArchivedAt:     b.ArchivedAt,
-		RemainingPairs: remaining,
-	}
-
-	response.Filters.LengthEnabled = b.FilterLengthEnabled
-	if b.FilterLengthEnabled {
-		response.Filters.Length = &b.FilterLengthValue
-		lengthMode := BucketDetailsFiltersLengthMode(b.FilterLengthMode)
-		response.Filters.LengthMode = &lengthMode
-	}
-
-	return response, nil
+	return GetBucketDetails200JSONResponse(s.bucketDetails(b, remaining)), nil
 }
 
 func (s *Handlers) PopBucketName(
 	ctx context.Context,
 	request PopBucketNameRequestObject,
 ) (PopBucketNameResponseObject, error) {
-	b, err := s.bucketStore.OneByID(ctx, request.Id)
+	b, err := s.buckets.Find(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, serverplate.ErrBucketNotFound) {
 			return PopBucketName404JSONResponse(bucketNotFound()), nil
@@ -240,12 +673,18 @@ func (s *Handlers) PopBucketName(
 		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
 	}
 
-	if b.Archived() {
-		return PopBucketName409JSONResponse(bucketArchived()), nil
-	}
-
-	name, err := s.bucketStore.PopName(ctx, b)
+	name, err := s.buckets.Pop(ctx, b)
 	if err != nil {
+		switch {
+		case errors.Is(err, serverplate.ErrBucketArchived):
+			return PopBucketName409JSONResponse(bucketArchived()), nil
+		case errors.Is(err, serverplate.ErrBucketFilling):
+			return PopBucketName409JSONResponse(bucketFilling(b)), nil
+		case errors.Is(err, serverplate.ErrBucketExhausted):
+			return PopBucketName409JSONResponse(bucketExhausted()), nil
+		case errors.Is(err, serverplate.ErrWordsVersionMismatch):
+			return PopBucketName409JSONResponse(wordsVersionMismatch(err)), nil
+		}
 		return nil, fmt.Errorf("failed to pop a name from the bucket: %w", err)
 	}
 
@@ -254,6 +693,82 @@ func (s *Handlers) PopBucketName(
 	}, nil
 }
 
+func (s *Handlers) ReserveBucketName(
+	ctx context.Context,
+	request ReserveBucketNameRequestObject,
+) (ReserveBucketNameResponseObject, error) {
+	var ttl string
+	if request.Body != nil {
+		ttl = deref(request.Body.Ttl)
+	}
+
+	b, err := s.buckets.Find(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrBucketNotFound) {
+			return ReserveBucketName404JSONResponse(bucketNotFound()), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
+	}
+
+	l, err := s.buckets.Reserve(ctx, b, ttl)
+	if err != nil {
+		switch {
+		case serverplate.IsInvalidInput(err):
+			return ReserveBucketName400JSONResponse(validationFailed(err)), nil
+		case errors.Is(err, serverplate.ErrBucketArchived):
+			return ReserveBucketName409JSONResponse(bucketArchived()), nil
+		case errors.Is(err, serverplate.ErrBucketFilling):
+			return ReserveBucketName409JSONResponse(bucketFilling(b)), nil
+		case errors.Is(err, serverplate.ErrBucketExhausted):
+			return ReserveBucketName409JSONResponse(bucketExhausted()), nil
+		case errors.Is(err, serverplate.ErrWordsVersionMismatch):
+			return ReserveBucketName409JSONResponse(wordsVersionMismatch(err)), nil
+		}
+		return nil, fmt.Errorf("failed to reserve a name from the bucket: %w", err)
+	}
+
+	return ReserveBucketName201JSONResponse(toLease(l)), nil
+}
+
+func (s *Handlers) ConfirmLease(
+	ctx context.Context,
+	request ConfirmLeaseRequestObject,
+) (ConfirmLeaseResponseObject, error) {
+	l, err := s.buckets.ConfirmLease(ctx, request.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, serverplate.ErrLeaseNotFound):
+			return ConfirmLease404JSONResponse{
+				Status: 404,
+				Type:   "not_found",
+				Title:  "Lease not found",
+				Detail: new("The requested lease does not exist or it expired and its name went back to the bucket"),
+			}, nil
+		case errors.Is(err, serverplate.ErrLeaseExpired):
+			return ConfirmLease409JSONResponse{
+				Status: 409,
+				Type:   "lease_expired",
+				Title:  "Operation conflict. Lease expired.",
+				Detail: new("The lease expired before being confirmed, its name goes back to the bucket."),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to confirm the lease: %w", err)
+	}
+
+	return ConfirmLease200JSONResponse(toLease(l)), nil
+}
+
+func toLease(l serverplate.Lease) Lease {
+	return Lease{
+		Id:          l.ID,
+		BucketId:    l.BucketID,
+		Name:        l.Name,
+		ExpiresAt:   l.ExpiresAt,
+		ConfirmedAt: l.ConfirmedAt,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
 func (s *Handlers) UpdateBucket(
 	ctx context.Context,
 	request UpdateBucketRequestObject,
@@ -262,7 +777,7 @@ func (s *Handlers) UpdateBucket(
 		return nil, fmt.Errorf("request body is required")
 	}
 
-	b, err := s.bucketStore.OneByID(ctx, request.Id)
+	b, err := s.buckets.Find(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, serverplate.ErrBucketNotFound) {
 			return UpdateBucket404JSONResponse(bucketNotFound()), nil
@@ -270,59 +785,170 @@ func (s *Handlers) UpdateBucket(
 		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
 	}
 
-	if b.Archived() {
-		return UpdateBucket409JSONResponse(bucketArchived()), nil
+	opts := serverplate.UpdateBucketOptions{
+		Name:                 request.Body.Name,
+		Description:          request.Body.Description,
+		Retention:            request.Body.Retention,
+		ExpiresAt:            request.Body.ExpiresAt,
+		ArchiveWhenExhausted: request.Body.ArchiveWhenExhausted,
+	}
+	if request.Body.Filters != nil {
+		opts.Filters = new(generateOptions(request.Body.Filters).Filters())
+	}
+	if request.Body.Labels != nil {
+		opts.Labels = *request.Body.Labels
+	}
+
+	updated, delta, err := s.buckets.Update(ctx, b, opts)
+	if err != nil {
+		switch {
+		case serverplate.IsInvalidInput(err):
+			return UpdateBucket400JSONResponse(validationFailed(err)), nil
+		case errors.Is(err, serverplate.ErrBucketArchived):
+			return UpdateBucket409JSONResponse(bucketArchived()), nil
+		case errors.Is(err, serverplate.ErrBucketFilling):
+			return UpdateBucket409JSONResponse(bucketFilling(b)), nil
+		case errors.Is(err, serverplate.ErrBucketNameTaken):
+			return UpdateBucket409JSONResponse(bucketNameTaken()), nil
+		}
+		return nil, fmt.Errorf("failed to update bucket: %w", err)
 	}
 
-	if request.Body.Description != nil {
-		newDesc := *request.Body.Description
+	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
+	}
 
-		if len(newDesc) > 2048 {
-			return UpdateBucket400JSONResponse{
+	return UpdateBucket200JSONResponse(updatedBucketDetails(s.bucketDetails(updated, remaining), delta)), nil
+}
+
+// updatedBucketDetails adds the change in the amount of names left caused by an update to the bucket details.
+func updatedBucketDetails(d BucketDetails, delta int64) UpdatedBucketDetails {
+	return UpdatedBucketDetails{
+		Id:                   d.Id,
+		Name:                 d.Name,
+		Description:          d.Description,
+		CreatedAt:            d.CreatedAt,
+		UpdatedAt:            d.UpdatedAt,
+		ArchivedAt:           d.ArchivedAt,
+		RemainingPairs:       d.RemainingPairs,
+		RemainingPairsDelta:  delta,
+		Filters:              d.Filters,
+		Labels:               d.Labels,
+		Retention:            d.Retention,
+		RemovalAt:            d.RemovalAt,
+		ExpiresAt:            d.ExpiresAt,
+		ArchiveWhenExhausted: d.ArchiveWhenExhausted,
+		Seed:                 d.Seed,
+		WordsVersion:         d.WordsVersion,
+		Lazy:                 d.Lazy,
+		Fill:                 d.Fill,
+	}
+}
+
+func (s *Handlers) DeleteBucket(
+	ctx context.Context,
+	request DeleteBucketRequestObject,
+) (DeleteBucketResponseObject, error) {
+	b, err := s.buckets.Find(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrBucketNotFound) {
+			return DeleteBucket404JSONResponse(bucketNotFound()), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
+	}
+
+	if err := s.buckets.Delete(ctx, b, request.Params.Confirm); err != nil {
+		switch {
+		case errors.Is(err, serverplate.ErrBucketDeleteNotConfirmed):
+			return DeleteBucket400JSONResponse{
 				Status: 400,
 				Type:   "validation_error",
 				Title:  "Validation failed",
-				Detail: new("Description must not exceed 2048 characters"),
+				Detail: new("confirm must be the name of the bucket"),
+			}, nil
+		case errors.Is(err, serverplate.ErrBucketNotArchived):
+			return DeleteBucket409JSONResponse{
+				Status: 409,
+				Type:   "bucket_not_archived",
+				Title:  "Operation conflict. Bucket is not archived.",
+				Detail: new("Only archived buckets can be deleted, archive the bucket first."),
 			}, nil
 		}
+		return nil, err
+	}
+
+	return DeleteBucket204Response{}, nil
+}
 
-		b.Description = newDesc
+func (s *Handlers) CloneBucket(
+	ctx context.Context,
+	request CloneBucketRequestObject,
+) (CloneBucketResponseObject, error) {
+	if request.Body == nil {
+		return nil, fmt.Errorf("request body is required")
 	}
 
-	if err := s.bucketStore.Save(ctx, &b); err != nil {
-		return nil, fmt.Errorf("failed to save bucket: %w", err)
+	src, err := s.buckets.Find(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrBucketNotFound) {
+			return CloneBucket404JSONResponse(bucketNotFound()), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
 	}
 
-	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
+	values := deref(request.Body.Values)
+	if values == "" {
+		values = Reshuffle
+	}
+	if !values.Valid() {
+		return CloneBucket400JSONResponse{
+			Status: 400,
+			Type:   "validation_error",
+			Title:  "Validation failed",
+			Detail: new(fmt.Sprintf("values must be %q or %q", Reshuffle, CopyRemaining)),
+		}, nil
+	}
+
+	opts := serverplate.CloneBucketOptions{
+		Name:          request.Body.Name,
+		Description:   request.Body.Description,
+		CopyRemaining: values == CopyRemaining,
+	}
+	if request.Body.Labels != nil {
+		opts.Labels = *request.Body.Labels
+	}
+
+	b, err := s.buckets.Clone(ctx, src, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
+		switch {
+		case serverplate.IsInvalidInput(err):
+			return CloneBucket400JSONResponse(validationFailed(err)), nil
+		case errors.Is(err, serverplate.ErrBucketFilling):
+			return CloneBucket409JSONResponse(bucketFilling(src)), nil
+		case errors.Is(err, serverplate.ErrBucketNameTaken):
+			return CloneBucket409JSONResponse(bucketNameTaken()), nil
+		}
+		return nil, err
 	}
 
-	response := UpdateBucket200JSONResponse{
-		Id:             b.ID,
-		Name:           b.Name,
-		Description:    b.Description,
-		CreatedAt:      b.CreatedAt,
-		UpdatedAt:      b.UpdatedAt,
-		ArchivedAt:     b.ArchivedAt,
-		RemainingPairs: remaining,
+	if b.Filling() {
+		return CloneBucket202JSONResponse(s.bucketDetails(b, 0)), nil
 	}
 
-	response.Filters.LengthEnabled = b.FilterLengthEnabled
-	if b.FilterLengthEnabled {
-		response.Filters.Length = &b.FilterLengthValue
-		lengthMode := BucketDetailsFiltersLengthMode(b.FilterLengthMode)
-		response.Filters.LengthMode = &lengthMode
+	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
 	}
 
-	return response, nil
+	return CloneBucket201JSONResponse(s.bucketDetails(b, remaining)), nil
 }
 
 func (s *Handlers) ArchiveBucket(
 	ctx context.Context,
 	request ArchiveBucketRequestObject,
 ) (ArchiveBucketResponseObject, error) {
-	b, err := s.bucketStore.OneByID(ctx, request.Id)
+	b, err := s.buckets.Find(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, serverplate.ErrBucketNotFound) {
 			return ArchiveBucket404JSONResponse(bucketNotFound()), nil
@@ -330,10 +956,9 @@ func (s *Handlers) ArchiveBucket(
 		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
 	}
 
-	b.MarkArchived()
-
-	if err := s.bucketStore.Save(ctx, &b); err != nil {
-		return nil, fmt.Errorf("failed to save bucket: %w", err)
+	b, err = s.buckets.Archive(ctx, b)
+	if err != nil {
+		return nil, err
 	}
 
 	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
@@ -341,31 +966,14 @@ func (s *Handlers) ArchiveBucket(
 		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
 	}
 
-	response := ArchiveBucket200JSONResponse{
-		Id:             b.ID,
-		Name:           b.Name,
-		Description:    b.Description,
-		CreatedAt:      b.CreatedAt,
-		UpdatedAt:      b.UpdatedAt,
-		ArchivedAt:     b.ArchivedAt,
-		RemainingPairs: remaining,
-	}
-
-	response.Filters.LengthEnabled = b.FilterLengthEnabled
-	if b.FilterLengthEnabled {
-		response.Filters.Length = &b.FilterLengthValue
-		lengthMode := BucketDetailsFiltersLengthMode(b.FilterLengthMode)
-		response.Filters.LengthMode = &lengthMode
-	}
-
-	return response, nil
+	return ArchiveBucket200JSONResponse(s.bucketDetails(b, remaining)), nil
 }
 
 func (s *Handlers) RecoverBucket(
 	ctx context.Context,
 	request RecoverBucketRequestObject,
 ) (RecoverBucketResponseObject, error) {
-	b, err := s.bucketStore.OneByID(ctx, request.Id)
+	b, err := s.buckets.Find(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, serverplate.ErrBucketNotFound) {
 			return RecoverBucket404JSONResponse(bucketNotFound()), nil
@@ -373,33 +981,198 @@ func (s *Handlers) RecoverBucket(
 		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
 	}
 
-	b.Recover()
+	b, err = s.buckets.Recover(ctx, b)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.bucketStore.Save(ctx, &b); err != nil {
-		return nil, fmt.Errorf("failed to save bucket: %w", err)
+	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
 	}
 
+	return RecoverBucket200JSONResponse(s.bucketDetails(b, remaining)), nil
+}
+
+func (s *Handlers) StreamBucketEvents(
+	ctx context.Context,
+	request StreamBucketEventsRequestObject,
+) (StreamBucketEventsResponseObject, error) {
+	b, err := s.buckets.Find(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, serverplate.ErrBucketNotFound) {
+			return StreamBucketEvents404JSONResponse(bucketNotFound()), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve bucket by id: %w", err)
+	}
+
+	// the subscription starts before counting the names so that no change is missed in between.
+	events, cancel := s.events.Subscribe(b.ID)
+
 	remaining, err := s.bucketStore.RemainingValuesTotal(ctx, b)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to get remaining pairs count: %w", err)
 	}
 
-	response := RecoverBucket200JSONResponse{
-		Id:             b.ID,
-		Name:           b.Name,
-		Description:    b.Description,
-		CreatedAt:      b.CreatedAt,
-		UpdatedAt:      b.UpdatedAt,
-		ArchivedAt:     b.ArchivedAt,
-		RemainingPairs: remaining,
+	return bucketEventStream{
+		ctx:         ctx,
+		bucket:      b,
+		bucketStore: s.bucketStore,
+		events:      events,
+		cancel:      cancel,
+		remaining:   remaining,
+	}, nil
+}
+
+func (s *Handlers) ListAuditEvents(
+	ctx context.Context,
+	request ListAuditEventsRequestObject,
+) (ListAuditEventsResponseObject, error) {
+	params := request.Params
+
+	opts := serverplate.AuditListOptions{
+		BucketID: deref(params.BucketId),
+		Action:   serverplate.AuditAction(deref(params.Action)),
+		Actor:    deref(params.Actor),
+		Source:   serverplate.AuditSource(deref(params.Source)),
+		Since:    deref(params.Since),
+		Until:    deref(params.Until),
+		BeforeID: deref(params.Before),
+		Limit:    defaultListLimit,
+	}
+	if params.Limit != nil {
+		opts.Limit = *params.Limit
+	}
+
+	if opts.Limit < 1 || opts.Limit > maxListLimit || opts.BeforeID < 0 ||
+		(opts.Action != "" && !opts.Action.Valid()) || (opts.Source != "" && !opts.Source.Valid()) {
+		return ListAuditEvents400JSONResponse{
+			Status: 400,
+			Type:   "validation_error",
+			Title:  "Validation failed",
+			Detail: new(fmt.Sprintf(
+				"limit must be between 1 and %d, before must not be negative and action and source must be known",
+				maxListLimit,
+			)),
+		}, nil
 	}
 
-	response.Filters.LengthEnabled = b.FilterLengthEnabled
-	if b.FilterLengthEnabled {
-		response.Filters.Length = &b.FilterLengthValue
-		lengthMode := BucketDetailsFiltersLengthMode(b.FilterLengthMode)
-		response.Filters.LengthMode = &lengthMode
+	// one more event than requested is listed to know whether there is a next page.
+	limit := opts.Limit
+	opts.Limit++
+
+	events, err := s.auditStore.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *string
+	if len(events) > limit {
+		events = events[:limit]
+		next = new(nextAuditLink(params, limit, events[limit-1].ID))
+	}
+
+	items := make([]AuditEvent, 0, len(events))
+	for _, e := range events {
+		items = append(items, AuditEvent{
+			Id:         e.ID,
+			Action:     AuditAction(e.Action),
+			BucketId:   e.BucketID,
+			BucketName: e.BucketName,
+			Actor:      e.Actor,
+			Source:     AuditSource(e.Source),
+			Ip:         nonZero(e.IP),
+			RequestId:  nonZero(e.RequestID),
+			Before:     e.Before,
+			After:      e.After,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return ListAuditEvents200JSONResponse{
+		Events: items,
+		Next:   next,
+	}, nil
+}
+
+// nextAuditLink returns the link to the page of audit events older than the event with the id, keeping the rest of
+// the parameters.
+func nextAuditLink(params ListAuditEventsParams, limit int, before int64) string {
+	q := url.Values{}
+	if params.BucketId != nil {
+		q.Set("bucket_id", strconv.Itoa(int(*params.BucketId)))
+	}
+	if params.Action != nil {
+		q.Set("action", string(*params.Action))
+	}
+	if params.Actor != nil {
+		q.Set("actor", *params.Actor)
+	}
+	if params.Source != nil {
+		q.Set("source", string(*params.Source))
+	}
+	if params.Since != nil {
+		q.Set("since", params.Since.Format(time.RFC3339Nano))
+	}
+	if params.Until != nil {
+		q.Set("until", params.Until.Format(time.RFC3339Nano))
+	}
+	q.Set("before", strconv.FormatInt(before, 10))
+	q.Set("limit", strconv.Itoa(limit))
+
+	return BaseURL + "/v1alpha1/audit?" + q.Encode()
+}
+
+func (s *Handlers) ListJobs(
+	ctx context.Context,
+	_ ListJobsRequestObject,
+) (ListJobsResponseObject, error) {
+	jobs, err := s.jobRunner.Jobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	response := ListJobs200JSONResponse{
+		Jobs: make([]Job, 0, len(jobs)),
+	}
+	for _, j := range jobs {
+		job := Job{
+			Name:      j.Name,
+			Schedule:  j.Schedule,
+			NextRunAt: j.NextRunAt,
+		}
+
+		if j.LastRun != nil {
+			job.LastRun = &JobRun{
+				StartedAt:    j.LastRun.StartedAt,
+				EndedAt:      j.LastRun.EndedAt,
+				Error:        nonZero(j.LastRun.Error),
+				AffectedRows: j.LastRun.AffectedRows,
+			}
+		}
+
+		response.Jobs = append(response.Jobs, job)
 	}
 
 	return response, nil
 }
+
+func (s *Handlers) RunJob(
+	_ context.Context,
+	request RunJobRequestObject,
+) (RunJobResponseObject, error) {
+	if err := s.jobRunner.RunNow(request.Name); err != nil {
+		if errors.Is(err, serverplate.ErrJobNotFound) {
+			return RunJob404JSONResponse{
+				Status: 404,
+				Type:   "not_found",
+				Title:  "Job not found",
+				Detail: new(fmt.Sprintf("There is no job named %q", request.Name)),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to run job: %w", err)
+	}
+
+	return RunJob202Response{}, nil
+}
@@ -0,0 +1,1632 @@
+package api_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/bg"
+	"github.com/davidonium/serverplate/internal/env"
+	"github.com/davidonium/serverplate/internal/server/api"
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return newTestServerWithFiller(t, nil)
+}
+
+// newTestServerWithFiller builds a test server whose buckets are filled by filler, a nil filler fills them in the
+// background like the server does.
+func newTestServerWithFiller(t *testing.T, filler serverplate.BucketFiller) *httptest.Server {
+	t.Helper()
+
+	words := memstore.NewWords([]string{"brave", "calm"}, []string{"river", "mountain"})
+	generator := serverplate.NewGenerator(memstore.NewPairStore(words))
+	bucketStore := memstore.NewBucketStore(words)
+	leaseStore := memstore.NewLeaseStore(bucketStore)
+	events := serverplate.NewEventBus()
+	t.Cleanup(events.Close)
+	auditStore := memstore.NewAuditStore()
+	auditor := serverplate.NewAuditor(auditStore)
+
+	cfg := env.Config{
+		ArchivedBucketsRetention:          72 * time.Hour,
+		JobsRemoveArchivedBucketsSchedule: "0 * * * *",
+		JobsAutoArchiveBucketsSchedule:    "*/15 * * * *",
+		JobsReturnExpiredLeasesSchedule:   "* * * * *",
+		JobsFailStalledFillsSchedule:      "*/5 * * * *",
+		JobsLockTTL:                       time.Minute,
+		LeaseTTL:                          5 * time.Minute,
+		LeaseMaxTTL:                       time.Hour,
+		FillWorkers:                       2,
+		FillBatchSize:                     3,
+		FillStallTimeout:                  15 * time.Minute,
+	}
+	runner, err := bg.NewRunner(
+		slog.New(slog.DiscardHandler),
+		bucketStore,
+		memstore.NewJobRunStore(),
+		memstore.NewJobLockStore(),
+		leaseStore,
+		events,
+		auditor,
+		cfg,
+	)
+	if err != nil {
+		t.Fatalf("failed to create the job runner: %v", err)
+	}
+
+	if filler == nil {
+		bgFiller, err := bg.NewFiller(slog.New(slog.DiscardHandler), generator, bucketStore, events, cfg)
+		if err != nil {
+			t.Fatalf("failed to create the filler: %v", err)
+		}
+		t.Cleanup(bgFiller.Wait)
+		filler = bgFiller
+	}
+
+	buckets := serverplate.NewBucketService(
+		generator,
+		bucketStore,
+		leaseStore,
+		filler,
+		events,
+		auditor,
+		cfg.LeaseTTL,
+		cfg.LeaseMaxTTL,
+	)
+	handlers := api.New(generator, buckets, bucketStore, events, auditStore, runner, cfg.ArchivedBucketsRetention)
+
+	strict := api.NewStrictHandlerWithOptions(handlers, nil, api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc:  api.RequestErrorHandler(),
+		ResponseErrorHandlerFunc: api.ErrorHandler(slog.New(slog.DiscardHandler), true),
+	})
+	srv := httptest.NewServer(api.HandlerFromMuxWithBaseURL(strict, http.NewServeMux(), "/api"))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func doJSON(t *testing.T, srv *httptest.Server, method, path string, body any, out any) int {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, srv.URL+path, &buf)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to issue request %s %s: %v", method, path, err)
+	}
+	defer res.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode response of %s %s: %v", method, path, err)
+		}
+	}
+
+	return res.StatusCode
+}
+
+// waitFilled polls the bucket until its fill ends and returns its details, failing the test if the fill fails.
+func waitFilled(t *testing.T, srv *httptest.Server, bucket string) api.BucketDetails {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var details api.BucketDetails
+		path := "/api/v1alpha1/buckets/" + bucket
+		if status := doJSON(t, srv, http.MethodGet, path, nil, &details); status != http.StatusOK {
+			t.Fatalf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusOK)
+		}
+
+		if details.Fill == nil {
+			return details
+		}
+		if details.Fill.State == api.Failed {
+			t.Fatalf("GetBucketDetails() = the fill of %s failed: %+v", bucket, details.Fill)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetBucketDetails() = %s still filling, done %d of %d",
+				bucket,
+				details.Fill.Done,
+				details.Fill.Total,
+			)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBucketLifecycle(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "test-bucket",
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "test-bucket")
+
+	if created.RemainingPairs != 4 {
+		t.Errorf("CreateBucket() = unexpected remaining pairs got %d want 4", created.RemainingPairs)
+	}
+
+	popped := map[string]bool{}
+	for range 4 {
+		var res struct {
+			Name string `json:"name"`
+		}
+		path := fmt.Sprintf("/api/v1alpha1/buckets/%d/pop", created.Id)
+		if status := doJSON(t, srv, http.MethodPost, path, nil, &res); status != http.StatusOK {
+			t.Fatalf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+		}
+
+		if popped[res.Name] {
+			t.Errorf("PopBucketName() = name %q was popped twice", res.Name)
+		}
+		popped[res.Name] = true
+	}
+
+	var exhausted api.ProblemDetail
+	path := fmt.Sprintf("/api/v1alpha1/buckets/%d/pop", created.Id)
+	if status := doJSON(t, srv, http.MethodPost, path, nil, &exhausted); status != http.StatusConflict {
+		t.Errorf("PopBucketName() = unexpected status for an exhausted bucket got %d want %d",
+			status,
+			http.StatusConflict,
+		)
+	}
+
+	if exhausted.Type != "bucket_exhausted" {
+		t.Errorf("PopBucketName() = unexpected problem type got %q want %q", exhausted.Type, "bucket_exhausted")
+	}
+
+	path = fmt.Sprintf("/api/v1alpha1/buckets/%d/archive", created.Id)
+	if status := doJSON(t, srv, http.MethodPost, path, nil, nil); status != http.StatusOK {
+		t.Fatalf("ArchiveBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	var problem api.ProblemDetail
+	path = fmt.Sprintf("/api/v1alpha1/buckets/%d/pop", created.Id)
+	if status := doJSON(t, srv, http.MethodPost, path, nil, &problem); status != http.StatusConflict {
+		t.Errorf("PopBucketName() = unexpected status for an archived bucket got %d want %d",
+			status,
+			http.StatusConflict,
+		)
+	}
+}
+
+func TestGetBucketDetailsNotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	var problem api.ProblemDetail
+	status := doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/42", nil, &problem)
+	if status != http.StatusNotFound {
+		t.Errorf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusNotFound)
+	}
+
+	if problem.Type != "not_found" {
+		t.Errorf("GetBucketDetails() = unexpected problem type got %q want %q", problem.Type, "not_found")
+	}
+}
+
+func TestCreateBucketWithFilters(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "filtered-bucket",
+		"filters": map[string]any{
+			"noun_initial":   "r",
+			"excluded_chars": "b",
+		},
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "filtered-bucket")
+
+	if created.RemainingPairs != 1 {
+		t.Errorf("CreateBucket() = unexpected remaining pairs got %d want 1", created.RemainingPairs)
+	}
+
+	if created.Filters.NounInitial == nil || *created.Filters.NounInitial != "r" {
+		t.Errorf("CreateBucket() = unexpected noun initial got %v want %q", created.Filters.NounInitial, "r")
+	}
+
+	if created.Filters.Prefix != nil {
+		t.Errorf("CreateBucket() = unexpected prefix got %q want null", *created.Filters.Prefix)
+	}
+}
+
+func TestCreateBucketInvalidFilters(t *testing.T) {
+	srv := newTestServer(t)
+
+	var problem api.ProblemDetail
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "invalid-bucket",
+		"filters": map[string]any{
+			"adjective_initial": "br",
+		},
+	}, &problem)
+	if status != http.StatusBadRequest {
+		t.Errorf("CreateBucket() = unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+
+	if problem.Type != "validation_error" {
+		t.Errorf("CreateBucket() = unexpected problem type got %q want %q", problem.Type, "validation_error")
+	}
+}
+
+func TestCreateBucketValidationErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	var problem api.ProblemDetail
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "Invalid_Name",
+		"filters": map[string]any{
+			"length_enabled": true,
+			"length":         64,
+			"length_mode":    "around",
+			"noun_initial":   "rr",
+		},
+		"retention": "soon",
+	}, &problem)
+	if status != http.StatusBadRequest || problem.Type != "validation_error" {
+		t.Fatalf("CreateBucket() = unexpected response %d %+v", status, problem)
+	}
+
+	// every rejected field is reported at once.
+	if problem.Errors == nil {
+		t.Fatalf("CreateBucket() = got no field errors, want them listed")
+	}
+	var fields []string
+	for _, e := range *problem.Errors {
+		if e.Reason == "" {
+			t.Errorf("CreateBucket() = got an empty reason for %q", e.Name)
+		}
+		fields = append(fields, e.Name)
+	}
+	want := []string{"name", "filters.length_mode", "filters.length", "filters.noun_initial", "retention"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("CreateBucket() = unexpected field errors got %q want %q", fields, want)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "servers"}, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	waitFilled(t, srv, "servers")
+
+	problem = api.ProblemDetail{}
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "servers"}, &problem)
+	if status != http.StatusConflict || problem.Type != "name_taken" {
+		t.Errorf("CreateBucket() = unexpected response to a taken name %d %+v", status, problem)
+	}
+}
+
+func TestUpdateBucketFilters(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "test-bucket",
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "test-bucket")
+
+	var popped struct {
+		Name string `json:"name"`
+	}
+	path := fmt.Sprintf("/api/v1alpha1/buckets/%d/pop", created.Id)
+	if status := doJSON(t, srv, http.MethodPost, path, nil, &popped); status != http.StatusOK {
+		t.Fatalf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	wantRemaining := int64(2)
+	if strings.HasSuffix(popped.Name, "-river") {
+		wantRemaining = 1
+	}
+
+	var updated api.UpdatedBucketDetails
+	path = fmt.Sprintf("/api/v1alpha1/buckets/%d", created.Id)
+	status = doJSON(t, srv, http.MethodPatch, path, map[string]any{
+		"filters": map[string]any{
+			"noun_initial": "r",
+		},
+	}, &updated)
+	if status != http.StatusOK {
+		t.Fatalf("UpdateBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if updated.RemainingPairs != wantRemaining {
+		t.Errorf("UpdateBucket() = unexpected remaining pairs got %d want %d", updated.RemainingPairs, wantRemaining)
+	}
+
+	if want := wantRemaining - 3; updated.RemainingPairsDelta != want {
+		t.Errorf("UpdateBucket() = unexpected delta got %d want %d", updated.RemainingPairsDelta, want)
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPatch, path, map[string]any{
+		"filters": map[string]any{
+			"excluded_chars": "-",
+		},
+	}, &problem)
+	if status != http.StatusBadRequest {
+		t.Errorf("UpdateBucket() = unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestListBucketsPagination(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, name := range []string{"prod-web", "prod-db", "staging-web"} {
+		status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+			"name": name,
+		}, nil)
+		if status != http.StatusAccepted {
+			t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+		}
+		waitFilled(t, srv, name)
+	}
+
+	type listResponse struct {
+		Buckets []api.BucketListItem `json:"buckets"`
+		Next    *string              `json:"next"`
+	}
+
+	var first listResponse
+	path := "/api/v1alpha1/buckets?sort=name&limit=2&include_remaining=true"
+	if status := doJSON(t, srv, http.MethodGet, path, nil, &first); status != http.StatusOK {
+		t.Fatalf("ListBuckets() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if len(first.Buckets) != 2 || first.Buckets[0].Name != "prod-db" || first.Next == nil {
+		t.Fatalf("ListBuckets() = unexpected first page %+v", first)
+	}
+
+	if first.Buckets[0].RemainingPairs == nil || *first.Buckets[0].RemainingPairs != 4 {
+		t.Errorf("ListBuckets() = unexpected remaining pairs got %v want 4", first.Buckets[0].RemainingPairs)
+	}
+
+	var second listResponse
+	if status := doJSON(t, srv, http.MethodGet, *first.Next, nil, &second); status != http.StatusOK {
+		t.Fatalf("ListBuckets() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if len(second.Buckets) != 1 || second.Buckets[0].Name != "staging-web" || second.Next != nil {
+		t.Errorf("ListBuckets() = unexpected second page %+v", second)
+	}
+
+	var search listResponse
+	if status := doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets?q=WEB", nil, &search); status != http.StatusOK {
+		t.Fatalf("ListBuckets() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if len(search.Buckets) != 2 || search.Buckets[0].RemainingPairs != nil {
+		t.Errorf("ListBuckets() = unexpected search result %+v", search)
+	}
+
+	for _, path := range []string{
+		"/api/v1alpha1/buckets?limit=0",
+		"/api/v1alpha1/buckets?offset=-1",
+		"/api/v1alpha1/buckets?sort=remaining",
+	} {
+		if status := doJSON(t, srv, http.MethodGet, path, nil, nil); status != http.StatusBadRequest {
+			t.Errorf("ListBuckets() = %s unexpected status got %d want %d", path, status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestBucketLabels(t *testing.T) {
+	srv := newTestServer(t)
+
+	buckets := map[string]map[string]string{
+		"payments-prod":    {"team": "payments", "env": "prod"},
+		"payments-staging": {"team": "payments", "env": "staging"},
+		"search-prod":      {"team": "search", "env": "prod"},
+	}
+	ids := map[string]int32{}
+	for _, name := range []string{"payments-prod", "payments-staging", "search-prod"} {
+		var created api.BucketDetails
+		status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+			"name":   name,
+			"labels": buckets[name],
+		}, &created)
+		if status != http.StatusAccepted {
+			t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+		}
+		created = waitFilled(t, srv, name)
+		if !maps.Equal(created.Labels, buckets[name]) {
+			t.Errorf("CreateBucket() = unexpected labels got %v want %v", created.Labels, buckets[name])
+		}
+		ids[name] = created.Id
+	}
+
+	var list struct {
+		Buckets []api.BucketListItem `json:"buckets"`
+	}
+	path := "/api/v1alpha1/buckets?selector=" + url.QueryEscape("team=payments,env=prod")
+	if status := doJSON(t, srv, http.MethodGet, path, nil, &list); status != http.StatusOK {
+		t.Fatalf("ListBuckets() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if len(list.Buckets) != 1 || list.Buckets[0].Name != "payments-prod" {
+		t.Errorf("ListBuckets() = unexpected selected buckets %+v", list.Buckets)
+	}
+
+	var updated api.UpdatedBucketDetails
+	path = fmt.Sprintf("/api/v1alpha1/buckets/%d", ids["payments-staging"])
+	status := doJSON(t, srv, http.MethodPatch, path, map[string]any{
+		"labels": map[string]string{"team": "payments", "env": "prod"},
+	}, &updated)
+	if status != http.StatusOK {
+		t.Fatalf("UpdateBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if updated.Labels["env"] != "prod" {
+		t.Errorf("UpdateBucket() = unexpected labels got %v", updated.Labels)
+	}
+
+	path = "/api/v1alpha1/buckets?selector=" + url.QueryEscape("env=prod,team=payments")
+	if status := doJSON(t, srv, http.MethodGet, path, nil, &list); status != http.StatusOK {
+		t.Fatalf("ListBuckets() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if len(list.Buckets) != 2 {
+		t.Errorf("ListBuckets() = unexpected selected buckets after the update %+v", list.Buckets)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":   "invalid-labels",
+		"labels": map[string]string{"Team": "payments"},
+	}, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("CreateBucket() = unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+
+	path = "/api/v1alpha1/buckets?selector=team"
+	if status := doJSON(t, srv, http.MethodGet, path, nil, nil); status != http.StatusBadRequest {
+		t.Errorf("ListBuckets() = unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestBucketByName(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "pipeline-servers",
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "pipeline-servers")
+
+	var details api.BucketDetails
+	status = doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/pipeline-servers", nil, &details)
+	if status != http.StatusOK || details.Id != created.Id {
+		t.Fatalf("GetBucketDetails() = unexpected response %d %+v", status, details)
+	}
+
+	var updated api.UpdatedBucketDetails
+	status = doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/pipeline-servers", map[string]any{
+		"description": "Addressed by name",
+	}, &updated)
+	if status != http.StatusOK || updated.Description != "Addressed by name" {
+		t.Fatalf("UpdateBucket() = unexpected response %d %+v", status, updated)
+	}
+
+	var popped api.PopBucketName200JSONResponse
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/pipeline-servers/pop", nil, &popped)
+	if status != http.StatusOK || popped.Name == "" {
+		t.Fatalf("PopBucketName() = unexpected response %d %+v", status, popped)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/pipeline-servers/archive", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("ArchiveBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/pipeline-servers/pop", nil, nil)
+	if status != http.StatusConflict {
+		t.Errorf("PopBucketName() = unexpected status on archived got %d want %d", status, http.StatusConflict)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/pipeline-servers/recover", nil, nil)
+	if status != http.StatusOK {
+		t.Errorf("RecoverBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	for _, path := range []string{
+		"/api/v1alpha1/buckets/missing-servers",
+		"/api/v1alpha1/buckets/99999999999",
+	} {
+		if status := doJSON(t, srv, http.MethodGet, path, nil, nil); status != http.StatusNotFound {
+			t.Errorf("GetBucketDetails() = %s unexpected status got %d want %d", path, status, http.StatusNotFound)
+		}
+	}
+}
+
+func TestRenameBucket(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, name := range []string{"prod-servers", "taken-servers"} {
+		status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+			"name": name,
+		}, nil)
+		if status != http.StatusAccepted {
+			t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+		}
+		waitFilled(t, srv, name)
+	}
+
+	var updated api.UpdatedBucketDetails
+	status := doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/prod-servers", map[string]any{
+		"name": "prod-servers-eu",
+	}, &updated)
+	if status != http.StatusOK || updated.Name != "prod-servers-eu" {
+		t.Fatalf("UpdateBucket() = unexpected response %d %+v", status, updated)
+	}
+
+	path := "/api/v1alpha1/buckets/prod-servers-eu"
+	if status := doJSON(t, srv, http.MethodGet, path, nil, nil); status != http.StatusOK {
+		t.Errorf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPatch, path, map[string]any{"name": "taken-servers"}, &problem)
+	if status != http.StatusConflict || problem.Type != "name_taken" {
+		t.Errorf("UpdateBucket() = unexpected response to a taken name %d %+v", status, problem)
+	}
+
+	for _, name := range []string{"Invalid_Name", "-servers", "12345"} {
+		status := doJSON(t, srv, http.MethodPatch, path, map[string]any{"name": name}, nil)
+		if status != http.StatusBadRequest {
+			t.Errorf("UpdateBucket() = name %q unexpected status got %d want %d", name, status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestCloneBucket(t *testing.T) {
+	srv := newTestServer(t)
+
+	var src api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":        "prod-servers",
+		"description": "Production",
+		"labels":      map[string]string{"env": "prod"},
+		"filters":     map[string]any{"length_enabled": false, "excluded_chars": "b"},
+	}, &src)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	src = waitFilled(t, srv, "prod-servers")
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/prod-servers/pop", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	var fresh api.BucketDetails
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/prod-servers/clone", map[string]any{
+		"name":   "staging-servers",
+		"labels": map[string]string{"env": "staging"},
+	}, &fresh)
+	if status != http.StatusAccepted || fresh.Fill == nil {
+		t.Fatalf("CloneBucket() = unexpected reshuffle %d %+v, want it still filling", status, fresh)
+	}
+	fresh = waitFilled(t, srv, "staging-servers")
+	if fresh.Description != "Production" || fresh.Labels["env"] != "staging" {
+		t.Errorf("CloneBucket() = unexpected clone %+v", fresh)
+	}
+	if fresh.Filters.ExcludedChars == nil || *fresh.Filters.ExcludedChars != "b" {
+		t.Errorf("CloneBucket() = unexpected clone filters %+v", fresh.Filters)
+	}
+	if fresh.RemainingPairs != src.RemainingPairs {
+		t.Errorf("CloneBucket() = reshuffle remaining got %d want %d", fresh.RemainingPairs, src.RemainingPairs)
+	}
+
+	var copied api.BucketDetails
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/prod-servers/clone", map[string]any{
+		"name":   "qa-servers",
+		"values": "copy_remaining",
+	}, &copied)
+	if status != http.StatusCreated {
+		t.Fatalf("CloneBucket() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+	if copied.RemainingPairs != src.RemainingPairs-1 || copied.Labels["env"] != "prod" {
+		t.Errorf("CloneBucket() = unexpected copy %+v", copied)
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/prod-servers/clone", map[string]any{
+		"name": "qa-servers",
+	}, &problem)
+	if status != http.StatusConflict || problem.Type != "name_taken" {
+		t.Errorf("CloneBucket() = unexpected response to a taken name %d %+v", status, problem)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/prod-servers/clone", map[string]any{
+		"name":   "other-servers",
+		"values": "sorted",
+	}, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("CloneBucket() = unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/missing/clone", map[string]any{
+		"name": "other-servers",
+	}, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("CloneBucket() = unexpected status got %d want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestDeleteBucket(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":      "load-test",
+		"retention": "24h",
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "load-test")
+	if created.Retention == nil || *created.Retention != "24h" || created.RemovalAt != nil {
+		t.Errorf("CreateBucket() = unexpected retention %v removal %v", created.Retention, created.RemovalAt)
+	}
+
+	path := "/api/v1alpha1/buckets/load-test?confirm=load-test"
+	if status := doJSON(t, srv, http.MethodDelete, path, nil, nil); status != http.StatusConflict {
+		t.Errorf("DeleteBucket() = active bucket unexpected status got %d want %d", status, http.StatusConflict)
+	}
+
+	var archived api.BucketDetails
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/load-test/archive", nil, &archived)
+	if status != http.StatusOK {
+		t.Fatalf("ArchiveBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if archived.RemovalAt == nil || !archived.RemovalAt.Equal(archived.ArchivedAt.Add(24*time.Hour)) {
+		t.Errorf("ArchiveBucket() = unexpected removal %v archived %v", archived.RemovalAt, archived.ArchivedAt)
+	}
+
+	wrong := "/api/v1alpha1/buckets/load-test?confirm=other"
+	if status := doJSON(t, srv, http.MethodDelete, wrong, nil, nil); status != http.StatusBadRequest {
+		t.Errorf("DeleteBucket() = wrong confirmation unexpected status got %d want %d", status, http.StatusBadRequest)
+	}
+
+	if status := doJSON(t, srv, http.MethodDelete, path, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("DeleteBucket() = unexpected status got %d want %d", status, http.StatusNoContent)
+	}
+
+	if status := doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/load-test", nil, nil); status != http.StatusNotFound {
+		t.Errorf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestUpdateBucketRetention(t *testing.T) {
+	srv := newTestServer(t)
+
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "hackathon",
+	}, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	waitFilled(t, srv, "hackathon")
+
+	cases := []struct {
+		retention string
+		status    int
+		want      *string
+	}{
+		{retention: "0", status: http.StatusOK, want: new("0s")},
+		{retention: "90m", status: http.StatusOK, want: new("1h30m")},
+		{retention: "", status: http.StatusOK, want: nil},
+		{retention: "-1h", status: http.StatusBadRequest},
+		{retention: "forever", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range cases {
+		var updated api.UpdatedBucketDetails
+		status := doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/hackathon", map[string]any{
+			"retention": tt.retention,
+		}, &updated)
+		if status != tt.status {
+			t.Errorf("UpdateBucket() = retention %q unexpected status got %d want %d", tt.retention, status, tt.status)
+			continue
+		}
+		if status != http.StatusOK {
+			continue
+		}
+
+		if (updated.Retention == nil) != (tt.want == nil) || (tt.want != nil && *updated.Retention != *tt.want) {
+			t.Errorf("UpdateBucket() = retention %q got %v want %v", tt.retention, updated.Retention, tt.want)
+		}
+	}
+}
+
+func TestJobs(t *testing.T) {
+	srv := newTestServer(t)
+
+	var listed struct {
+		Jobs []api.Job `json:"jobs"`
+	}
+	if status := doJSON(t, srv, http.MethodGet, "/api/v1alpha1/jobs", nil, &listed); status != http.StatusOK {
+		t.Fatalf("ListJobs() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if len(listed.Jobs) != 4 || listed.Jobs[0].Name != "remove_archived_buckets" {
+		t.Fatalf("ListJobs() = got %+v, want the remove_archived_buckets job first", listed.Jobs)
+	}
+
+	job := listed.Jobs[0]
+	if job.Schedule != "0 * * * *" || job.NextRunAt != nil || job.LastRun != nil {
+		t.Errorf("ListJobs() = got %+v, want the configured schedule without runs", job)
+	}
+
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/jobs/remove_archived_buckets/run", nil, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("RunJob() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.LastRun == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		doJSON(t, srv, http.MethodGet, "/api/v1alpha1/jobs", nil, &listed)
+		job = listed.Jobs[0]
+	}
+
+	if job.LastRun == nil {
+		t.Fatal("ListJobs() = expected the job run to be recorded")
+	}
+
+	if job.LastRun.Error != nil || job.LastRun.AffectedRows != 0 {
+		t.Errorf("ListJobs() = got last run %+v, want a successful run without affected rows", job.LastRun)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/jobs/missing_job/run", nil, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("RunJob() = unexpected status for a missing job got %d want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestBucketAutoArchivePolicy(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":                   "load-test",
+		"expires_at":             "2026-11-01T02:00:00+02:00",
+		"archive_when_exhausted": true,
+	}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	created = waitFilled(t, srv, "load-test")
+
+	want := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	if created.ExpiresAt == nil || !created.ExpiresAt.Equal(want) || !created.ArchiveWhenExhausted {
+		t.Errorf(
+			"CreateBucket() = got expiry %v and archive when exhausted %v, want %v and true",
+			created.ExpiresAt,
+			created.ArchiveWhenExhausted,
+			want,
+		)
+	}
+
+	var updated api.UpdatedBucketDetails
+	status = doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/load-test", map[string]any{
+		"expires_at":             "",
+		"archive_when_exhausted": false,
+	}, &updated)
+	if status != http.StatusOK {
+		t.Fatalf("UpdateBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if updated.ExpiresAt != nil || updated.ArchiveWhenExhausted {
+		t.Errorf(
+			"UpdateBucket() = got expiry %v and archive when exhausted %v, want none",
+			updated.ExpiresAt,
+			updated.ArchiveWhenExhausted,
+		)
+	}
+
+	status = doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/load-test", map[string]any{
+		"expires_at": "next week",
+	}, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("UpdateBucket() = unexpected status for an invalid expiry got %d want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestBucketLeases(t *testing.T) {
+	srv := newTestServer(t)
+
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "provisioning"}, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	waitFilled(t, srv, "provisioning")
+
+	var lease api.Lease
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/provisioning/reserve", map[string]any{
+		"ttl": "10m",
+	}, &lease)
+	if status != http.StatusCreated {
+		t.Fatalf("ReserveBucketName() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+
+	if lease.Id == "" || lease.Name == "" || lease.ConfirmedAt != nil {
+		t.Fatalf("ReserveBucketName() = got %+v, want a pending lease", lease)
+	}
+	if ttl := lease.ExpiresAt.Sub(lease.CreatedAt); ttl != 10*time.Minute {
+		t.Errorf("ReserveBucketName() = got a ttl of %v, want 10m", ttl)
+	}
+
+	var details api.BucketDetails
+	doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/provisioning", nil, &details)
+	if details.RemainingPairs != 3 {
+		t.Errorf("GetBucketDetails() = unexpected remaining pairs got %d want 3", details.RemainingPairs)
+	}
+
+	var confirmed api.Lease
+	path := "/api/v1alpha1/leases/" + lease.Id + "/confirm"
+	if status := doJSON(t, srv, http.MethodPost, path, nil, &confirmed); status != http.StatusOK {
+		t.Fatalf("ConfirmLease() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if confirmed.ConfirmedAt == nil || confirmed.Name != lease.Name {
+		t.Errorf("ConfirmLease() = got %+v, want the confirmed lease of %q", confirmed, lease.Name)
+	}
+
+	if status := doJSON(t, srv, http.MethodPost, path, nil, nil); status != http.StatusOK {
+		t.Errorf("ConfirmLease() = unexpected status confirming twice got %d want %d", status, http.StatusOK)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/leases/unknown/confirm", nil, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("ConfirmLease() = unexpected status for a missing lease got %d want %d", status, http.StatusNotFound)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/provisioning/reserve", map[string]any{
+		"ttl": "2h",
+	}, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("ReserveBucketName() = unexpected status for a ttl over the max got %d want %d",
+			status,
+			http.StatusBadRequest,
+		)
+	}
+
+	var expiring api.Lease
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/provisioning/reserve", map[string]any{
+		"ttl": "50ms",
+	}, &expiring)
+	if status != http.StatusCreated {
+		t.Fatalf("ReserveBucketName() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	var problem api.ProblemDetail
+	path = "/api/v1alpha1/leases/" + expiring.Id + "/confirm"
+	if status := doJSON(t, srv, http.MethodPost, path, nil, &problem); status != http.StatusConflict {
+		t.Errorf("ConfirmLease() = unexpected status for an expired lease got %d want %d", status, http.StatusConflict)
+	}
+	if problem.Type != "lease_expired" {
+		t.Errorf("ConfirmLease() = unexpected problem type got %q want %q", problem.Type, "lease_expired")
+	}
+
+	var pending api.Lease
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/provisioning/reserve", nil, &pending)
+	if status != http.StatusCreated {
+		t.Fatalf("ReserveBucketName() = unexpected status without a body got %d want %d", status, http.StatusCreated)
+	}
+	if ttl := pending.ExpiresAt.Sub(pending.CreatedAt); ttl != 5*time.Minute {
+		t.Errorf("ReserveBucketName() = got a ttl of %v, want the default 5m", ttl)
+	}
+}
+
+func TestSeededGeneration(t *testing.T) {
+	srv := newTestServer(t)
+
+	var first, second api.GenerateName200JSONResponse
+	doJSON(t, srv, http.MethodPost, "/api/v1alpha1/generate", map[string]any{"seed": 7}, &first)
+	doJSON(t, srv, http.MethodPost, "/api/v1alpha1/generate", map[string]any{"seed": 7}, &second)
+
+	if first.Name == "" || first.Name != second.Name || first.WordsVersion == nil {
+		t.Fatalf("GenerateName() = got %+v and %+v, want the same name with a words version", first, second)
+	}
+
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/generate", map[string]any{
+		"seed":          7,
+		"words_version": "0000000000000000",
+	}, nil)
+	if status != http.StatusConflict {
+		t.Errorf("GenerateName() = unexpected status for another words version got %d want %d",
+			status,
+			http.StatusConflict,
+		)
+	}
+
+	popOrder := func(name string) []string {
+		var names []string
+		for {
+			var res struct {
+				Name string `json:"name"`
+			}
+			status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/"+name+"/pop", nil, &res)
+			if status != http.StatusOK {
+				return names
+			}
+			names = append(names, res.Name)
+		}
+	}
+
+	var original api.BucketDetails
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "original",
+		"seed": 7,
+	}, &original)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	original = waitFilled(t, srv, "original")
+
+	if original.Seed == nil || *original.Seed != 7 ||
+		original.WordsVersion == nil || *original.WordsVersion != *first.WordsVersion {
+		t.Fatalf("CreateBucket() = got seed %v and words version %v, want 7 and %q",
+			original.Seed,
+			original.WordsVersion,
+			*first.WordsVersion,
+		)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":          "rebuilt",
+		"seed":          7,
+		"words_version": *original.WordsVersion,
+	}, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	waitFilled(t, srv, "rebuilt")
+
+	want := popOrder("original")
+	if got := popOrder("rebuilt"); len(want) != 4 || !slices.Equal(got, want) {
+		t.Errorf("PopBucketName() = got %v from the rebuilt bucket, want %v", got, want)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":          "mismatch",
+		"seed":          7,
+		"words_version": "0000000000000000",
+	}, nil)
+	if status != http.StatusConflict {
+		t.Errorf("CreateBucket() = unexpected status for another words version got %d want %d",
+			status,
+			http.StatusConflict,
+		)
+	}
+
+	status = doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/mismatch", nil, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestLazyBucket(t *testing.T) {
+	srv := newTestServer(t)
+
+	popOrder := func(name string) []string {
+		var names []string
+		for {
+			var res struct {
+				Name string `json:"name"`
+			}
+			status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/"+name+"/pop", nil, &res)
+			if status != http.StatusOK {
+				return names
+			}
+			names = append(names, res.Name)
+		}
+	}
+
+	var lazy api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name": "lazy",
+		"lazy": true,
+	}, &lazy)
+	if status != http.StatusCreated {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+	if !lazy.Lazy || lazy.Seed == nil || lazy.WordsVersion == nil || lazy.RemainingPairs != 4 {
+		t.Fatalf("CreateBucket() = got %+v, want a lazy bucket with a seed, a words version and 4 names", lazy)
+	}
+
+	// the same seed walks the names in the same order
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":          "rebuilt",
+		"lazy":          true,
+		"seed":          *lazy.Seed,
+		"words_version": *lazy.WordsVersion,
+	}, nil)
+	if status != http.StatusCreated {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+
+	var first struct {
+		Name string `json:"name"`
+	}
+	doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/lazy/pop", nil, &first)
+
+	var copied api.BucketDetails
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/lazy/clone", map[string]any{
+		"name":   "copied",
+		"values": "copy_remaining",
+	}, &copied)
+	if status != http.StatusCreated || !copied.Lazy || copied.RemainingPairs != 3 {
+		t.Fatalf("CloneBucket() = unexpected copy %d %+v", status, copied)
+	}
+
+	want := append([]string{first.Name}, popOrder("lazy")...)
+	if got := popOrder("rebuilt"); len(want) != 4 || !slices.Equal(got, want) {
+		t.Errorf("PopBucketName() = got %v from the rebuilt bucket, want %v", got, want)
+	}
+	if got := popOrder("copied"); !slices.Equal(got, want[1:]) {
+		t.Errorf("PopBucketName() = got %v from the copy, want %v", got, want[1:])
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/lazy/pop", nil, &problem)
+	if status != http.StatusConflict || problem.Type != "bucket_exhausted" {
+		t.Errorf("PopBucketName() = unexpected response once exhausted %d %+v", status, problem)
+	}
+
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":          "mismatch",
+		"lazy":          true,
+		"words_version": "0000000000000000",
+	}, nil)
+	if status != http.StatusConflict {
+		t.Errorf("CreateBucket() = unexpected status for another words version got %d want %d",
+			status,
+			http.StatusConflict,
+		)
+	}
+}
+
+func TestDeriveName(t *testing.T) {
+	srv := newTestServer(t)
+
+	derive := func(body map[string]any) (api.DeriveName200JSONResponse, int) {
+		var res api.DeriveName200JSONResponse
+		status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/derive", body, &res)
+		return res, status
+	}
+
+	first, status := derive(map[string]any{"key": "i-0abc123def4567890"})
+	if status != http.StatusOK {
+		t.Fatalf("DeriveName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	second, _ := derive(map[string]any{"key": "i-0abc123def4567890"})
+	if first.Name == "" || first.Name != second.Name || first.WordsVersion == "" {
+		t.Fatalf("DeriveName() = got %+v and %+v, want the same name with a words version", first, second)
+	}
+
+	suffixed, _ := derive(map[string]any{"key": "i-0abc123def4567890", "suffix_length": 6})
+	if !strings.HasPrefix(suffixed.Name, first.Name+"-") || len(suffixed.Name) != len(first.Name)+7 {
+		t.Errorf("DeriveName() = got %q, want %q with a 6 characters suffix", suffixed.Name, first.Name)
+	}
+
+	filtered, _ := derive(map[string]any{
+		"key":     "i-0abc123def4567890",
+		"filters": map[string]any{"adjective_initial": "b"},
+	})
+	if !strings.HasPrefix(filtered.Name, "b") {
+		t.Errorf("DeriveName() = got %q, want a name matching the filters", filtered.Name)
+	}
+
+	cases := []struct {
+		Body   map[string]any
+		Status int
+	}{
+		{Body: map[string]any{"key": ""}, Status: http.StatusBadRequest},
+		{Body: map[string]any{"key": "i-0abc", "suffix_length": 17}, Status: http.StatusBadRequest},
+		{
+			Body:   map[string]any{"key": "i-0abc", "filters": map[string]any{"prefix": "zzzz"}},
+			Status: http.StatusBadRequest,
+		},
+		{Body: map[string]any{"key": "i-0abc", "words_version": "0000000000000000"}, Status: http.StatusConflict},
+		{Body: map[string]any{"key": "i-0abc", "words_version": first.WordsVersion}, Status: http.StatusOK},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			if _, status := derive(tt.Body); status != tt.Status {
+				t.Errorf("DeriveName() = body: %v - unexpected status got %d want %d", tt.Body, status, tt.Status)
+			}
+		})
+	}
+}
+
+func TestGenerateNameValidationErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	var problem api.ProblemDetail
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/generate", map[string]any{
+		"filters": map[string]any{"length_enabled": true},
+	}, &problem)
+	if status != http.StatusBadRequest || problem.Type != "validation_error" || problem.Errors == nil {
+		t.Fatalf("GenerateName() = unexpected response %d %+v", status, problem)
+	}
+	if errs := *problem.Errors; len(errs) != 1 || errs[0].Name != "filters.length" {
+		t.Errorf("GenerateName() = unexpected field errors got %+v want %q", errs, "filters.length")
+	}
+}
+
+func TestDeriveNameValidationErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	var problem api.ProblemDetail
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/derive", map[string]any{
+		"key":           "",
+		"suffix_length": 17,
+		"filters":       map[string]any{"length_enabled": true},
+	}, &problem)
+	if status != http.StatusBadRequest || problem.Type != "validation_error" || problem.Errors == nil {
+		t.Fatalf("DeriveName() = unexpected response %d %+v", status, problem)
+	}
+
+	var fields []string
+	for _, e := range *problem.Errors {
+		fields = append(fields, e.Name)
+	}
+	want := []string{"key", "suffix_length", "filters.length"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("DeriveName() = unexpected field errors got %q want %q", fields, want)
+	}
+}
+
+func TestSampleNames(t *testing.T) {
+	srv := newTestServer(t)
+
+	var stats api.SamplingStats
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/stats/sampling", map[string]any{
+		"samples": 200,
+		"filters": map[string]any{"adjective_initial": "b"},
+	}, &stats)
+	if status != http.StatusOK {
+		t.Fatalf("SampleNames() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	if stats.Samples != 200 || stats.PairCount == 0 || stats.DistinctPairs == 0 {
+		t.Errorf("SampleNames() = got %+v, want 200 samples of the matching pairs", stats)
+	}
+
+	var total int
+	for _, w := range stats.Adjectives.Words {
+		if !strings.HasPrefix(w.Word, "b") {
+			t.Errorf("SampleNames() = got adjective %q, want only the ones matching the filters", w.Word)
+		}
+		total += w.Count
+	}
+	if total != 200 {
+		t.Errorf("SampleNames() = got adjective counts adding up to %d, want 200", total)
+	}
+
+	cases := []struct {
+		Body   map[string]any
+		Status int
+	}{
+		{Body: nil, Status: http.StatusOK},
+		{Body: map[string]any{"samples": 0}, Status: http.StatusBadRequest},
+		{Body: map[string]any{"samples": 10001}, Status: http.StatusBadRequest},
+		{Body: map[string]any{"filters": map[string]any{"prefix": "zzzz"}}, Status: http.StatusBadRequest},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/stats/sampling", tt.Body, nil)
+			if status != tt.Status {
+				t.Errorf("SampleNames() = body: %v - unexpected status got %d want %d", tt.Body, status, tt.Status)
+			}
+		})
+	}
+}
+
+func TestSampleNamesValidationErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	cases := []struct {
+		Body  map[string]any
+		Field string
+	}{
+		{Body: map[string]any{"filters": map[string]any{"length_enabled": true}}, Field: "filters.length"},
+		{Body: map[string]any{"samples": 0}, Field: "samples"},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			var problem api.ProblemDetail
+			status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/stats/sampling", tt.Body, &problem)
+			if status != http.StatusBadRequest || problem.Type != "validation_error" || problem.Errors == nil {
+				t.Fatalf("SampleNames() = unexpected response %d %+v", status, problem)
+			}
+			if errs := *problem.Errors; len(errs) != 1 || errs[0].Name != tt.Field {
+				t.Errorf("SampleNames() = unexpected field errors got %+v want %q", errs, tt.Field)
+			}
+		})
+	}
+}
+
+func TestPreviewBucket(t *testing.T) {
+	srv := newTestServer(t)
+
+	var preview api.BucketPreview
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/preview", map[string]any{
+		"samples": 5,
+		"filters": map[string]any{"adjective_initial": "b"},
+	}, &preview)
+	if status != http.StatusOK {
+		t.Fatalf("PreviewBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	// brave-river and brave-mountain are the only matching names.
+	slices.Sort(preview.Samples)
+	if preview.PairCount != 2 || !slices.Equal(preview.Samples, []string{"brave-mountain", "brave-river"}) {
+		t.Errorf("PreviewBucket() = got %d pairs sampled as %q, want both brave names", preview.PairCount, preview.Samples)
+	}
+	want := []api.LengthCount{{Length: 11, Count: 1}, {Length: 14, Count: 1}}
+	if !slices.Equal(preview.Lengths, want) {
+		t.Errorf("PreviewBucket() = got lengths %v, want %v", preview.Lengths, want)
+	}
+
+	preview = api.BucketPreview{}
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/preview", map[string]any{
+		"filters": map[string]any{"prefix": "zzzz"},
+	}, &preview)
+	if status != http.StatusOK || preview.PairCount != 0 || len(preview.Samples) != 0 {
+		t.Errorf("PreviewBucket() = got %d %+v, want an empty preview", status, preview)
+	}
+
+	cases := []struct {
+		Body   map[string]any
+		Status int
+	}{
+		{Body: nil, Status: http.StatusOK},
+		{Body: map[string]any{"samples": 0}, Status: http.StatusOK},
+		{Body: map[string]any{"samples": 101}, Status: http.StatusBadRequest},
+		{Body: map[string]any{"filters": map[string]any{"adjective_initial": "bb"}}, Status: http.StatusBadRequest},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/preview", tt.Body, nil)
+			if status != tt.Status {
+				t.Errorf("PreviewBucket() = body: %v - unexpected status got %d want %d", tt.Body, status, tt.Status)
+			}
+		})
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/preview", map[string]any{"samples": 101}, &problem)
+	if status != http.StatusBadRequest || problem.Type != "validation_error" || problem.Errors == nil {
+		t.Fatalf("PreviewBucket() = unexpected response %d %+v", status, problem)
+	}
+	if errs := *problem.Errors; len(errs) != 1 || errs[0].Name != "samples" {
+		t.Errorf("PreviewBucket() = unexpected field errors got %+v want %q", errs, "samples")
+	}
+
+	// the bucket the empty preview describes cannot be created.
+	problem = api.ProblemDetail{}
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{
+		"name":    "empty",
+		"filters": map[string]any{"prefix": "zzzz"},
+	}, &problem)
+	if status != http.StatusUnprocessableEntity || problem.Type != "no_matches" {
+		t.Errorf("CreateBucket() = unexpected response to filters matching nothing %d %+v", status, problem)
+	}
+}
+
+// heldFiller never writes the names, so the buckets it is given stay filling.
+type heldFiller struct{}
+
+func (heldFiller) Fill(serverplate.Bucket) {}
+
+func TestFillingBucket(t *testing.T) {
+	srv := newTestServerWithFiller(t, heldFiller{})
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "filling"}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	if created.Fill == nil || created.Fill.State != api.Filling || created.RemainingPairs != 0 {
+		t.Fatalf("CreateBucket() = got fill %+v and %d names, want a filling bucket without names",
+			created.Fill,
+			created.RemainingPairs,
+		)
+	}
+
+	var details api.BucketDetails
+	status = doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/filling", nil, &details)
+	if status != http.StatusOK {
+		t.Fatalf("GetBucketDetails() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if details.Fill == nil || details.Fill.State != api.Filling {
+		t.Errorf("GetBucketDetails() = got fill %+v, want the bucket filling", details.Fill)
+	}
+
+	for _, action := range []string{"pop", "reserve"} {
+		var problem api.ProblemDetail
+		path := "/api/v1alpha1/buckets/filling/" + action
+		if status := doJSON(t, srv, http.MethodPost, path, nil, &problem); status != http.StatusConflict {
+			t.Errorf("%s = unexpected status got %d want %d", action, status, http.StatusConflict)
+		}
+		if problem.Type != "bucket_filling" {
+			t.Errorf("%s = unexpected problem type got %q want %q", action, problem.Type, "bucket_filling")
+		}
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/filling", map[string]any{
+		"filters": map[string]any{"excluded_chars": "b"},
+	}, &problem)
+	if status != http.StatusConflict {
+		t.Errorf("UpdateBucket() = unexpected status for new filters got %d want %d", status, http.StatusConflict)
+	}
+}
+
+func TestBucketFillProgress(t *testing.T) {
+	srv := newTestServer(t)
+
+	var created api.BucketDetails
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "progress"}, &created)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	if created.Fill == nil {
+		t.Fatalf("CreateBucket() = got no fill, want the bucket filling")
+	}
+
+	filled := waitFilled(t, srv, "progress")
+	if filled.RemainingPairs != 4 {
+		t.Errorf("GetBucketDetails() = unexpected remaining pairs got %d want 4", filled.RemainingPairs)
+	}
+
+	var popped struct {
+		Name string `json:"name"`
+	}
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/progress/pop", nil, &popped)
+	if status != http.StatusOK {
+		t.Errorf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+}
+
+// readEvent reads the next event of a Server-Sent Events stream, skipping the comments.
+func readEvent(t *testing.T, sc *bufio.Scanner) (string, api.BucketEvent) {
+	t.Helper()
+
+	var name string
+	var ev api.BucketEvent
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev); err != nil {
+				t.Fatalf("StreamBucketEvents() = invalid event data %q: %v", line, err)
+			}
+		case line == "" && name != "":
+			return name, ev
+		}
+	}
+
+	t.Fatalf("StreamBucketEvents() = stream ended while waiting for an event: %v", sc.Err())
+	return "", api.BucketEvent{}
+}
+
+func TestStreamBucketEvents(t *testing.T) {
+	srv := newTestServer(t)
+
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", map[string]any{"name": "live"}, nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	waitFilled(t, srv, "live")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1alpha1/buckets/live/events", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to open the event stream: %v", err)
+	}
+	defer res.Body.Close()
+
+	if ct := res.Header.Get("Content-Type"); res.StatusCode != http.StatusOK || ct != "text/event-stream" {
+		t.Fatalf("StreamBucketEvents() = got status %d and content type %q, want an event stream", res.StatusCode, ct)
+	}
+
+	sc := bufio.NewScanner(res.Body)
+	if name, ev := readEvent(t, sc); name != "remaining" || ev.Remaining != 4 {
+		t.Fatalf("StreamBucketEvents() = got %s %+v, want the 4 remaining names first", name, ev)
+	}
+
+	var popped struct {
+		Name string `json:"name"`
+	}
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/live/pop", nil, &popped)
+	if status != http.StatusOK {
+		t.Fatalf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	name, ev := readEvent(t, sc)
+	if name != "popped" || ev.Type != api.Popped || ev.Name == nil || *ev.Name != popped.Name || ev.Remaining != 3 {
+		t.Errorf("StreamBucketEvents() = got %s %+v, want %q popped with 3 names remaining", name, ev, popped.Name)
+	}
+
+	var lease api.Lease
+	status = doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/live/reserve", nil, &lease)
+	if status != http.StatusCreated {
+		t.Fatalf("ReserveBucketName() = unexpected status got %d want %d", status, http.StatusCreated)
+	}
+
+	name, ev = readEvent(t, sc)
+	if name != "reserved" || ev.Name == nil || *ev.Name != lease.Name || ev.Remaining != 2 {
+		t.Errorf("StreamBucketEvents() = got %s %+v, want %q reserved with 2 names remaining", name, ev, lease.Name)
+	}
+
+	for _, action := range []string{"archive", "recover"} {
+		path := "/api/v1alpha1/buckets/live/" + action
+		if status := doJSON(t, srv, http.MethodPost, path, nil, nil); status != http.StatusOK {
+			t.Fatalf("%s = unexpected status got %d want %d", action, status, http.StatusOK)
+		}
+	}
+
+	if name, ev := readEvent(t, sc); name != "archived" || ev.Name != nil {
+		t.Errorf("StreamBucketEvents() = got %s %+v, want the bucket archived", name, ev)
+	}
+	if name, _ := readEvent(t, sc); name != "recovered" {
+		t.Errorf("StreamBucketEvents() = got %s, want the bucket recovered", name)
+	}
+
+	var problem api.ProblemDetail
+	status = doJSON(t, srv, http.MethodGet, "/api/v1alpha1/buckets/missing/events", nil, &problem)
+	if status != http.StatusNotFound {
+		t.Errorf("StreamBucketEvents() = unexpected status got %d want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestAuditEvents(t *testing.T) {
+	srv := newTestServer(t)
+
+	body := map[string]any{"name": "audited", "description": "before"}
+	if status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets", body, nil); status != http.StatusAccepted {
+		t.Fatalf("CreateBucket() = unexpected status got %d want %d", status, http.StatusAccepted)
+	}
+	details := waitFilled(t, srv, "audited")
+
+	var popped struct {
+		Name string `json:"name"`
+	}
+	status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/audited/pop", nil, &popped)
+	if status != http.StatusOK {
+		t.Fatalf("PopBucketName() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	update := map[string]any{"description": "after"}
+	if status := doJSON(t, srv, http.MethodPatch, "/api/v1alpha1/buckets/audited", update, nil); status != http.StatusOK {
+		t.Fatalf("UpdateBucket() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	for range 2 {
+		status := doJSON(t, srv, http.MethodPost, "/api/v1alpha1/buckets/audited/archive", nil, nil)
+		if status != http.StatusOK {
+			t.Fatalf("ArchiveBucket() = unexpected status got %d want %d", status, http.StatusOK)
+		}
+	}
+
+	var list struct {
+		Events []api.AuditEvent `json:"events"`
+		Next   *string          `json:"next"`
+	}
+	if status := doJSON(t, srv, http.MethodGet, "/api/v1alpha1/audit", nil, &list); status != http.StatusOK {
+		t.Fatalf("ListAuditEvents() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+
+	var actions []api.AuditAction
+	for _, e := range list.Events {
+		actions = append(actions, e.Action)
+		if e.BucketId != details.Id || e.BucketName != "audited" {
+			t.Errorf("ListAuditEvents() = got the event of %d %q, want the audited bucket", e.BucketId, e.BucketName)
+		}
+	}
+
+	// archiving an archived bucket changes nothing, so it is not recorded again.
+	want := []api.AuditAction{api.BucketArchived, api.BucketUpdated, api.BucketPopped, api.BucketCreated}
+	if !slices.Equal(actions, want) || list.Next != nil {
+		t.Fatalf("ListAuditEvents() = got %v with next %v, want %v", actions, list.Next, want)
+	}
+
+	updated := list.Events[1]
+	before, _ := updated.Before.(map[string]any)
+	after, _ := updated.After.(map[string]any)
+	if before["description"] != "before" || after["description"] != "after" {
+		t.Errorf("ListAuditEvents() = got the update from %v to %v, want the description change", before, after)
+	}
+
+	if name, _ := list.Events[2].After.(map[string]any); name["name"] != popped.Name {
+		t.Errorf("ListAuditEvents() = got the popped name %v, want %q", name, popped.Name)
+	}
+
+	if list.Events[3].Before != nil {
+		t.Errorf("ListAuditEvents() = got the state %v before the creation, want null", list.Events[3].Before)
+	}
+
+	path := "/api/v1alpha1/audit?action=bucket.popped"
+	if status := doJSON(t, srv, http.MethodGet, path, nil, &list); status != http.StatusOK {
+		t.Fatalf("ListAuditEvents() = unexpected status got %d want %d", status, http.StatusOK)
+	}
+	if len(list.Events) != 1 || list.Events[0].Action != api.BucketPopped {
+		t.Errorf("ListAuditEvents() = got %+v filtering by action, want the popped event", list.Events)
+	}
+
+	var pages []api.AuditAction
+	next := new("/api/v1alpha1/audit?limit=3")
+	for next != nil {
+		list.Next = nil
+		if status := doJSON(t, srv, http.MethodGet, *next, nil, &list); status != http.StatusOK {
+			t.Fatalf("ListAuditEvents() = unexpected status got %d want %d", status, http.StatusOK)
+		}
+		for _, e := range list.Events {
+			pages = append(pages, e.Action)
+		}
+		next = list.Next
+	}
+
+	if !slices.Equal(pages, want) {
+		t.Errorf("ListAuditEvents() = got %v following the next links, want %v", pages, want)
+	}
+
+	for _, path := range []string{"/api/v1alpha1/audit?limit=0", "/api/v1alpha1/audit?action=bucket.painted"} {
+		if status := doJSON(t, srv, http.MethodGet, path, nil, nil); status != http.StatusBadRequest {
+			t.Errorf("ListAuditEvents() = unexpected status for %s got %d want %d", path, status, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// eventsKeepAlive is how often a comment is sent on a quiet stream, so that proxies do not close it as idle.
+const eventsKeepAlive = 15 * time.Second
+
+// bucketEventStream streams the events of a bucket as Server-Sent Events. It takes the place of the generated
+// response, which copies its body without flushing the events as they happen.
+type bucketEventStream struct {
+	ctx         context.Context
+	bucket      serverplate.Bucket
+	bucketStore serverplate.BucketStore
+	events      <-chan serverplate.BucketEvent
+	cancel      func()
+	// remaining is the amount of names of the bucket when the subscription started.
+	remaining int64
+}
+
+func (s bucketEventStream) VisitStreamBucketEventsResponse(w http.ResponseWriter) error {
+	defer s.cancel()
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	first := serverplate.BucketEvent{Type: serverplate.BucketEventRemaining, BucketID: s.bucket.ID, At: time.Now()}
+	if err := s.write(w, rc, first, s.remaining); err != nil {
+		return nil
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			if err := rc.Flush(); err != nil {
+				return nil
+			}
+		case ev, ok := <-s.events:
+			if !ok {
+				return nil
+			}
+
+			remaining, err := s.bucketStore.RemainingValuesTotal(s.ctx, s.bucket)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("failed to get remaining pairs count: %w", err)
+			}
+
+			// a failed write means the client is gone, there is nobody left to report it to.
+			if err := s.write(w, rc, ev, remaining); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (s bucketEventStream) write(
+	w http.ResponseWriter,
+	rc *http.ResponseController,
+	ev serverplate.BucketEvent,
+	remaining int64,
+) error {
+	data, err := json.Marshal(BucketEvent{
+		Type:      BucketEventType(ev.Type),
+		Name:      nonZero(ev.Name),
+		Remaining: remaining,
+		At:        ev.At,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
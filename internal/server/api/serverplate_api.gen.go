@@ -25,62 +25,250 @@ import (
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
-// Defines values for BucketDetailsFiltersLengthMode.
+// Defines values for AuditAction.
 const (
-	BucketDetailsFiltersLengthModeExactly BucketDetailsFiltersLengthMode = "exactly"
-	BucketDetailsFiltersLengthModeUpto    BucketDetailsFiltersLengthMode = "upto"
+	BucketArchived  AuditAction = "bucket.archived"
+	BucketCreated   AuditAction = "bucket.created"
+	BucketDeleted   AuditAction = "bucket.deleted"
+	BucketPopped    AuditAction = "bucket.popped"
+	BucketRecovered AuditAction = "bucket.recovered"
+	BucketReserved  AuditAction = "bucket.reserved"
+	BucketUpdated   AuditAction = "bucket.updated"
+	LeaseConfirmed  AuditAction = "lease.confirmed"
+	LeaseReleased   AuditAction = "lease.released"
 )
 
-// Valid indicates whether the value is a known member of the BucketDetailsFiltersLengthMode enum.
-func (e BucketDetailsFiltersLengthMode) Valid() bool {
+// Valid indicates whether the value is a known member of the AuditAction enum.
+func (e AuditAction) Valid() bool {
 	switch e {
-	case BucketDetailsFiltersLengthModeExactly:
+	case BucketArchived:
 		return true
-	case BucketDetailsFiltersLengthModeUpto:
+	case BucketCreated:
+		return true
+	case BucketDeleted:
+		return true
+	case BucketPopped:
+		return true
+	case BucketRecovered:
+		return true
+	case BucketReserved:
+		return true
+	case BucketUpdated:
+		return true
+	case LeaseConfirmed:
+		return true
+	case LeaseReleased:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for AuditSource.
+const (
+	AuditSourceApi AuditSource = "api"
+	AuditSourceJob AuditSource = "job"
+	AuditSourceWeb AuditSource = "web"
+)
+
+// Valid indicates whether the value is a known member of the AuditSource enum.
+func (e AuditSource) Valid() bool {
+	switch e {
+	case AuditSourceApi:
+		return true
+	case AuditSourceJob:
+		return true
+	case AuditSourceWeb:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for BucketEventType.
+const (
+	Archived  BucketEventType = "archived"
+	Popped    BucketEventType = "popped"
+	Recovered BucketEventType = "recovered"
+	Released  BucketEventType = "released"
+	Remaining BucketEventType = "remaining"
+	Reserved  BucketEventType = "reserved"
+)
+
+// Valid indicates whether the value is a known member of the BucketEventType enum.
+func (e BucketEventType) Valid() bool {
+	switch e {
+	case Archived:
+		return true
+	case Popped:
+		return true
+	case Recovered:
+		return true
+	case Released:
+		return true
+	case Remaining:
+		return true
+	case Reserved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for BucketFillState.
+const (
+	Failed  BucketFillState = "failed"
+	Filling BucketFillState = "filling"
+)
+
+// Valid indicates whether the value is a known member of the BucketFillState enum.
+func (e BucketFillState) Valid() bool {
+	switch e {
+	case Failed:
+		return true
+	case Filling:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for BucketFiltersLengthMode.
+const (
+	BucketFiltersLengthModeExactly BucketFiltersLengthMode = "exactly"
+	BucketFiltersLengthModeUpto    BucketFiltersLengthMode = "upto"
+)
+
+// Valid indicates whether the value is a known member of the BucketFiltersLengthMode enum.
+func (e BucketFiltersLengthMode) Valid() bool {
+	switch e {
+	case BucketFiltersLengthModeExactly:
+		return true
+	case BucketFiltersLengthModeUpto:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FiltersLengthMode.
+const (
+	FiltersLengthModeExactly FiltersLengthMode = "exactly"
+	FiltersLengthModeUpto    FiltersLengthMode = "upto"
+)
+
+// Valid indicates whether the value is a known member of the FiltersLengthMode enum.
+func (e FiltersLengthMode) Valid() bool {
+	switch e {
+	case FiltersLengthModeExactly:
+		return true
+	case FiltersLengthModeUpto:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListBucketsParamsSort.
+const (
+	CreatedAt ListBucketsParamsSort = "created_at"
+	Name      ListBucketsParamsSort = "name"
+	UpdatedAt ListBucketsParamsSort = "updated_at"
+)
+
+// Valid indicates whether the value is a known member of the ListBucketsParamsSort enum.
+func (e ListBucketsParamsSort) Valid() bool {
+	switch e {
+	case CreatedAt:
+		return true
+	case Name:
+		return true
+	case UpdatedAt:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for CreateBucketJSONBodyFiltersLengthMode.
+// Defines values for ListBucketsParamsOrder.
 const (
-	CreateBucketJSONBodyFiltersLengthModeExactly CreateBucketJSONBodyFiltersLengthMode = "exactly"
-	CreateBucketJSONBodyFiltersLengthModeUpto    CreateBucketJSONBodyFiltersLengthMode = "upto"
+	Asc  ListBucketsParamsOrder = "asc"
+	Desc ListBucketsParamsOrder = "desc"
 )
 
-// Valid indicates whether the value is a known member of the CreateBucketJSONBodyFiltersLengthMode enum.
-func (e CreateBucketJSONBodyFiltersLengthMode) Valid() bool {
+// Valid indicates whether the value is a known member of the ListBucketsParamsOrder enum.
+func (e ListBucketsParamsOrder) Valid() bool {
 	switch e {
-	case CreateBucketJSONBodyFiltersLengthModeExactly:
+	case Asc:
 		return true
-	case CreateBucketJSONBodyFiltersLengthModeUpto:
+	case Desc:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for GenerateNameJSONBodyFiltersLengthMode.
+// Defines values for CloneBucketJSONBodyValues.
 const (
-	Exactly GenerateNameJSONBodyFiltersLengthMode = "exactly"
-	Upto    GenerateNameJSONBodyFiltersLengthMode = "upto"
+	CopyRemaining CloneBucketJSONBodyValues = "copy_remaining"
+	Reshuffle     CloneBucketJSONBodyValues = "reshuffle"
 )
 
-// Valid indicates whether the value is a known member of the GenerateNameJSONBodyFiltersLengthMode enum.
-func (e GenerateNameJSONBodyFiltersLengthMode) Valid() bool {
+// Valid indicates whether the value is a known member of the CloneBucketJSONBodyValues enum.
+func (e CloneBucketJSONBodyValues) Valid() bool {
 	switch e {
-	case Exactly:
+	case CopyRemaining:
 		return true
-	case Upto:
+	case Reshuffle:
 		return true
 	default:
 		return false
 	}
 }
 
+// AuditAction The change an audit event records
+type AuditAction string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	// Action The change an audit event records
+	Action AuditAction `json:"action"`
+
+	// Actor Who made the change, the name of the job for the background jobs
+	Actor string `json:"actor"`
+
+	// After State of the bucket after the change, or the name handed out or returned (null if there is none, like after a bucket is deleted)
+	After interface{} `json:"after"`
+
+	// Before State of the bucket before the change, or the name handed out or returned (null if there was none, like before a bucket is created)
+	Before   interface{} `json:"before"`
+	BucketId int32       `json:"bucket_id"`
+
+	// BucketName Name of the bucket when the change was made
+	BucketName string `json:"bucket_name"`
+
+	// CreatedAt When the change was made
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+
+	// Ip Address of the client that made the change (null if it was not made through a request)
+	Ip *string `json:"ip"`
+
+	// RequestId Id of the request that made the change (null if it was not made through a request)
+	RequestId *string `json:"request_id"`
+
+	// Source Where a change was made from, `job` for the background jobs
+	Source AuditSource `json:"source"`
+}
+
+// AuditSource Where a change was made from, `job` for the background jobs
+type AuditSource string
+
 // BucketDetails defines model for BucketDetails.
 type BucketDetails struct {
+	// ArchiveWhenExhausted Whether the bucket is archived automatically once every name has been popped
+	ArchiveWhenExhausted bool `json:"archive_when_exhausted"`
+
 	// ArchivedAt Timestamp when the bucket was archived
 	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 
@@ -90,33 +278,117 @@ type BucketDetails struct {
 	// Description Description of the bucket
 	Description string `json:"description"`
 
-	// Filters Filter configuration for this bucket
-	Filters struct {
-		// Length Length constraint value (null if not enabled)
-		Length *int `json:"length,omitempty"`
+	// ExpiresAt When the bucket is archived automatically, null when it never expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
-		// LengthEnabled Whether length filtering is enabled
-		LengthEnabled bool `json:"length_enabled"`
+	// Fill Progress of the names being written after the bucket was created (null once they are all written)
+	Fill *BucketFill `json:"fill"`
 
-		// LengthMode Mode for length constraint
-		LengthMode *BucketDetailsFiltersLengthMode `json:"length_mode,omitempty"`
-	} `json:"filters"`
+	// Filters Filter configuration for this bucket
+	Filters BucketFilters `json:"filters"`
 
 	// Id Unique identifier for the bucket
 	Id int32 `json:"id"`
 
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels Labels `json:"labels"`
+
+	// Lazy Whether the names of the bucket are computed when they are popped
+	Lazy bool `json:"lazy"`
+
 	// Name Name of the bucket
 	Name string `json:"name"`
 
 	// RemainingPairs Number of names remaining in the bucket
 	RemainingPairs int64 `json:"remaining_pairs"`
 
+	// RemovalAt When the archived bucket will be removed, null when the bucket is not archived or it is kept forever
+	RemovalAt *time.Time `json:"removal_at,omitempty"`
+
+	// Retention How long the bucket is kept once archived before being removed, `0` when it is kept forever. Null when the server default is used.
+	Retention *string `json:"retention,omitempty"`
+
+	// Seed Seed the names of the bucket were shuffled with, null when they were shuffled randomly
+	Seed *int64 `json:"seed,omitempty"`
+
 	// UpdatedAt Timestamp when the bucket was last updated
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// WordsVersion Version of the word lists the seeded bucket was built from, null when it is not seeded
+	WordsVersion *string `json:"words_version,omitempty"`
+}
+
+// BucketEvent defines model for BucketEvent.
+type BucketEvent struct {
+	// At When the event happened
+	At time.Time `json:"at"`
+
+	// Name The name popped, reserved or released (null for the other events)
+	Name *string `json:"name"`
+
+	// Remaining Number of names remaining in the bucket once the event happened
+	Remaining int64 `json:"remaining"`
+
+	// Type `popped`, `reserved` and `released` are about a name handed out or returned to the bucket, `archived` and `recovered` about its status, and `remaining` is sent when the remaining names change otherwise, like when its filters change or its fill ends.
+	Type BucketEventType `json:"type"`
+}
+
+// BucketEventType `popped`, `reserved` and `released` are about a name handed out or returned to the bucket, `archived` and `recovered` about its status, and `remaining` is sent when the remaining names change otherwise, like when its filters change or its fill ends.
+type BucketEventType string
+
+// BucketFill defines model for BucketFill.
+type BucketFill struct {
+	// Done Number of names written so far
+	Done int64 `json:"done"`
+
+	// Error Why the fill failed (null unless it failed)
+	Error *string `json:"error"`
+
+	// State `filling` while the names are being written, `failed` when writing them failed. The bucket cannot hand out names in either state.
+	State BucketFillState `json:"state"`
+
+	// Total Number of names to write, 0 until the fill starts
+	Total int64 `json:"total"`
 }
 
-// BucketDetailsFiltersLengthMode Mode for length constraint
-type BucketDetailsFiltersLengthMode string
+// BucketFillState `filling` while the names are being written, `failed` when writing them failed. The bucket cannot hand out names in either state.
+type BucketFillState string
+
+// BucketFilters Filter configuration for this bucket
+type BucketFilters struct {
+	// AdjectiveInitial Letter the adjective must start with (null if not set)
+	AdjectiveInitial *string `json:"adjective_initial,omitempty"`
+
+	// Alliterative Whether adjective and noun must start with the same letter
+	Alliterative bool `json:"alliterative"`
+
+	// ExcludedChars Characters that never appear in the names (null if not set)
+	ExcludedChars *string `json:"excluded_chars,omitempty"`
+
+	// Length Length constraint value (null if not enabled)
+	Length *int `json:"length,omitempty"`
+
+	// LengthEnabled Whether length filtering is enabled
+	LengthEnabled bool `json:"length_enabled"`
+
+	// LengthMode Mode for length constraint
+	LengthMode *BucketFiltersLengthMode `json:"length_mode,omitempty"`
+
+	// MinLength Minimum length of the names (null if not set)
+	MinLength *int `json:"min_length,omitempty"`
+
+	// NounInitial Letter the noun must start with (null if not set)
+	NounInitial *string `json:"noun_initial,omitempty"`
+
+	// Prefix Fixed text the names start with (null if not set)
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Suffix Fixed text the names end with (null if not set)
+	Suffix *string `json:"suffix,omitempty"`
+}
+
+// BucketFiltersLengthMode Mode for length constraint
+type BucketFiltersLengthMode string
 
 // BucketListItem defines model for BucketListItem.
 type BucketListItem struct {
@@ -132,732 +404,2058 @@ type BucketListItem struct {
 	// Id Unique identifier for the bucket
 	Id int32 `json:"id"`
 
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels Labels `json:"labels"`
+
 	// Name Name of the bucket
 	Name string `json:"name"`
 
+	// RemainingPairs Number of remaining pairs in the bucket, only present when `include_remaining` is requested
+	RemainingPairs *int64 `json:"remaining_pairs,omitempty"`
+
 	// UpdatedAt Timestamp when the bucket was last updated
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
-// ProblemDetail RFC 7807 Problem Details for HTTP APIs
-type ProblemDetail struct {
-	// Detail A human-readable explanation specific to this occurrence
-	Detail *string `json:"detail,omitempty"`
-
-	// Status HTTP status code
-	Status int `json:"status"`
+// BucketPreview defines model for BucketPreview.
+type BucketPreview struct {
+	// Lengths Number of matching names of each length, by ascending length. Lengths no name has are left out.
+	Lengths []LengthCount `json:"lengths"`
 
-	// Title A short, human-readable summary of the problem
-	Title string `json:"title"`
+	// PairCount Number of names matching the filters, the bucket would hold that many names
+	PairCount int `json:"pair_count"`
 
-	// Type A URI reference that identifies the problem type
-	Type string `json:"type"`
+	// Samples Names matching the filters picked at random, fewer than asked when not enough names match
+	Samples []string `json:"samples"`
 }
 
-// ListBucketsParams defines parameters for ListBuckets.
-type ListBucketsParams struct {
-	// Archived If present (regardless of value), returns only archived buckets
-	Archived *string `form:"archived,omitempty" json:"archived,omitempty"`
-}
+// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+type Filters struct {
+	// AdjectiveInitial Letter the adjective must start with
+	AdjectiveInitial *string `json:"adjective_initial,omitempty"`
 
-// CreateBucketJSONBody defines parameters for CreateBucket.
-type CreateBucketJSONBody struct {
-	// Description Description of the bucket
-	Description *string `json:"description,omitempty"`
+	// Alliterative Only generate names whose adjective and noun start with the same letter
+	Alliterative *bool `json:"alliterative,omitempty"`
 
-	// Filters Optional filters for name generation. If not provided, names are generated without constraints.
-	Filters *struct {
-		// Length Length constraint for generated names (required if length_enabled is true)
-		Length *int `json:"length,omitempty"`
+	// ExcludedChars Characters that must not appear in the name, useful to avoid the ones that are easy to confuse
+	ExcludedChars *string `json:"excluded_chars,omitempty"`
 
-		// LengthEnabled Whether length filtering is enabled
-		LengthEnabled *bool `json:"length_enabled,omitempty"`
+	// Length Length constraint for generated names (required if length_enabled is true)
+	Length *int `json:"length,omitempty"`
 
-		// LengthMode Mode for length constraint
-		LengthMode *CreateBucketJSONBodyFiltersLengthMode `json:"length_mode,omitempty"`
-	} `json:"filters,omitempty"`
+	// LengthEnabled Whether length filtering is enabled
+	LengthEnabled *bool `json:"length_enabled,omitempty"`
 
-	// Name Name of the bucket
-	Name string `json:"name"`
-}
+	// LengthMode Mode for length constraint
+	LengthMode *FiltersLengthMode `json:"length_mode,omitempty"`
 
-// CreateBucketJSONBodyFiltersLengthMode defines parameters for CreateBucket.
-type CreateBucketJSONBodyFiltersLengthMode string
+	// MinLength Minimum length of the generated names. Combined with an `upto` length it builds a length range.
+	MinLength *int `json:"min_length,omitempty"`
 
-// UpdateBucketJSONBody defines parameters for UpdateBucket.
-type UpdateBucketJSONBody struct {
-	// Description New description for the bucket. Use empty string to clear the description.
-	Description *string `json:"description,omitempty"`
+	// NounInitial Letter the noun must start with
+	NounInitial *string `json:"noun_initial,omitempty"`
+
+	// Prefix Fixed text the whole name must start with
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Suffix Fixed text the whole name must end with
+	Suffix *string `json:"suffix,omitempty"`
 }
 
-// GenerateNameJSONBody defines parameters for GenerateName.
-type GenerateNameJSONBody struct {
-	// Filters Optional filters for name generation. If not provided, names are generated without constraints.
-	Filters *struct {
-		// Length Length constraint for generated names (required if length_enabled is true)
-		Length *int `json:"length,omitempty"`
+// FiltersLengthMode Mode for length constraint
+type FiltersLengthMode string
 
-		// LengthEnabled Whether length filtering is enabled
-		LengthEnabled *bool `json:"length_enabled,omitempty"`
+// InvalidParam A request field rejected by the validation
+type InvalidParam struct {
+	// Name Name of the rejected field, nested fields are joined with a dot
+	Name string `json:"name"`
 
-		// LengthMode Mode for length constraint
-		LengthMode *GenerateNameJSONBodyFiltersLengthMode `json:"length_mode,omitempty"`
-	} `json:"filters,omitempty"`
+	// Reason Why the value of the field was rejected
+	Reason string `json:"reason"`
 }
 
-// GenerateNameJSONBodyFiltersLengthMode defines parameters for GenerateName.
-type GenerateNameJSONBodyFiltersLengthMode string
-
-// CreateBucketJSONRequestBody defines body for CreateBucket for application/json ContentType.
-type CreateBucketJSONRequestBody CreateBucketJSONBody
+// Job defines model for Job.
+type Job struct {
+	// LastRun The latest run of the job (null if it never ran)
+	LastRun *JobRun `json:"last_run"`
 
-// UpdateBucketJSONRequestBody defines body for UpdateBucket for application/json ContentType.
-type UpdateBucketJSONRequestBody UpdateBucketJSONBody
+	// Name Name of the job
+	Name string `json:"name"`
 
-// GenerateNameJSONRequestBody defines body for GenerateName for application/json ContentType.
-type GenerateNameJSONRequestBody GenerateNameJSONBody
+	// NextRunAt When the job runs next (null if the scheduler is not running)
+	NextRunAt *time.Time `json:"next_run_at"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// List all buckets
-	// (GET /v1alpha1/buckets)
-	ListBuckets(w http.ResponseWriter, r *http.Request, params ListBucketsParams)
-	// Create a new bucket
-	// (POST /v1alpha1/buckets)
-	CreateBucket(w http.ResponseWriter, r *http.Request)
-	// Get bucket details
-	// (GET /v1alpha1/buckets/{id})
-	GetBucketDetails(w http.ResponseWriter, r *http.Request, id int32)
-	// Update bucket
-	// (PATCH /v1alpha1/buckets/{id})
-	UpdateBucket(w http.ResponseWriter, r *http.Request, id int32)
-	// Archive a bucket
-	// (POST /v1alpha1/buckets/{id}/archive)
-	ArchiveBucket(w http.ResponseWriter, r *http.Request, id int32)
-	// Pop a name from bucket
-	// (POST /v1alpha1/buckets/{id}/pop)
-	PopBucketName(w http.ResponseWriter, r *http.Request, id int32)
-	// Recover an archived bucket
-	// (POST /v1alpha1/buckets/{id}/recover)
-	RecoverBucket(w http.ResponseWriter, r *http.Request, id int32)
-	// Generate a random server name
-	// (POST /v1alpha1/generate)
-	GenerateName(w http.ResponseWriter, r *http.Request)
+	// Schedule Cron expression the job runs on
+	Schedule string `json:"schedule"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+// JobRun defines model for JobRun.
+type JobRun struct {
+	// AffectedRows Amount of records the run changed or removed
+	AffectedRows int64 `json:"affected_rows"`
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	// EndedAt When the run ended
+	EndedAt time.Time `json:"ended_at"`
 
-// ListBuckets operation middleware
-func (siw *ServerInterfaceWrapper) ListBuckets(w http.ResponseWriter, r *http.Request) {
+	// Error The error the run failed with (null if it succeeded)
+	Error *string `json:"error"`
 
-	var err error
+	// StartedAt When the run started
+	StartedAt time.Time `json:"started_at"`
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListBucketsParams
+// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+type Labels map[string]string
 
-	// ------------- Optional query parameter "archived" -------------
+// Lease defines model for Lease.
+type Lease struct {
+	// BucketId ID of the bucket the name was reserved from
+	BucketId int32 `json:"bucket_id"`
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "archived", r.URL.Query(), &params.Archived, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived", Err: err})
-		return
-	}
+	// ConfirmedAt When the lease was confirmed (null while it is pending)
+	ConfirmedAt *time.Time `json:"confirmed_at"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListBuckets(w, r, params)
-	}))
+	// CreatedAt When the name was reserved
+	CreatedAt time.Time `json:"created_at"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ExpiresAt When the name goes back to the bucket unless the lease is confirmed
+	ExpiresAt time.Time `json:"expires_at"`
 
-	handler.ServeHTTP(w, r)
+	// Id Lease ID
+	Id string `json:"id"`
+
+	// Name The reserved server name
+	Name string `json:"name"`
 }
 
-// CreateBucket operation middleware
-func (siw *ServerInterfaceWrapper) CreateBucket(w http.ResponseWriter, r *http.Request) {
+// LengthCount defines model for LengthCount.
+type LengthCount struct {
+	// Count Number of matching names of that length
+	Count int `json:"count"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateBucket(w, r)
-	}))
+	// Length Length of the names, in characters
+	Length int `json:"length"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// ProblemDetail RFC 7807 Problem Details for HTTP APIs
+type ProblemDetail struct {
+	// Detail A human-readable explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
 
-	handler.ServeHTTP(w, r)
-}
+	// Errors Every field rejected by the validation, only present on `validation_error` problems. It is the invalid-params extension of RFC 7807.
+	Errors *[]InvalidParam `json:"errors,omitempty"`
 
-// GetBucketDetails operation middleware
-func (siw *ServerInterfaceWrapper) GetBucketDetails(w http.ResponseWriter, r *http.Request) {
+	// Status HTTP status code
+	Status int `json:"status"`
 
-	var err error
+	// Title A short, human-readable summary of the problem
+	Title string `json:"title"`
 
-	// ------------- Path parameter "id" -------------
-	var id int32
+	// Type A URI reference that identifies the problem type
+	Type string `json:"type"`
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+// SamplingStats defines model for SamplingStats.
+type SamplingStats struct {
+	Adjectives WordDistribution `json:"adjectives"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBucketDetails(w, r, id)
-	}))
+	// DistinctPairs Number of matching pairs picked at least once
+	DistinctPairs int              `json:"distinct_pairs"`
+	Nouns         WordDistribution `json:"nouns"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// PairCount Number of pairs matching the filters
+	PairCount int `json:"pair_count"`
 
-	handler.ServeHTTP(w, r)
+	// Samples Number of names picked
+	Samples int `json:"samples"`
 }
 
-// UpdateBucket operation middleware
-func (siw *ServerInterfaceWrapper) UpdateBucket(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// UpdatedBucketDetails defines model for UpdatedBucketDetails.
+type UpdatedBucketDetails struct {
+	// ArchiveWhenExhausted Whether the bucket is archived automatically once every name has been popped
+	ArchiveWhenExhausted bool `json:"archive_when_exhausted"`
+
+	// ArchivedAt Timestamp when the bucket was archived
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// CreatedAt Timestamp when the bucket was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Description Description of the bucket
+	Description string `json:"description"`
+
+	// ExpiresAt When the bucket is archived automatically, null when it never expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Fill Progress of the names being written after the bucket was created (null once they are all written)
+	Fill *BucketFill `json:"fill"`
+
+	// Filters Filter configuration for this bucket
+	Filters BucketFilters `json:"filters"`
+
+	// Id Unique identifier for the bucket
+	Id int32 `json:"id"`
+
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels Labels `json:"labels"`
+
+	// Lazy Whether the names of the bucket are computed when they are popped
+	Lazy bool `json:"lazy"`
+
+	// Name Name of the bucket
+	Name string `json:"name"`
+
+	// RemainingPairs Number of names remaining in the bucket
+	RemainingPairs int64 `json:"remaining_pairs"`
+
+	// RemainingPairsDelta Change in the amount of remaining pairs caused by the update, negative when the new filters are more restrictive. Zero when the filters were not updated.
+	RemainingPairsDelta int64 `json:"remaining_pairs_delta"`
+
+	// RemovalAt When the archived bucket will be removed, null when the bucket is not archived or it is kept forever
+	RemovalAt *time.Time `json:"removal_at,omitempty"`
+
+	// Retention How long the bucket is kept once archived before being removed, `0` when it is kept forever. Null when the server default is used.
+	Retention *string `json:"retention,omitempty"`
+
+	// Seed Seed the names of the bucket were shuffled with, null when they were shuffled randomly
+	Seed *int64 `json:"seed,omitempty"`
+
+	// UpdatedAt Timestamp when the bucket was last updated
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// WordsVersion Version of the word lists the seeded bucket was built from, null when it is not seeded
+	WordsVersion *string `json:"words_version,omitempty"`
+}
+
+// WordDistribution defines model for WordDistribution.
+type WordDistribution struct {
+	// ChiSquare Pearson's chi-squared statistic of the counts, a uniform pick keeps it close to `degrees_of_freedom`, a much larger value points to a bias.
+	ChiSquare float64 `json:"chi_square"`
+
+	// DegreesOfFreedom Number of words minus one
+	DegreesOfFreedom int `json:"degrees_of_freedom"`
+
+	// Words Every word that is part of a matching pair, by descending count
+	Words []WordFrequency `json:"words"`
+}
+
+// WordFrequency defines model for WordFrequency.
+type WordFrequency struct {
+	// Count Number of picked names with the word
+	Count int `json:"count"`
+
+	// Expected Number of picked names a uniform pick would have with the word on average
+	Expected float64 `json:"expected"`
+	Word     string  `json:"word"`
+}
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// BucketId Only returns the events of the bucket, which may have been removed since
+	BucketId *int32 `form:"bucket_id,omitempty" json:"bucket_id,omitempty"`
+
+	// Action Only returns the events of the action
+	Action *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+
+	// Actor Only returns the events made by the actor
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Source Only returns the events made from the source
+	Source *AuditSource `form:"source,omitempty" json:"source,omitempty"`
+
+	// Since Only returns the events recorded at or after the time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only returns the events recorded before the time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Before Only returns the events older than the event with the id, used by the `next` link
+	Before *int64 `form:"before,omitempty" json:"before,omitempty"`
+
+	// Limit Maximum amount of events returned
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListBucketsParams defines parameters for ListBuckets.
+type ListBucketsParams struct {
+	// Archived If present (regardless of value), returns only archived buckets
+	Archived *string `form:"archived,omitempty" json:"archived,omitempty"`
+
+	// Q Only returns the buckets whose name or description contain the given text, ignoring the case
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort Field the buckets are sorted by. Buckets are sorted by id when not given. `updated_at` uses the creation time of buckets never updated.
+	Sort *ListBucketsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Direction of the sort
+	Order *ListBucketsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Maximum amount of buckets returned
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Amount of buckets skipped before the first returned one
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Selector Only returns the buckets having every given label, as comma separated `key=value` pairs
+	Selector *string `form:"selector,omitempty" json:"selector,omitempty"`
+
+	// IncludeRemaining Whether to include the remaining pairs of every bucket
+	IncludeRemaining *bool `form:"include_remaining,omitempty" json:"include_remaining,omitempty"`
+}
+
+// ListBucketsParamsSort defines parameters for ListBuckets.
+type ListBucketsParamsSort string
+
+// ListBucketsParamsOrder defines parameters for ListBuckets.
+type ListBucketsParamsOrder string
+
+// CreateBucketJSONBody defines parameters for CreateBucket.
+type CreateBucketJSONBody struct {
+	// ArchiveWhenExhausted Archive the bucket automatically once every name has been popped.
+	ArchiveWhenExhausted *bool `json:"archive_when_exhausted,omitempty"`
+
+	// Description Description of the bucket
+	Description *string `json:"description,omitempty"`
+
+	// ExpiresAt RFC 3339 time the bucket is archived automatically at, it never expires when not given.
+	ExpiresAt *string `json:"expires_at,omitempty"`
+
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// Lazy Compute each name when it is popped instead of storing every name when the bucket is created, which makes creating it instant whatever the number of matching names. Lazy buckets are always seeded, a random seed is picked when none is given, and they stop handing out names once the word lists change. Names returned by an expired lease are handed out first and updating the filters only applies to the names not walked past yet. The filters of a lazy bucket must match at least one name in 1000.
+	Lazy *bool `json:"lazy,omitempty"`
+
+	// Name Name of the bucket
+	Name string `json:"name"`
+
+	// Retention How long the bucket is kept once archived before being removed, as a duration like `720h`. `0` keeps the bucket forever and the server default is used when not given.
+	Retention *string `json:"retention,omitempty"`
+
+	// Seed Shuffles the names of the bucket deterministically, the same seed, filters and word lists always give the same order. The names are shuffled randomly when not given.
+	Seed *int64 `json:"seed,omitempty"`
+
+	// WordsVersion Version of the word lists the seeded bucket must be built from, like the `words_version` of the bucket being rebuilt. Ignored without a seed.
+	WordsVersion *string `json:"words_version,omitempty"`
+}
+
+// PreviewBucketJSONBody defines parameters for PreviewBucket.
+type PreviewBucketJSONBody struct {
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Samples Number of sample names to return
+	Samples *int `json:"samples,omitempty"`
+}
+
+// DeleteBucketParams defines parameters for DeleteBucket.
+type DeleteBucketParams struct {
+	// Confirm Name of the bucket, confirms that the bucket is meant to be removed
+	Confirm string `form:"confirm" json:"confirm"`
+}
+
+// UpdateBucketJSONBody defines parameters for UpdateBucket.
+type UpdateBucketJSONBody struct {
+	// ArchiveWhenExhausted Archive the bucket automatically once every name has been popped.
+	ArchiveWhenExhausted *bool `json:"archive_when_exhausted,omitempty"`
+
+	// Description New description for the bucket. Use empty string to clear the description.
+	Description *string `json:"description,omitempty"`
+
+	// ExpiresAt RFC 3339 time the bucket is archived automatically at, an empty string removes the expiry.
+	ExpiresAt *string `json:"expires_at,omitempty"`
+
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// Name New name for the bucket. Must be lowercase alphanumeric or '-', up to 63 characters, start and end with an alphanumeric character and not be made only of digits.
+	Name *string `json:"name,omitempty"`
+
+	// Retention How long the bucket is kept once archived before being removed, as a duration like `720h`. `0` keeps the bucket forever and an empty string restores the server default.
+	Retention *string `json:"retention,omitempty"`
+}
+
+// CloneBucketJSONBody defines parameters for CloneBucket.
+type CloneBucketJSONBody struct {
+	// Description Description of the new bucket, the one of the source bucket when not given
+	Description *string `json:"description,omitempty"`
+
+	// Labels Key/value labels of the bucket. Keys are lowercase alphanumeric, '-', '_' or '.' up to 63 characters, values are alphanumeric, '-', '_' or '.' up to 63 characters and can be empty.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// Name Name of the new bucket
+	Name string `json:"name"`
+
+	// Values How the new bucket is filled. `reshuffle` generates every name matching the filters in a new random order, `copy_remaining` copies the names the source bucket has not popped yet keeping their order. Clones of lazy buckets are lazy too, reshuffling picks a new seed and copying continues from where the source bucket is.
+	Values *CloneBucketJSONBodyValues `json:"values,omitempty"`
+}
+
+// CloneBucketJSONBodyValues defines parameters for CloneBucket.
+type CloneBucketJSONBodyValues string
+
+// ReserveBucketNameJSONBody defines parameters for ReserveBucketName.
+type ReserveBucketNameJSONBody struct {
+	// Ttl How long the name waits to be confirmed, the server default when omitted
+	Ttl *string `json:"ttl,omitempty"`
+}
+
+// DeriveNameJSONBody defines parameters for DeriveName.
+type DeriveNameJSONBody struct {
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Key The input the name is derived from, at most 1024 bytes long
+	Key string `json:"key"`
+
+	// Namespace Keys the hash, the same key derives unrelated names in different namespaces
+	Namespace *string `json:"namespace,omitempty"`
+
+	// SuffixLength Number of hex characters derived from the key appended to the name, telling apart the keys that map to the same adjective-noun pair. The filters only apply to the pair.
+	SuffixLength *int `json:"suffix_length,omitempty"`
+
+	// WordsVersion Version of the word lists the name must be derived from, see the `words_version` of the response.
+	WordsVersion *string `json:"words_version,omitempty"`
+}
+
+// GenerateNameJSONBody defines parameters for GenerateName.
+type GenerateNameJSONBody struct {
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Seed Picks the name deterministically, the same seed, filters and word lists always give the same name. The name is picked randomly when not given.
+	Seed *int64 `json:"seed,omitempty"`
+
+	// WordsVersion Version of the word lists the seeded name must be picked from, see the `words_version` of the response. Ignored without a seed.
+	WordsVersion *string `json:"words_version,omitempty"`
+}
+
+// SampleNamesJSONBody defines parameters for SampleNames.
+type SampleNamesJSONBody struct {
+	// Filters Optional filters for name generation. If not provided, names are generated without constraints. Every filter is optional and they are combined.
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Samples Number of names to pick
+	Samples *int `json:"samples,omitempty"`
+}
+
+// CreateBucketJSONRequestBody defines body for CreateBucket for application/json ContentType.
+type CreateBucketJSONRequestBody CreateBucketJSONBody
+
+// PreviewBucketJSONRequestBody defines body for PreviewBucket for application/json ContentType.
+type PreviewBucketJSONRequestBody PreviewBucketJSONBody
+
+// UpdateBucketJSONRequestBody defines body for UpdateBucket for application/json ContentType.
+type UpdateBucketJSONRequestBody UpdateBucketJSONBody
+
+// CloneBucketJSONRequestBody defines body for CloneBucket for application/json ContentType.
+type CloneBucketJSONRequestBody CloneBucketJSONBody
+
+// ReserveBucketNameJSONRequestBody defines body for ReserveBucketName for application/json ContentType.
+type ReserveBucketNameJSONRequestBody ReserveBucketNameJSONBody
+
+// DeriveNameJSONRequestBody defines body for DeriveName for application/json ContentType.
+type DeriveNameJSONRequestBody DeriveNameJSONBody
+
+// GenerateNameJSONRequestBody defines body for GenerateName for application/json ContentType.
+type GenerateNameJSONRequestBody GenerateNameJSONBody
+
+// SampleNamesJSONRequestBody defines body for SampleNames for application/json ContentType.
+type SampleNamesJSONRequestBody SampleNamesJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List the audit events
+	// (GET /v1alpha1/audit)
+	ListAuditEvents(w http.ResponseWriter, r *http.Request, params ListAuditEventsParams)
+	// List buckets
+	// (GET /v1alpha1/buckets)
+	ListBuckets(w http.ResponseWriter, r *http.Request, params ListBucketsParams)
+	// Create a new bucket
+	// (POST /v1alpha1/buckets)
+	CreateBucket(w http.ResponseWriter, r *http.Request)
+	// Preview the names a bucket would hold
+	// (POST /v1alpha1/buckets/preview)
+	PreviewBucket(w http.ResponseWriter, r *http.Request)
+	// Delete an archived bucket
+	// (DELETE /v1alpha1/buckets/{id})
+	DeleteBucket(w http.ResponseWriter, r *http.Request, id string, params DeleteBucketParams)
+	// Get bucket details
+	// (GET /v1alpha1/buckets/{id})
+	GetBucketDetails(w http.ResponseWriter, r *http.Request, id string)
+	// Update bucket
+	// (PATCH /v1alpha1/buckets/{id})
+	UpdateBucket(w http.ResponseWriter, r *http.Request, id string)
+	// Archive a bucket
+	// (POST /v1alpha1/buckets/{id}/archive)
+	ArchiveBucket(w http.ResponseWriter, r *http.Request, id string)
+	// Clone a bucket
+	// (POST /v1alpha1/buckets/{id}/clone)
+	CloneBucket(w http.ResponseWriter, r *http.Request, id string)
+	// Stream the activity of a bucket
+	// (GET /v1alpha1/buckets/{id}/events)
+	StreamBucketEvents(w http.ResponseWriter, r *http.Request, id string)
+	// Pop a name from bucket
+	// (POST /v1alpha1/buckets/{id}/pop)
+	PopBucketName(w http.ResponseWriter, r *http.Request, id string)
+	// Recover an archived bucket
+	// (POST /v1alpha1/buckets/{id}/recover)
+	RecoverBucket(w http.ResponseWriter, r *http.Request, id string)
+	// Reserve a name from a bucket
+	// (POST /v1alpha1/buckets/{id}/reserve)
+	ReserveBucketName(w http.ResponseWriter, r *http.Request, id string)
+	// Derive a stable server name from a key
+	// (POST /v1alpha1/derive)
+	DeriveName(w http.ResponseWriter, r *http.Request)
+	// Generate a random server name
+	// (POST /v1alpha1/generate)
+	GenerateName(w http.ResponseWriter, r *http.Request)
+	// List the background jobs
+	// (GET /v1alpha1/jobs)
+	ListJobs(w http.ResponseWriter, r *http.Request)
+	// Run a job now
+	// (POST /v1alpha1/jobs/{name}/run)
+	RunJob(w http.ResponseWriter, r *http.Request, name string)
+	// Confirm a lease
+	// (POST /v1alpha1/leases/{id}/confirm)
+	ConfirmLease(w http.ResponseWriter, r *http.Request, id string)
+	// Sample random names and report their distribution
+	// (POST /v1alpha1/stats/sampling)
+	SampleNames(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+
+	// ------------- Optional query parameter "bucket_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "bucket_id", r.URL.Query(), &params.BucketId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bucket_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "action", r.URL.Query(), &params.Action, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "actor", r.URL.Query(), &params.Actor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "source", r.URL.Query(), &params.Source, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "since", r.URL.Query(), &params.Since, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "until", r.URL.Query(), &params.Until, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "before", r.URL.Query(), &params.Before, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBuckets operation middleware
+func (siw *ServerInterfaceWrapper) ListBuckets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBucketsParams
+
+	// ------------- Optional query parameter "archived" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "archived", r.URL.Query(), &params.Archived, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", r.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "sort", r.URL.Query(), &params.Sort, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "order", r.URL.Query(), &params.Order, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "selector" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "selector", r.URL.Query(), &params.Selector, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "selector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_remaining" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "include_remaining", r.URL.Query(), &params.IncludeRemaining, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_remaining", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBuckets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBucket operation middleware
+func (siw *ServerInterfaceWrapper) CreateBucket(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBucket(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewBucket operation middleware
+func (siw *ServerInterfaceWrapper) PreviewBucket(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewBucket(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBucket operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBucket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteBucketParams
+
+	// ------------- Required query parameter "confirm" -------------
+
+	if paramValue := r.URL.Query().Get("confirm"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "confirm"})
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "confirm", r.URL.Query(), &params.Confirm, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "confirm", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBucket(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBucketDetails operation middleware
+func (siw *ServerInterfaceWrapper) GetBucketDetails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBucketDetails(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBucket operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBucket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBucket(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ArchiveBucket operation middleware
+func (siw *ServerInterfaceWrapper) ArchiveBucket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ArchiveBucket(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CloneBucket operation middleware
+func (siw *ServerInterfaceWrapper) CloneBucket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CloneBucket(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamBucketEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamBucketEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamBucketEvents(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PopBucketName operation middleware
+func (siw *ServerInterfaceWrapper) PopBucketName(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PopBucketName(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RecoverBucket operation middleware
+func (siw *ServerInterfaceWrapper) RecoverBucket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RecoverBucket(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReserveBucketName operation middleware
+func (siw *ServerInterfaceWrapper) ReserveBucketName(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReserveBucketName(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeriveName operation middleware
+func (siw *ServerInterfaceWrapper) DeriveName(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeriveName(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GenerateName operation middleware
+func (siw *ServerInterfaceWrapper) GenerateName(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateName(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListJobs(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListJobs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RunJob operation middleware
+func (siw *ServerInterfaceWrapper) RunJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RunJob(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmLease operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmLease(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id int32
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmLease(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SampleNames operation middleware
+func (siw *ServerInterfaceWrapper) SampleNames(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SampleNames(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/audit", wrapper.ListAuditEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/buckets", wrapper.ListBuckets)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets", wrapper.CreateBucket)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/preview", wrapper.PreviewBucket)
+	m.HandleFunc("DELETE "+options.BaseURL+"/v1alpha1/buckets/{id}", wrapper.DeleteBucket)
+	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/buckets/{id}", wrapper.GetBucketDetails)
+	m.HandleFunc("PATCH "+options.BaseURL+"/v1alpha1/buckets/{id}", wrapper.UpdateBucket)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/archive", wrapper.ArchiveBucket)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/clone", wrapper.CloneBucket)
+	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/buckets/{id}/events", wrapper.StreamBucketEvents)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/pop", wrapper.PopBucketName)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/recover", wrapper.RecoverBucket)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/reserve", wrapper.ReserveBucketName)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/derive", wrapper.DeriveName)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/generate", wrapper.GenerateName)
+	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/jobs", wrapper.ListJobs)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/jobs/{name}/run", wrapper.RunJob)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/leases/{id}/confirm", wrapper.ConfirmLease)
+	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/stats/sampling", wrapper.SampleNames)
+
+	return m
+}
+
+type ListAuditEventsRequestObject struct {
+	Params ListAuditEventsParams
+}
+
+type ListAuditEventsResponseObject interface {
+	VisitListAuditEventsResponse(w http.ResponseWriter) error
+}
+
+type ListAuditEvents200JSONResponse struct {
+	Events []AuditEvent `json:"events"`
+
+	// Next Link to the next page of events, null when this is the last page
+	Next *string `json:"next,omitempty"`
+}
+
+func (response ListAuditEvents200JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEvents400JSONResponse ProblemDetail
+
+func (response ListAuditEvents400JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEvents500JSONResponse ProblemDetail
+
+func (response ListAuditEvents500JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListBucketsRequestObject struct {
+	Params ListBucketsParams
+}
+
+type ListBucketsResponseObject interface {
+	VisitListBucketsResponse(w http.ResponseWriter) error
+}
+
+type ListBuckets200JSONResponse struct {
+	Buckets []BucketListItem `json:"buckets"`
+
+	// Next Link to the next page of buckets, null when this is the last page
+	Next *string `json:"next,omitempty"`
+}
+
+func (response ListBuckets200JSONResponse) VisitListBucketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListBuckets400JSONResponse ProblemDetail
+
+func (response ListBuckets400JSONResponse) VisitListBucketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListBuckets500JSONResponse ProblemDetail
+
+func (response ListBuckets500JSONResponse) VisitListBucketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBucketRequestObject struct {
+	Body *CreateBucketJSONRequestBody
+}
+
+type CreateBucketResponseObject interface {
+	VisitCreateBucketResponse(w http.ResponseWriter) error
+}
+
+type CreateBucket201JSONResponse BucketDetails
+
+func (response CreateBucket201JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBucket202JSONResponse BucketDetails
+
+func (response CreateBucket202JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBucket400JSONResponse ProblemDetail
+
+func (response CreateBucket400JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBucket409JSONResponse ProblemDetail
+
+func (response CreateBucket409JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBucket422JSONResponse ProblemDetail
+
+func (response CreateBucket422JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateBucket(w, r, id)
-	}))
+type CreateBucket500JSONResponse ProblemDetail
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+func (response CreateBucket500JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	handler.ServeHTTP(w, r)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ArchiveBucket operation middleware
-func (siw *ServerInterfaceWrapper) ArchiveBucket(w http.ResponseWriter, r *http.Request) {
+type PreviewBucketRequestObject struct {
+	Body *PreviewBucketJSONRequestBody
+}
 
-	var err error
+type PreviewBucketResponseObject interface {
+	VisitPreviewBucketResponse(w http.ResponseWriter) error
+}
 
-	// ------------- Path parameter "id" -------------
-	var id int32
+type PreviewBucket200JSONResponse BucketPreview
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+func (response PreviewBucket200JSONResponse) VisitPreviewBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ArchiveBucket(w, r, id)
-	}))
+	return json.NewEncoder(w).Encode(response)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+type PreviewBucket400JSONResponse ProblemDetail
 
-	handler.ServeHTTP(w, r)
+func (response PreviewBucket400JSONResponse) VisitPreviewBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// PopBucketName operation middleware
-func (siw *ServerInterfaceWrapper) PopBucketName(w http.ResponseWriter, r *http.Request) {
+type PreviewBucket500JSONResponse ProblemDetail
 
-	var err error
+func (response PreviewBucket500JSONResponse) VisitPreviewBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	// ------------- Path parameter "id" -------------
-	var id int32
+	return json.NewEncoder(w).Encode(response)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+type DeleteBucketRequestObject struct {
+	Id     string `json:"id"`
+	Params DeleteBucketParams
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PopBucketName(w, r, id)
-	}))
+type DeleteBucketResponseObject interface {
+	VisitDeleteBucketResponse(w http.ResponseWriter) error
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+type DeleteBucket204Response struct {
+}
 
-	handler.ServeHTTP(w, r)
+func (response DeleteBucket204Response) VisitDeleteBucketResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-// RecoverBucket operation middleware
-func (siw *ServerInterfaceWrapper) RecoverBucket(w http.ResponseWriter, r *http.Request) {
+type DeleteBucket400JSONResponse ProblemDetail
 
-	var err error
+func (response DeleteBucket400JSONResponse) VisitDeleteBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	// ------------- Path parameter "id" -------------
-	var id int32
+	return json.NewEncoder(w).Encode(response)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+type DeleteBucket404JSONResponse ProblemDetail
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RecoverBucket(w, r, id)
-	}))
+func (response DeleteBucket404JSONResponse) VisitDeleteBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r)
+type DeleteBucket409JSONResponse ProblemDetail
+
+func (response DeleteBucket409JSONResponse) VisitDeleteBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// GenerateName operation middleware
-func (siw *ServerInterfaceWrapper) GenerateName(w http.ResponseWriter, r *http.Request) {
+type DeleteBucket500JSONResponse ProblemDetail
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GenerateName(w, r)
-	}))
+func (response DeleteBucket500JSONResponse) VisitDeleteBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r)
+type GetBucketDetailsRequestObject struct {
+	Id string `json:"id"`
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+type GetBucketDetailsResponseObject interface {
+	VisitGetBucketDetailsResponse(w http.ResponseWriter) error
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+type GetBucketDetails200JSONResponse BucketDetails
+
+func (response GetBucketDetails200JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+type GetBucketDetails404JSONResponse ProblemDetail
+
+func (response GetBucketDetails404JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
+type GetBucketDetails500JSONResponse ProblemDetail
+
+func (response GetBucketDetails500JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+type UpdateBucketRequestObject struct {
+	Id   string `json:"id"`
+	Body *UpdateBucketJSONRequestBody
 }
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+type UpdateBucketResponseObject interface {
+	VisitUpdateBucketResponse(w http.ResponseWriter) error
 }
 
-type RequiredParamError struct {
-	ParamName string
+type UpdateBucket200JSONResponse UpdatedBucketDetails
+
+func (response UpdateBucket200JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+type UpdateBucket400JSONResponse ProblemDetail
+
+func (response UpdateBucket400JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+type UpdateBucket404JSONResponse ProblemDetail
+
+func (response UpdateBucket404JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+type UpdateBucket409JSONResponse ProblemDetail
+
+func (response UpdateBucket409JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+type UpdateBucket500JSONResponse ProblemDetail
+
+func (response UpdateBucket500JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+type ArchiveBucketRequestObject struct {
+	Id string `json:"id"`
+}
+
+type ArchiveBucketResponseObject interface {
+	VisitArchiveBucketResponse(w http.ResponseWriter) error
+}
+
+type ArchiveBucket200JSONResponse BucketDetails
+
+func (response ArchiveBucket200JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveBucket404JSONResponse ProblemDetail
+
+func (response ArchiveBucket404JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveBucket500JSONResponse ProblemDetail
+
+func (response ArchiveBucket500JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneBucketRequestObject struct {
+	Id   string `json:"id"`
+	Body *CloneBucketJSONRequestBody
+}
+
+type CloneBucketResponseObject interface {
+	VisitCloneBucketResponse(w http.ResponseWriter) error
+}
+
+type CloneBucket201JSONResponse BucketDetails
+
+func (response CloneBucket201JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneBucket202JSONResponse BucketDetails
+
+func (response CloneBucket202JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloneBucket400JSONResponse ProblemDetail
+
+func (response CloneBucket400JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+type CloneBucket404JSONResponse ProblemDetail
+
+func (response CloneBucket404JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+type CloneBucket409JSONResponse ProblemDetail
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+func (response CloneBucket409JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+type CloneBucket500JSONResponse ProblemDetail
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+func (response CloneBucket500JSONResponse) VisitCloneBucketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+type StreamBucketEventsRequestObject struct {
+	Id string `json:"id"`
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+type StreamBucketEventsResponseObject interface {
+	VisitStreamBucketEventsResponse(w http.ResponseWriter) error
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+type StreamBucketEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+func (response StreamBucketEvents200TexteventStreamResponse) VisitStreamBucketEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
+	w.WriteHeader(200)
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
 	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
 
-	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/buckets", wrapper.ListBuckets)
-	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets", wrapper.CreateBucket)
-	m.HandleFunc("GET "+options.BaseURL+"/v1alpha1/buckets/{id}", wrapper.GetBucketDetails)
-	m.HandleFunc("PATCH "+options.BaseURL+"/v1alpha1/buckets/{id}", wrapper.UpdateBucket)
-	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/archive", wrapper.ArchiveBucket)
-	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/pop", wrapper.PopBucketName)
-	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/buckets/{id}/recover", wrapper.RecoverBucket)
-	m.HandleFunc("POST "+options.BaseURL+"/v1alpha1/generate", wrapper.GenerateName)
+type StreamBucketEvents404JSONResponse ProblemDetail
 
-	return m
+func (response StreamBucketEvents404JSONResponse) VisitStreamBucketEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListBucketsRequestObject struct {
-	Params ListBucketsParams
+type StreamBucketEvents500JSONResponse ProblemDetail
+
+func (response StreamBucketEvents500JSONResponse) VisitStreamBucketEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListBucketsResponseObject interface {
-	VisitListBucketsResponse(w http.ResponseWriter) error
+type PopBucketNameRequestObject struct {
+	Id string `json:"id"`
 }
 
-type ListBuckets200JSONResponse struct {
-	Buckets []BucketListItem `json:"buckets"`
+type PopBucketNameResponseObject interface {
+	VisitPopBucketNameResponse(w http.ResponseWriter) error
 }
 
-func (response ListBuckets200JSONResponse) VisitListBucketsResponse(w http.ResponseWriter) error {
+type PopBucketName200JSONResponse struct {
+	// Name The popped server name
+	Name string `json:"name"`
+}
+
+func (response PopBucketName200JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListBuckets500JSONResponse ProblemDetail
+type PopBucketName404JSONResponse ProblemDetail
 
-func (response ListBuckets500JSONResponse) VisitListBucketsResponse(w http.ResponseWriter) error {
+func (response PopBucketName404JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateBucketRequestObject struct {
-	Body *CreateBucketJSONRequestBody
-}
-
-type CreateBucketResponseObject interface {
-	VisitCreateBucketResponse(w http.ResponseWriter) error
-}
-
-type CreateBucket201JSONResponse BucketDetails
+type PopBucketName409JSONResponse ProblemDetail
 
-func (response CreateBucket201JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+func (response PopBucketName409JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateBucket500JSONResponse ProblemDetail
+type PopBucketName500JSONResponse ProblemDetail
 
-func (response CreateBucket500JSONResponse) VisitCreateBucketResponse(w http.ResponseWriter) error {
+func (response PopBucketName500JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetBucketDetailsRequestObject struct {
-	Id int32 `json:"id"`
+type RecoverBucketRequestObject struct {
+	Id string `json:"id"`
 }
 
-type GetBucketDetailsResponseObject interface {
-	VisitGetBucketDetailsResponse(w http.ResponseWriter) error
+type RecoverBucketResponseObject interface {
+	VisitRecoverBucketResponse(w http.ResponseWriter) error
 }
 
-type GetBucketDetails200JSONResponse BucketDetails
+type RecoverBucket200JSONResponse BucketDetails
 
-func (response GetBucketDetails200JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+func (response RecoverBucket200JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetBucketDetails404JSONResponse ProblemDetail
+type RecoverBucket404JSONResponse ProblemDetail
 
-func (response GetBucketDetails404JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+func (response RecoverBucket404JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetBucketDetails500JSONResponse ProblemDetail
+type RecoverBucket500JSONResponse ProblemDetail
 
-func (response GetBucketDetails500JSONResponse) VisitGetBucketDetailsResponse(w http.ResponseWriter) error {
+func (response RecoverBucket500JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateBucketRequestObject struct {
-	Id   int32 `json:"id"`
-	Body *UpdateBucketJSONRequestBody
+type ReserveBucketNameRequestObject struct {
+	Id   string `json:"id"`
+	Body *ReserveBucketNameJSONRequestBody
 }
 
-type UpdateBucketResponseObject interface {
-	VisitUpdateBucketResponse(w http.ResponseWriter) error
+type ReserveBucketNameResponseObject interface {
+	VisitReserveBucketNameResponse(w http.ResponseWriter) error
 }
 
-type UpdateBucket200JSONResponse BucketDetails
+type ReserveBucketName201JSONResponse Lease
 
-func (response UpdateBucket200JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+func (response ReserveBucketName201JSONResponse) VisitReserveBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateBucket400JSONResponse ProblemDetail
+type ReserveBucketName400JSONResponse ProblemDetail
 
-func (response UpdateBucket400JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+func (response ReserveBucketName400JSONResponse) VisitReserveBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateBucket404JSONResponse ProblemDetail
+type ReserveBucketName404JSONResponse ProblemDetail
 
-func (response UpdateBucket404JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+func (response ReserveBucketName404JSONResponse) VisitReserveBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateBucket409JSONResponse ProblemDetail
+type ReserveBucketName409JSONResponse ProblemDetail
 
-func (response UpdateBucket409JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+func (response ReserveBucketName409JSONResponse) VisitReserveBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateBucket500JSONResponse ProblemDetail
+type ReserveBucketName500JSONResponse ProblemDetail
 
-func (response UpdateBucket500JSONResponse) VisitUpdateBucketResponse(w http.ResponseWriter) error {
+func (response ReserveBucketName500JSONResponse) VisitReserveBucketNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ArchiveBucketRequestObject struct {
-	Id int32 `json:"id"`
+type DeriveNameRequestObject struct {
+	Body *DeriveNameJSONRequestBody
 }
 
-type ArchiveBucketResponseObject interface {
-	VisitArchiveBucketResponse(w http.ResponseWriter) error
+type DeriveNameResponseObject interface {
+	VisitDeriveNameResponse(w http.ResponseWriter) error
 }
 
-type ArchiveBucket200JSONResponse BucketDetails
+type DeriveName200JSONResponse struct {
+	// Name The derived server name
+	Name string `json:"name"`
 
-func (response ArchiveBucket200JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+	// WordsVersion Version of the word lists the name was derived from
+	WordsVersion string `json:"words_version"`
+}
+
+func (response DeriveName200JSONResponse) VisitDeriveNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ArchiveBucket404JSONResponse ProblemDetail
+type DeriveName400JSONResponse ProblemDetail
 
-func (response ArchiveBucket404JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+func (response DeriveName400JSONResponse) VisitDeriveNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ArchiveBucket500JSONResponse ProblemDetail
+type DeriveName409JSONResponse ProblemDetail
 
-func (response ArchiveBucket500JSONResponse) VisitArchiveBucketResponse(w http.ResponseWriter) error {
+func (response DeriveName409JSONResponse) VisitDeriveNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeriveName500JSONResponse ProblemDetail
+
+func (response DeriveName500JSONResponse) VisitDeriveNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PopBucketNameRequestObject struct {
-	Id int32 `json:"id"`
+type GenerateNameRequestObject struct {
+	Body *GenerateNameJSONRequestBody
 }
 
-type PopBucketNameResponseObject interface {
-	VisitPopBucketNameResponse(w http.ResponseWriter) error
+type GenerateNameResponseObject interface {
+	VisitGenerateNameResponse(w http.ResponseWriter) error
 }
 
-type PopBucketName200JSONResponse struct {
-	// Name The popped server name
+type GenerateName200JSONResponse struct {
+	// Name The generated server name
 	Name string `json:"name"`
+
+	// WordsVersion Version of the word lists the name was picked from, only returned for seeded names
+	WordsVersion *string `json:"words_version,omitempty"`
 }
 
-func (response PopBucketName200JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
+func (response GenerateName200JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PopBucketName404JSONResponse ProblemDetail
+type GenerateName400JSONResponse ProblemDetail
 
-func (response PopBucketName404JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
+func (response GenerateName400JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PopBucketName409JSONResponse ProblemDetail
+type GenerateName409JSONResponse ProblemDetail
 
-func (response PopBucketName409JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
+func (response GenerateName409JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PopBucketName500JSONResponse ProblemDetail
+type GenerateName500JSONResponse ProblemDetail
 
-func (response PopBucketName500JSONResponse) VisitPopBucketNameResponse(w http.ResponseWriter) error {
+func (response GenerateName500JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RecoverBucketRequestObject struct {
-	Id int32 `json:"id"`
+type ListJobsRequestObject struct {
 }
 
-type RecoverBucketResponseObject interface {
-	VisitRecoverBucketResponse(w http.ResponseWriter) error
+type ListJobsResponseObject interface {
+	VisitListJobsResponse(w http.ResponseWriter) error
 }
 
-type RecoverBucket200JSONResponse BucketDetails
+type ListJobs200JSONResponse struct {
+	Jobs []Job `json:"jobs"`
+}
 
-func (response RecoverBucket200JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
+func (response ListJobs200JSONResponse) VisitListJobsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RecoverBucket404JSONResponse ProblemDetail
+type ListJobs500JSONResponse ProblemDetail
 
-func (response RecoverBucket404JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
+func (response ListJobs500JSONResponse) VisitListJobsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RunJobRequestObject struct {
+	Name string `json:"name"`
+}
+
+type RunJobResponseObject interface {
+	VisitRunJobResponse(w http.ResponseWriter) error
+}
+
+type RunJob202Response struct {
+}
+
+func (response RunJob202Response) VisitRunJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type RunJob404JSONResponse ProblemDetail
+
+func (response RunJob404JSONResponse) VisitRunJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RunJob500JSONResponse ProblemDetail
+
+func (response RunJob500JSONResponse) VisitRunJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmLeaseRequestObject struct {
+	Id string `json:"id"`
+}
+
+type ConfirmLeaseResponseObject interface {
+	VisitConfirmLeaseResponse(w http.ResponseWriter) error
+}
+
+type ConfirmLease200JSONResponse Lease
+
+func (response ConfirmLease200JSONResponse) VisitConfirmLeaseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmLease404JSONResponse ProblemDetail
+
+func (response ConfirmLease404JSONResponse) VisitConfirmLeaseResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RecoverBucket500JSONResponse ProblemDetail
+type ConfirmLease409JSONResponse ProblemDetail
+
+func (response ConfirmLease409JSONResponse) VisitConfirmLeaseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmLease500JSONResponse ProblemDetail
 
-func (response RecoverBucket500JSONResponse) VisitRecoverBucketResponse(w http.ResponseWriter) error {
+func (response ConfirmLease500JSONResponse) VisitConfirmLeaseResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateNameRequestObject struct {
-	Body *GenerateNameJSONRequestBody
+type SampleNamesRequestObject struct {
+	Body *SampleNamesJSONRequestBody
 }
 
-type GenerateNameResponseObject interface {
-	VisitGenerateNameResponse(w http.ResponseWriter) error
+type SampleNamesResponseObject interface {
+	VisitSampleNamesResponse(w http.ResponseWriter) error
 }
 
-type GenerateName200JSONResponse struct {
-	// Name The generated server name
-	Name string `json:"name"`
-}
+type SampleNames200JSONResponse SamplingStats
 
-func (response GenerateName200JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
+func (response SampleNames200JSONResponse) VisitSampleNamesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateName400JSONResponse ProblemDetail
+type SampleNames400JSONResponse ProblemDetail
 
-func (response GenerateName400JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
+func (response SampleNames400JSONResponse) VisitSampleNamesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateName500JSONResponse ProblemDetail
+type SampleNames500JSONResponse ProblemDetail
 
-func (response GenerateName500JSONResponse) VisitGenerateNameResponse(w http.ResponseWriter) error {
+func (response SampleNames500JSONResponse) VisitSampleNamesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -866,12 +2464,21 @@ func (response GenerateName500JSONResponse) VisitGenerateNameResponse(w http.Res
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
-	// List all buckets
+	// List the audit events
+	// (GET /v1alpha1/audit)
+	ListAuditEvents(ctx context.Context, request ListAuditEventsRequestObject) (ListAuditEventsResponseObject, error)
+	// List buckets
 	// (GET /v1alpha1/buckets)
 	ListBuckets(ctx context.Context, request ListBucketsRequestObject) (ListBucketsResponseObject, error)
 	// Create a new bucket
 	// (POST /v1alpha1/buckets)
 	CreateBucket(ctx context.Context, request CreateBucketRequestObject) (CreateBucketResponseObject, error)
+	// Preview the names a bucket would hold
+	// (POST /v1alpha1/buckets/preview)
+	PreviewBucket(ctx context.Context, request PreviewBucketRequestObject) (PreviewBucketResponseObject, error)
+	// Delete an archived bucket
+	// (DELETE /v1alpha1/buckets/{id})
+	DeleteBucket(ctx context.Context, request DeleteBucketRequestObject) (DeleteBucketResponseObject, error)
 	// Get bucket details
 	// (GET /v1alpha1/buckets/{id})
 	GetBucketDetails(ctx context.Context, request GetBucketDetailsRequestObject) (GetBucketDetailsResponseObject, error)
@@ -881,15 +2488,39 @@ type StrictServerInterface interface {
 	// Archive a bucket
 	// (POST /v1alpha1/buckets/{id}/archive)
 	ArchiveBucket(ctx context.Context, request ArchiveBucketRequestObject) (ArchiveBucketResponseObject, error)
+	// Clone a bucket
+	// (POST /v1alpha1/buckets/{id}/clone)
+	CloneBucket(ctx context.Context, request CloneBucketRequestObject) (CloneBucketResponseObject, error)
+	// Stream the activity of a bucket
+	// (GET /v1alpha1/buckets/{id}/events)
+	StreamBucketEvents(ctx context.Context, request StreamBucketEventsRequestObject) (StreamBucketEventsResponseObject, error)
 	// Pop a name from bucket
 	// (POST /v1alpha1/buckets/{id}/pop)
 	PopBucketName(ctx context.Context, request PopBucketNameRequestObject) (PopBucketNameResponseObject, error)
 	// Recover an archived bucket
 	// (POST /v1alpha1/buckets/{id}/recover)
 	RecoverBucket(ctx context.Context, request RecoverBucketRequestObject) (RecoverBucketResponseObject, error)
+	// Reserve a name from a bucket
+	// (POST /v1alpha1/buckets/{id}/reserve)
+	ReserveBucketName(ctx context.Context, request ReserveBucketNameRequestObject) (ReserveBucketNameResponseObject, error)
+	// Derive a stable server name from a key
+	// (POST /v1alpha1/derive)
+	DeriveName(ctx context.Context, request DeriveNameRequestObject) (DeriveNameResponseObject, error)
 	// Generate a random server name
 	// (POST /v1alpha1/generate)
 	GenerateName(ctx context.Context, request GenerateNameRequestObject) (GenerateNameResponseObject, error)
+	// List the background jobs
+	// (GET /v1alpha1/jobs)
+	ListJobs(ctx context.Context, request ListJobsRequestObject) (ListJobsResponseObject, error)
+	// Run a job now
+	// (POST /v1alpha1/jobs/{name}/run)
+	RunJob(ctx context.Context, request RunJobRequestObject) (RunJobResponseObject, error)
+	// Confirm a lease
+	// (POST /v1alpha1/leases/{id}/confirm)
+	ConfirmLease(ctx context.Context, request ConfirmLeaseRequestObject) (ConfirmLeaseResponseObject, error)
+	// Sample random names and report their distribution
+	// (POST /v1alpha1/stats/sampling)
+	SampleNames(ctx context.Context, request SampleNamesRequestObject) (SampleNamesResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -921,6 +2552,32 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ListAuditEvents operation middleware
+func (sh *strictHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request, params ListAuditEventsParams) {
+	var request ListAuditEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAuditEvents(ctx, request.(ListAuditEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAuditEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAuditEventsResponseObject); ok {
+		if err := validResponse.VisitListAuditEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListBuckets operation middleware
 func (sh *strictHandler) ListBuckets(w http.ResponseWriter, r *http.Request, params ListBucketsParams) {
 	var request ListBucketsRequestObject
@@ -978,8 +2635,69 @@ func (sh *strictHandler) CreateBucket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PreviewBucket operation middleware
+func (sh *strictHandler) PreviewBucket(w http.ResponseWriter, r *http.Request) {
+	var request PreviewBucketRequestObject
+
+	var body PreviewBucketJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if !errors.Is(err, io.EOF) {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+	} else {
+		request.Body = &body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewBucket(ctx, request.(PreviewBucketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewBucket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PreviewBucketResponseObject); ok {
+		if err := validResponse.VisitPreviewBucketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteBucket operation middleware
+func (sh *strictHandler) DeleteBucket(w http.ResponseWriter, r *http.Request, id string, params DeleteBucketParams) {
+	var request DeleteBucketRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteBucket(ctx, request.(DeleteBucketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteBucket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteBucketResponseObject); ok {
+		if err := validResponse.VisitDeleteBucketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetBucketDetails operation middleware
-func (sh *strictHandler) GetBucketDetails(w http.ResponseWriter, r *http.Request, id int32) {
+func (sh *strictHandler) GetBucketDetails(w http.ResponseWriter, r *http.Request, id string) {
 	var request GetBucketDetailsRequestObject
 
 	request.Id = id
@@ -1005,7 +2723,7 @@ func (sh *strictHandler) GetBucketDetails(w http.ResponseWriter, r *http.Request
 }
 
 // UpdateBucket operation middleware
-func (sh *strictHandler) UpdateBucket(w http.ResponseWriter, r *http.Request, id int32) {
+func (sh *strictHandler) UpdateBucket(w http.ResponseWriter, r *http.Request, id string) {
 	var request UpdateBucketRequestObject
 
 	request.Id = id
@@ -1038,7 +2756,7 @@ func (sh *strictHandler) UpdateBucket(w http.ResponseWriter, r *http.Request, id
 }
 
 // ArchiveBucket operation middleware
-func (sh *strictHandler) ArchiveBucket(w http.ResponseWriter, r *http.Request, id int32) {
+func (sh *strictHandler) ArchiveBucket(w http.ResponseWriter, r *http.Request, id string) {
 	var request ArchiveBucketRequestObject
 
 	request.Id = id
@@ -1063,8 +2781,67 @@ func (sh *strictHandler) ArchiveBucket(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
+// CloneBucket operation middleware
+func (sh *strictHandler) CloneBucket(w http.ResponseWriter, r *http.Request, id string) {
+	var request CloneBucketRequestObject
+
+	request.Id = id
+
+	var body CloneBucketJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CloneBucket(ctx, request.(CloneBucketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CloneBucket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CloneBucketResponseObject); ok {
+		if err := validResponse.VisitCloneBucketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StreamBucketEvents operation middleware
+func (sh *strictHandler) StreamBucketEvents(w http.ResponseWriter, r *http.Request, id string) {
+	var request StreamBucketEventsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamBucketEvents(ctx, request.(StreamBucketEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamBucketEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamBucketEventsResponseObject); ok {
+		if err := validResponse.VisitStreamBucketEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PopBucketName operation middleware
-func (sh *strictHandler) PopBucketName(w http.ResponseWriter, r *http.Request, id int32) {
+func (sh *strictHandler) PopBucketName(w http.ResponseWriter, r *http.Request, id string) {
 	var request PopBucketNameRequestObject
 
 	request.Id = id
@@ -1090,7 +2867,7 @@ func (sh *strictHandler) PopBucketName(w http.ResponseWriter, r *http.Request, i
 }
 
 // RecoverBucket operation middleware
-func (sh *strictHandler) RecoverBucket(w http.ResponseWriter, r *http.Request, id int32) {
+func (sh *strictHandler) RecoverBucket(w http.ResponseWriter, r *http.Request, id string) {
 	var request RecoverBucketRequestObject
 
 	request.Id = id
@@ -1115,6 +2892,73 @@ func (sh *strictHandler) RecoverBucket(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
+// ReserveBucketName operation middleware
+func (sh *strictHandler) ReserveBucketName(w http.ResponseWriter, r *http.Request, id string) {
+	var request ReserveBucketNameRequestObject
+
+	request.Id = id
+
+	var body ReserveBucketNameJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if !errors.Is(err, io.EOF) {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+	} else {
+		request.Body = &body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReserveBucketName(ctx, request.(ReserveBucketNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReserveBucketName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReserveBucketNameResponseObject); ok {
+		if err := validResponse.VisitReserveBucketNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeriveName operation middleware
+func (sh *strictHandler) DeriveName(w http.ResponseWriter, r *http.Request) {
+	var request DeriveNameRequestObject
+
+	var body DeriveNameJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeriveName(ctx, request.(DeriveNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeriveName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeriveNameResponseObject); ok {
+		if err := validResponse.VisitDeriveNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GenerateName operation middleware
 func (sh *strictHandler) GenerateName(w http.ResponseWriter, r *http.Request) {
 	var request GenerateNameRequestObject
@@ -1149,43 +2993,263 @@ func (sh *strictHandler) GenerateName(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListJobs operation middleware
+func (sh *strictHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	var request ListJobsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListJobs(ctx, request.(ListJobsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListJobs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListJobsResponseObject); ok {
+		if err := validResponse.VisitListJobsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RunJob operation middleware
+func (sh *strictHandler) RunJob(w http.ResponseWriter, r *http.Request, name string) {
+	var request RunJobRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RunJob(ctx, request.(RunJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RunJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RunJobResponseObject); ok {
+		if err := validResponse.VisitRunJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ConfirmLease operation middleware
+func (sh *strictHandler) ConfirmLease(w http.ResponseWriter, r *http.Request, id string) {
+	var request ConfirmLeaseRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmLease(ctx, request.(ConfirmLeaseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmLease")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ConfirmLeaseResponseObject); ok {
+		if err := validResponse.VisitConfirmLeaseResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SampleNames operation middleware
+func (sh *strictHandler) SampleNames(w http.ResponseWriter, r *http.Request) {
+	var request SampleNamesRequestObject
+
+	var body SampleNamesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if !errors.Is(err, io.EOF) {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+	} else {
+		request.Body = &body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SampleNames(ctx, request.(SampleNamesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SampleNames")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SampleNamesResponseObject); ok {
+		if err := validResponse.VisitSampleNamesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaaW8bORL9KwXufsgAunxkJ9G3ONlkBWS8hsfZBTYIAqq7Ws0Jm+yQbDlC4P++KJIt",
-	"9eUj44mjGRgIEB1sFqv46tWrkr+yRBelVqicZfOvzCY5Fty/PKmST+heoeNC+g9Ko0s0TqB/x02SizWm",
-	"H7mjtynaxIjSCa3YnF2IAq3jRQmXOSpwOcLSbweX3EL9KBuxTJuCNmApdzh2okA2YqqSki8lsrkzFY6Y",
-	"25TI5sw6I9SKXY1YYpC732U6PslGDL/woiQb7HB2+HR8cDg+PLw4mM1n9O9/1x2td5SW8e5ZXu3egc4a",
-	"Z2nZ/xXNGg0oXqCFTBsojU6rxD+Fai2MVgUqN2Q9E9KhsX3Lr/0XkGiViVVluN+M9na5sLtDtK9Uolq5",
-	"vL/ZW/85bWad4UI5WHNZITyhiwKRgdIOUNGVpT81XTs4vvYyhXK4QkNOBLMf4wZ98//N0eVoIKyD4LNQ",
-	"KxC2tto02jKz1FoiVw0zhU6xb+MXnaKPj+z6SlurqmDz96wqnQ6WEic37EPzEuN3nRu6GjGDnythyK/3",
-	"XU8/bNfr5W+YODqlGAjAOyU+VwgiReVEJtDEmxxC00EDuEK5o0M2FHLCWt/OKS/wBpzuYDm2HrJ2CJIG",
-	"Cy6UUKuPJRdD0DytiiUashMQv30AhLrG9PFh26t/HA96VZXp76QFya2D+PgN3HB0IzfcQlsdLAgy5O+h",
-	"TSItduuHc5fzQ+gJnP1WWLdwWHwv0v4u4Xlk9a31Pz0H/PUScSjZzoxeSiyCQuo7ev76Jfz8bPYzxHUQ",
-	"pZS/uH9dXJzBi7OF7RXh9JrtXkBeFVyNDfKUnAP8UkquQmW3JSYiEwk4HSq8TpLKGFQJtqJ4kSOQ72gd",
-	"lTjHhbIg1JpLkYI2UAhriYnr+EAmUKaDd2wdd9UAvXvPwpeQUK1t2H/6/PkQEp1wEoc8trk2btR13FZF",
-	"wc2mRmkZwtvycxFdEqqsHK1Yi9QDqudG+KBv+t35Agxm6GMILudul3u2aRf8Dk3j8dVHNEabWzVBjGNc",
-	"Vgejjzd6TqhMDxz2bOExtUKFpPTo/rhKdQG2yUFcpVBwxVe0gD6KeWe3RucsPFBK7pDQyUaMUjyYOZjM",
-	"JrMxl2XODyhyukTFS8Hm7GgymxwRkLnLPSKm64OwblqbmH9lKxxgg3N0lVEWOEhhHV0pl7I+2Ai0X8el",
-	"3ETZhylB3Ob6ErSSm21VavhCyeSzYpGSdhXWnWy/K7nhBQbJ/L57lkUGpUGLysETgytuUonW0pm83P1p",
-	"BCae9jrTgrb5XKHZ1JQyZ426GZorCkIXEh8IE7bUygYSOJzN6D9KUaoRVLnLUorE+zX9zYaytNuvTSGN",
-	"oAuHhX/xd4MZm7O/TXf93jQ2e9OOathmBePG8E0PsfX2wxhtx/TXKknQ2qyiKzTojMBdyPydk7mn3+ju",
-	"Tc60WXngSAvl0CguIVbof/o0pXWRWCJqmkikQ5baDgD4pa8RBGCFl7VflGqZkNKCcHApXF7nJqYxGZfc",
-	"Ygpa1Uzi+QlqadcFcTByUpfjyOAnOt3cAyV72rn+O6Z8HQy/r6ermt+0msAiNJ115EY1xxlshJoiryvX",
-	"aObs5B5Nb4Njt/f4ZFspRQbt9o7aU9IfvY74bh1wxivp2Dzj0uLogTriaLNuZh+qRe4pq+8mTTtM5u0M",
-	"09huGUXvqsfPB38YYbXnbAOEFRaAbVJp3fHsI3kGtmoxol/RUwXTryK9ulUaBEFM+aSC2id24UtKbL4T",
-	"vltAtJnzDbp2fG/RADHWi1d1NSdNsyvmvldoQ6NZ1m/tuu5d6O8FpJuLcVo/OGLHs+OHg9SpdvBaVyqF",
-	"McTwpxptGCp+2VeF8AbdQOBK7pKBWvLON7QWisr5DiZ0VV7txk0m8NL3a05ugrokkmtsAglXsMS6NZ4A",
-	"keGorpEjrziSylhtfA0URTQ16WVEOMtWS/z4bPjuWuYUL1uhbA9OJvDOImBRug2EMkEdRiKRm+4lTFr1",
-	"JgQybTdZN4qggn95G9XG4ez42R1K4V0q0Y8ikAjFLcMTbTxgkp7wFM7jKGMM/6F2P/7K4avFftLY8ez5",
-	"w53opVaZFInbHUjsxrieMQzydEx0s5cUGxLsdgUxjT55Whhs0n7h5pPdci00ptkTeNFp4z1/bgPjw3Qp",
-	"qBNE4JXTpD8SP5BIUSLhn2cODRxByjd2Ahd++lYzLkVcpFiUmmIK42iXSIYr4JLsbMadScJ2zBAVX5/D",
-	"45n3iMT3gpE6cXxUMndKswimbXrclGmlLq/PsnMs9DoOGs12rudb98zowlfTqNd3d9SF9pkuQ+hOw0T+",
-	"Tw3ttkgZ7movcoRSl2VbSbSUxtLwNY4LXfmB/X262huSJ56he2H7nEqP1fQb0vxMl63LvT3ZDSZ6jeb2",
-	"hCecbCMRf/rxNmpKGUHBP/n5lAOeOM82Ky7UreUyHqFbL8MWd62W52GTx2rZHQD4sDyWy2/Lo4gmj8eu",
-	"3mjlUj0qvj5/3sQVVCatUCuJAz/chd8PdGcqPjDoCnvFsvnHtNWPQ/rHIf11k4kBfXH1QHJpd78/VDHt",
-	"jhHq6g8egdR/8xCABrsqB5SbGgrukhztnk5TQyiBDzBg2LT+Tadfst/qhEtIcY1SlwUJh7CWjVhlJJuz",
-	"3LlyPp1KWpdr6+bPZs9mU14KdvXh6v8BAAD//wGHzKYCLQAA",
+	"H4sIAAAAAAAC/+x9f3PbtrLoV8Ho3Zme3qEU+UeS1jOdO07TnKYnzclN0p772uRZEAlZqEmABUDLOh1/",
+	"9zfYBUCQgiQ6cRK3N9M/GlMgsVgs9vcu/hjlsqqlYMLo0ckfI50vWUXhn6dNwc1pbrgU9s+C6VzxGv8c",
+	"vV4yki+pOGeECkLtUMIumTBEsVyqQo+yERNNNTr5dTRv8gtmJrli1LBilPkHTV10H1CVL/ll/MR+7JKp",
+	"+FHBStZ5q5Z13X1HM4VfKRnVbJJLseCqip4oBv8vRm+zEbuiVV2y0UkCCrOu7Q/aKC7OR9cZ4uQ7u06L",
+	"klrJminDGeCLBlT9h2KL0cno/9xrcXvPIfZejNXrzL4k1SZ6/7WUpKIFIybgOYN/C1oxIhfw79/knCyk",
+	"gn/PaX5xrmQjCvtYj+J1USHFupKNTq2ILgxLAPDKUBMmQsQQGNqBSKoWqCUVBSuIbIx9rJhplGAF+Zto",
+	"ypJw+JBihGsipGAZKfkFc1+kfgKuidvdL+MF/DGyE9gNUvSSjSvZCEM5oM9+nM7tIKMadp2N5mwhFRu2",
+	"Hhz7ngta0c6K3DfjJTm6t0vaBBdGnfHCQhwWfJCNFlJV1IxORlyYo8N247gw7JypUfsu4qa/3ucRnThQ",
+	"VksmosUC5JbGOrRimDZjfCFFLW4tZ9SkaHbA9w+nhw/GB9PxwdevDw9OptOT6fSXUbRcyxHGhlcsNXsf",
+	"TYfTLqIeHCcRxetNYE+LQjGtPYrykjNhiFlS0z937YZz43Y7jFGyOV8SShT7vWHafNlb6tFkOjk4OJo8",
+	"3Nj6xOLcNxwtdKF9WnhA3ajbhfToyeEv//jvfz149OPrx7/8z/FPP9x/9fzRz88fvjwdAriWjcrZIL73",
+	"Codeu+VyxQorIHgxyjz7jM9El8Y9swwzws52EBfOv+drHYp9G4CX899YbgJDfxVWsEHRcJZ7BE0WSlYZ",
+	"mf0m57NdDNiJvxWbW3hqPspGv8l5V+bg4w2cPoJ1P2aG8lInRA2KqDN7pM/Y1ZI22rAiuQDLpWIuwDVx",
+	"bxeENkZW1PCcluWaSJEzK8PV2vM/TeaMCRIEbAB7QUvdksJcypJRFGfu00kG8ZpXTBta1S0r8qyJtlBt",
+	"4wZ76XAXb9o9dauZdBjV/fHB4fjw8PXB9KaMqjN5H5bH7V9dDt2Z/5XVYRTshAYiq5UsGjgjhIlLrqSo",
+	"mEgyaXZVc8X0bia9jxwyAswE0MUNEZYwiPvyO2/Rgpcl0G9Z/nMxOvl1N8vAQ/DEvnP9tofU0Qslz2MO",
+	"joiaMy7OyUpxY5iIFJbNzXbMEojeLNmaUHvUy9K/nBTXC14apvToZCDgMDgIru4CfhL894YRXjBh+IIz",
+	"1bKSDWoYphCUdM7KvcA9w1Ew/t/r3TwDkdrTAhUj9rONRaI/TYi+GzCKoVpL/LFRewTGoOKrpEarWEW5",
+	"4OL8rKZc6cQsTTVnys6D6wsvEC62TH18OEjTUKySl7TcffLCefNEycuSzBmBl1kRH7zuQbXiPLwslT2W",
+	"XJMLVhtLOvaAvvPBVMwwgYD24f5erkgpxXkPGJgWTk+7HtR98QyG1cyms8BFeuBOyPPOUnFTScEWtClh",
+	"dKNZMenQwMPD6XKQVsJSAvEVY8VWyl4xxYheNotFaUmbm2VvL9a9IYqKQlblepQgji0ARsTizN93kFcl",
+	"1Ya01vM2oXW0U2jtxeDKmvFn9pQl6eJn/MHj0I4mJddGu61kRUTiVpVoeGmc6tQRLY6y8Y39gKV0R6cf",
+	"xgB29IFNptDy8sA3s21qleOUTnyltEhk+Nv8AruYAfpMlrSumWDF7ZlKaf762tu2yKkz4n0laOGiU8RJ",
+	"Ri+NJEgDgFN3zYaeOT6IzbhdeGemHAT2DswdHwxi1vikD8cMUTPLyMwjZ0aoKMjM42eGqsLcOgboTleB",
+	"kRHkGZl5Thk+6JxbM/c1bjTRhppGZ36EQ8LMnhJtFxz4QfjNIcwZKbBdK669Q8KdMk0cvYdxyj8tCROF",
+	"nkQWS5DjkSvNr749Jvi09c+1m9uxcMLHdp9j+DWc5PZb2YiaHWfuidMou0eukILtJzGvJmpJFlR19K3D",
+	"6XQ6zLnAlEo78NawS4DfBeVlOFeNKJnWlvHh4y8HSTNDTYpY7eeBPFZLXrJIstEgiN0qMzLD+Zw0to85",
+	"SvXKQTIhr9tzllNhubKlbKBr/CwXhHFgCABRTDQOFMuh4GtdKmh/3VibkYaW+3fLSICZZWRKGmF42eJX",
+	"G6pMx+d5fzpwA3tUiHj2MGVISH6Td1Khtwu6i8AfCHigzxtF7WPHWblu9cyewCjs160g4oIbnkLOM2a8",
+	"aRNGk6rRBnEBykvrDkLx2nP6zIfQHS1LbpiF+5JtNxVaECy1CNmIDVhAJ7CssgTIB9kI7Covm4IVZ/mS",
+	"ppD77ZIqmgNTA3cY2qhWIFDlpQbSzm5MlAdyOgQZJRPnZpnaDPvcbrI2inJhyCUtG9adlQk6d6e9ZTPH",
+	"Q/REnPbMfWD7LuA4x+dBcGo/azxpZ5oI226aShaJnf5RFgzotuyvNeIATW0kzpSbct09/+63DZxWXJxt",
+	"w+uPXPCqqfycHUN/545+NQStlkwHHbAkPe+mqGoIOdWKLfhVimdcsYIYdmWi9e6Zer8AaRbDJ2OieOep",
+	"egy1R7w9jrKdoz7j2jw1rNrq+3xPF+MHspk++yA7oZq75vG6G16nMJTA0K6BkxEpyjWpFWvV/RkXIAnP",
+	"uvaAC3ywoq/5DFJc77b74d2sfEcP29nKC8UuOVttchXkUzu3raImX7b2llwQRvOlk04Zma8J1TkThR2C",
+	"DycEVQNNhGxjKlQxUrKFsWq1VaC5YdV+GoYPfWtt7dZ2HVGl6Nr+bQnpLIdf9yrSYR1OfzZM6ayzx7Ip",
+	"C7KUZeHDjAJDQn0V+8HB1ynK0jBCpw9aen5S8/yCFYQa51TLyIKtQABTQai+8L5mVKUgmBmtJobrV+eX",
+	"UBwdojktq9ZJ8TbC96Yl0sFqjwQjFLdLzALhpGhuq1nwT/gHLcPyLSMEAjlngqGZMCFPUe7WSl7yAhzD",
+	"wbRzw5yb0tpnrUamJ+Q7iOLhxy2nkH4+q58Hd30uqzkX6GD9IBbIhr1RU/uSff3//UrH/377H6NBNge4",
+	"g4Oh0EOl5ZYeHd6wX0rNUlbJMINkm4Z8U3sEsAFO+w2TJCONZoumJEYSeik57AqRgrlX7fYwqtf2d2s8",
+	"NpqlTJYePqfjr9/+ZxKnw00XS4ktdTll258Dqwp2NTpLXRZfG3bNMDtm185+KLvGzelNkjtr6PR2YUK+",
+	"decVCZgKMrOTzfxr3ICPvdCE+kfKuvomPdPolk2hDcNn0CEfaPyslrJ0LuudvEUlD8N4y2kYaAz1J/dm",
+	"UWdq9HwPnfs6ISWeikta8uIFVbRKJCqFlJ8FZ2VBFLPv2dAKOhjhXeqUoS4X36/tho/BtzMimA5/oaD5",
+	"TUYURwrZVY2d+Jo4wk6qxVRLsd0/ip4SBw6u0KqYHrAu00Oihr2YMzJnZsWYIAfA3x8c7XUxB98ygJQS",
+	"2D/IeUI1pNqcqUYMT1z4Qc5fNiKRtGD9qyU1djdVI+I8zjh1C/1YiqZzEPbvqk0yivGGodizYDijmpe0",
+	"YgS7grXujmBbgFUjNLHDOwmRxKKgaEpUO6zsU42wBks/Ne7weDw9GB/cf32wL7S137XhpkwIZCWFzVlR",
+	"TEOosgO7FB2QpuQ/8b+hZBSm7WIta+llC4W9bMQmkdHFAgj+TMlVQrU4BQUWjUdIr8bj2wgXznEBPAi5",
+	"x8salrbARLEvp9NOBcO27iM6KA6GOyi2RE7sGYGfwrwufNL1SHFDdJPnEDQeGkJRZsgy3cA9Cx3sidmM",
+	"MKhgrwbEe2xkPUJIkdCz4PSgRcFRsX/RIabdvqDRP9j6HvJdtJe7vo8J+QdbOytVrpjKqWaElvWSiqZi",
+	"iucZ+WL8RUa+OPvCEt0Xky9IUxMjyYMjkgcNOEPOrl1a1Q3fBo6eU2HZPKtqs55088GZuHSOGYtvZuXm",
+	"qKbrigmjRykZ+4xRzTZPXSf9updy+7iLljYvHOWTi5vbfIab+61CRcJuaoSIK8wXXnAHAMN9mDxRo8/h",
+	"yw/itAywbCz9ts7HwHxFtI4l05Bp2w2t+5hqizMeoWwHoAfvkn/e147tfE8fd2Y5fvLDw1f/ffj986Nv",
+	"//HoX//zy4P/+/jl65//fvrjdzfL1QhkpltP7O4UjAFutDi/Onww7ECPOPcmUMeOqY3ztdcjtelTA/M3",
+	"6JNRTsfh0XS7VbnVso2DRhnhImIy3YD/3tBwgAkXlcLFCyXnJaswbXsTpJdPviUPv5o+JG4cwYHo/fn+",
+	"9esX5PTFU72hxhdbPndKlk1FxVgxWtjzbTWdkgqMMeua5XzBczwo1gWU541STORdAnod1RTkEqhIE44W",
+	"iWXQFdca0/kQE8422CrPE7qLd0bttl56fm8pyKz98Qy+PSM1Ik5PyFNgffYTDthxbe0nTdiVYcInpnmM",
+	"D3azdmyxhJ8Vs3MSSZJ29/BHkstuzcv9r5NeUsNNSmc9JXoplcn6m6ubqqJq7QnaYaKzlw54wkXdtG7D",
+	"1FalU59OyU8vnxLFFgzoBI9iiNboeF7i0nXayd2/zrwes1cLMm09mkdG6ky9sp/l4twWcKUqIbyTb+/W",
+	"/kuq4jG38MwbX31XcG24yM3+mE1gVDAycldbeYNZaTE6vk7vufWfvBOgw/z7CFvKv/6+jvteCAGX32Gg",
+	"EHbal10TvOYdZ3pvF7J4Uz3OUqTxE4agNmplblJd4F+7zvqU1QvonRWsNDTp9BXnzPt2aWSndYN8OW10",
+	"y/cwepYRwc7Bz93G2QRb+U0D3bmSCvQAozigZEJ+YUq24/1YyEwWMgTmOhrzeFipXG+70hjY3Im319lo",
+	"g2g3dYElP9O/NzRVnfmCUaWl+MJmJ/IxjiqAn1rSyEOVnkWuzggljeB2NUCI5IKxGhLq8lJqZiXerGDn",
+	"ijF9JhdnC8VYIauZfa1qbMCOqnOmnOepllwYSC6jZM6p7qDtwfHh5GGsHMpmXkaaoYBjgQZWf75dhwgy",
+	"q0nFRaOJSzALU95PajnwxjbRan90zFqTmiqgP9plWRCftC+7AKU/e4Okot3cJ6AliHy9N1C2cuXf0YYn",
+	"EZQ60t2Zbq5POr7swkA+zmMhipF8lEIxu6rR5Tj08z0qdFFTesm6ExMpCL1kip53Nvpgcv94EGkB8Cd/",
+	"9JX+vSLWLdpvdFjeJtrtm1wsZEIjePE0DgiBIgjh2dggQWu9ooKee02eRC5GVHNG+EJdUsOsmjvKRqGy",
+	"YHQwmU6mY/ASHNgly5oJWnNbmTqZTo7Qv47B+XuXBzjuHvQcsI/OWYIkXkIOtt2kmp4Hzyg6y1wVZ8eE",
+	"dBHwSmroYMCE9bcrbTLczFVUj1/5KCowKOYLQwQWN+CPckKeyLKUKxg3sw7CGSm5AMNVMaM4u4Q3yQKG",
+	"4THFWI0leNB6nxbWjuHatF0HUHYqWjEMLP/6RyocqtziQ5p8z8WTWf9BviQVXSPBQpmncx8SzVGZ4fZ7",
+	"vzdMrb2heNKxHZE73Lhw/Tq7IcyhMDgFUPixhWZw94XhkMDWO9Hti5C3QAO/JVCzqwvDDQEBMrMP2zro",
+	"BCzhxxtgpq3PHgoQuqNRFZYqqrh0PpQkZI7AUliKil6mN3a03hjoqAPEDngh23wPvIfTDwivLAufChOe",
+	"tkKGFxmJdcuI22w7xb5IPnWEk5pihRFqOOH7D/SP9MqOjrThgHisjNkCV8krbjpghWi9VYsq/Ozo5HA6",
+	"3QPSW6hdqaXQqDgcTqeoPwjjarRoXZc8Bz577zcXomzn7aodCL391yB1qWXXKReC3Z2Eu8rJBjQBrkwQ",
+	"Wzh3txCRa+/8gFS8uqdajO7RmvcE5X/hnn/z8OBNM50ePgBUf3N/euNMPIeLtBbRK7a08RmtF01ZroPQ",
+	"Q8kZNQwCh/3xDfdnF/q7XrgEXI9oQV46n9eYeK8J0CKJhOt1Nrr/McF6KgxTgpbEpd1+B56Ua8hXAN+P",
+	"0wYSCLzOIsXI617DVaOgAvk8sdInj2ERm17KFbrnerXL2jfMgcylYHBQopkdeatK0KOgUu5UgJ4ugg/x",
+	"b4qdU1WUrksAGHxfZoHBJhe0TbC3mePtbt+cn3usYYIa9nJSJHrJ+2Bh9Dm/ZALSUTLCz4VU3qmTU71N",
+	"Vv2+RU75WNk+kJ+AmzaGlSpGtFTotJ2QR6nHhEfpmQD2xCYn+QTjmZVPiAGIJtiFWpkY0Z5LeoicF2md",
+	"RnXlg8/IcnGMTjJwO//o7YCVP+aK5XFCvJssBYfVHlRaUI2ozqNUMfzLzjQIiE3J6fHz0URntj33wMOi",
+	"L3hdd9UnMJYCkM6rkUTdYqHZFkhj0KbvZDl4CJf00h4W7GaD5whi3RmBYGpVWRZl+Yil39kFW38D3GFG",
+	"vAsySX2sZDsUfMNo9Y2PQmdMXH4z9NCFlhuSuEz/XoUvQOW0AbVuixRSUG7UCqRxvaXq7pY1p0gWDVKd",
+	"esU/768+BdH27vqT+8R/eZUJFSik43fRoDxO3kOFwk9Au4XP2tMNtSePfhtVkdqkssYYNeDfs754h2oq",
+	"Cqg1Bj8zmF79JOk59E5wWWY++Obd81ha3ebv+9JzLnrNw7Jem5XAURU/XxpCV3SN03OjCZR/Q1QUWyG5",
+	"rJlE3TYWTLvxXMNpmJBn9N/rmGVCAAFDR67YGj5JIQJBi3Wv3Y+HbVNfQxQ+8mzKBZgfyWL9HrxkV9Oz",
+	"XXnkp/hejNgbNT6bDMkyv7sdv2wM/Ojo6GvUuAZ1gqMma/NQ3bf7+l2iVcnBeHrQ8YKk+n8N6Z8Vdc56",
+	"955Wu0jiW+xfhQVcmNnUNqPBXSdcaMMoNF7UBnXviEYS7Zmc8tk6Vy+Ye2jftd8W2lCo6aMGUGvfF1ty",
+	"YXqnE9PoVnStXaOcjNDWF491GC464TZKQBYUbFbW1v1oI2tgDnailj+EripRGx90lk8IVm0FRmTr3ISj",
+	"isKlW1nooh4oqAzaOUEH79d6oeFlTz62dWgLjy15rWhpV1FbybxmBjlneNUGtsoWMZgMDpiLg/H4Pctd",
+	"bVx60AH+oKWhH6y5FrViqvCNJaDhy8x2yJpNoO8WRkajb7vGW20EI9lxa+dZdw24hjbcwl5ZemvTrYIZ",
+	"pqzWr0PjwVCaZT+ZtdFwUcTk6U7DuWftGq3pgqm+sN3o17VrfQM7vd1ic6xQzxA1yIKdBK9JZ6LZRgdj",
+	"pAZ4dUKeWidBVBNIYabu9h0dFuzBw8X0AT1+sGDHi2Hp7mldtR3mahN6lsPBral/vWSNTfUvYpZEx0qz",
+	"48p2zw6nhx8PIBzQCgWrr21pzTMhL2RZdvNZrboWqXheZbsj2r7nJpALmDm52C0msiBbigdJSmaYHDhD",
+	"+L/+ePB/K8Wi5LkF/lRgKzOvA5ao01ptLyQY/21m/3dm6AUTsy+9dzMU2scH2R1JX+dix2GGJQggWOjh",
+	"4cdb6E+iVtKSPWQLficMN2syJs9d4TmISMeGPUP920zIM/fD7MtECbjPvr+TxhzaGB0rLe0Iv1dHRf9J",
+	"i+8lq6WyJpBcRcXuTq3oVMlTW5bumHC1UbQOImqJ/m7uS0jxwBc+NwoURF+z7ZXDuPrCKb+hZYDfOery",
+	"u6jA8piMzNosOuAQUzQA/UdDD3ofKazQoMRTumm0ud4It2y13Vzl72QhOi3+YJptTcrB8W2XMNRUu8mJ",
+	"kQf0YLrXzbgp667f0yu2X5Q47O91AjliZrGT/o5IBbdxlmvirkf+oJsKiTvHbtz+xC32NvtlbGFAf/Di",
+	"Gsm5ZKk2fi9Bnbca7kZz4L7bh5alpfmgTbTMZEWxod/CdXYMVoc9E3NGJDTc/WcyiudKrRC+IlgHYvMS",
+	"Ca+sok+9rUzClhUbPOUxfDGwlJ2ROxxFbOFVENIWggn5GUvJIPkFjEe5IAU/5127uJTScuOmtmA9faxD",
+	"EMkmj0X+8WLU11y3h8z2GXfX2X6LMfM4cr0duqZexagwbovaAs6UY9995Tah33T2H49OtuxLR6t2lPKJ",
+	"GQ9cQhTRHykk87deeNEdERJCe/zxoH0uDXkCV0KMXdy0hZBdBef9p1GHHyX7it9J3otcJMEeLbQ7cxyw",
+	"dgrEy0J6KvGte0ONVPDsdJnX35npmnl/RQb29oNrNjuM5N3hrcK/eBeP7Z07JH9nJoG42jLCRCs+yI3Q",
+	"pGoM2Iuu4we4V70h8jr0KrJ8NHo/c6FTqB33ukJoL42qhEu+yLxdrCV0hOCVm3FCoLLXf0SFbBfrpFas",
+	"LmnO+ja1di/F5TED3uqoM04Gh2oZ5+xfu4stFGvDeka2YqQKzbec18C9Z9/BMEnk/qbnlCd0IcT5X1gX",
+	"evsxIn13K7L3nK3ik9FraDkhP2nnQiGIJUtUecmo6p+pDii+sq1bZbEzJFjRq2euEvpwevzVx4kRUtFd",
+	"nHJ2jP0ATLe+q2HCLdEetkI66W/jj87qSTfEgEYWtqlFsg8GtqyyPCi01aWi+34YDsMsW5qz1Amf7Ak+",
+	"jVnzJ4o/bVKPjbMynQhMDYlAXb9TiOL2hHiyGnWfzuPE5N3w4/wc6u1dy53PBlPaYGpZoiggM2Zsz2kW",
+	"6ncx+KwJhBHCpaK9CzjaGxvac6cNXBfh7kq4i1omUvluf7t1d91zONrudP+RqovIiRa1556Q076Dyqcg",
+	"jTF3QBTh1qyuVPIeLJ/PwFXkB+ManGAZ0YyRWXtX18zquvZHr67ZkbxgVS3tq2TsIAOnuvAq4HjDVeds",
+	"Tuco2VQA3ar+6hrgnTAmE46Czzbk3tPtFWo64IDnpRQ7jncyizLUrIWAWsesRC0ui44s3IKUNgdmpJYl",
+	"z0NHFLQE4SbmVv/07q1c1txfcBPBw7W/zsby3LbP5UIxvfR5I23edSdDy55LNzyX9bp7PUVbnemnWrr7",
+	"eFuDM/PZn3HmyukW1zxgO5VpaZ9/tin325Q3TdBsqSTzFU5tYUi8sd1cot0ZndpgjfyedM7bv9agXU0H",
+	"QAfPruQ1bOPXLXFRzB2NUZawJ8zGGcPDNSGz8OIspE/r2D5PtobnwjERF2SHk5KRmT118Y0IcMb1DQ8h",
+	"mChuRq78KYRTBe6wsp+ECQ+MlBnxi7Fv20wAz+sgHRMi8bJec+i1IQwX9oRB5fYKbpXeBI537mCLcdxd",
+	"abKQ6E+YspWKKyGb+2TJWjC75+phB/5iyVteeJWBf3y28byNFy7I3LDe+mf1T2CrARcbpMu1Be7JaNor",
+	"oxitNCSuuxs3da8DJ9UOkvErJgzBZiXRPX15ye3zgutcCsFyo31it9Kujhks6zcivvSy1+nAe/Xb6kiX",
+	"A4EiBEdjCwpuSE6V8gKh9wYEAd4InAmNRd7eJTp5I96I72xpQIBLBzjsubZ8lbi0wVevvnPDgG7AsUWi",
+	"+2DtOPvOD6/++ZwU1FBM7n0jZvDWiZNFM1cDjQn2MzvwhPzxBlj4m9HJG3eN5ptR9gbYOzzrdv+E3wLy",
+	"3sA9qJPJ5Ho2eSPcflDlFDzNmPD9InBrNHEb095b6nxwWLaQs1ZwrQOqMrdgt70erbSw9CGJLuWqXEMT",
+	"SaaJlkEhqTY1WSSyCHP/W+Othl0ZPJFjDTi5qaBzrScSFjJ8rpfREzdh+Gwg72GpDoO+LdGlTbKNgqa7",
+	"eGwt610ZqD4DrAh+JHe3cNvtB9MVWp/GRgKnrBGTz7Ea/rN/abdJuL3vsrMPbqnr8g51fFeiJ8LQp4K7",
+	"7M+6u5557m2//rXZGeGb2lyGt2Pb52Du+QSCjeK4u5ku9ULWHbLZz5vcNd77+RMwPo9j1+8Y5qDBWVLR",
+	"C1fvSN3tW5AOsc/F7kDo+9jxE0M97C/xI5897B8lXctd/f7ZxX6Tw+lINJnMuOuAAklsP6Cv6YU7ntD+",
+	"IpG5bZkYVOtCC4PWMjOmJDW1CvqkY/7G9by2BaiUbVgtccsCMUsF1zLO2iXgdLgCN3CWEajCWnENNtfG",
+	"jQ5MFH3AQ09BnNUlsqROPyDpf4MOdBsecWPKPQkh7s4Pjg2S56zd7SxVPQyecFlx078952BaDcvX+JD+",
+	"SLwCZj9Xczdu7NK7PmnCuz2vPNzR8FkR/KwI7pA1QMwdWt5iqhZM7cnWqK1FesHWrjiditYx9PRxRij5",
+	"8fRbQosCOtFIRSg554a8+v40w+7qkTHlPewXbM0Kux9LSMzo+PhaFKNYgiApTA/bVdOc3aw1AKYy+wY3",
+	"sNNSpUKqjwEVz/1lgZ+mCPKCrdOmKV7uYSJBjVtXuM4B1GAT64Pp4TGZrw3TwM87/JiPp3SeHxweFWxx",
+	"fP/Bw6++nm67lwgQvQkJXBFmgbCbF3VsuGBrB491/ipWRg2SuCAFX8DlIqbdRL0lp3H7vZ2da1TbznHb",
+	"ykOX7Cq+WSzGFsBtQQZvZtF6PpFWDAMmQCj01Hdj/d2+tPaDYeHhyoox3JFqK3N7nUt805O1fw/GdFpP",
+	"xFWqD3YXqb5nB4r2UtM566AEk6N2dJ7w8vk9u0pY+n77ETI2h3p8PA6GuXzGB4sjmqLQ998Ue8VavCG3",
+	"0LyjD9aNXVEeHhqV0X3yMCbIAuQI4eLkZOGxVFbcuzL6Tx1cfMdWEnewJE9hopjGAqJYujs9wx7xrpLh",
+	"cz62qxl/D1khlNh7x0qWuHAC1QfZu0M/Ub6H3/rEkjzdF+kFJIyEU3+7XZDQho2Nedep4o41QOpIIQfi",
+	"zYTQ7bU8uvWWD0MlT1tw9q7hhtsSO50dkG1rXVZg8lq7afqDtZTaIYVaRN0pObQhcT4LnA9T3oq7H7c9",
+	"bI9LV878Jufbk1meBcJvu67aW8FDY7qQ9gB/QUagv+MHK0S5Nkz5Pj4ucdDfBu5dBFxhb2H7J3hkVSN0",
+	"urH9DxbaW2U2fv2DWi7bO+/3XekFH7zxgXWdXdyt63f8QoUeMSQo6t4fltSu76lGbFdgXhmqjA43zW/0",
+	"993arsUTEMptuId8KVfaOZdBFvqL5WfRRf8heWlhlYdl0i3eCLvHe3zhcbbwb3Ke9mSLVpXa58vGEsEz",
+	"74c7i24E2xvSOkxLSrtcK6f8Be2f0vX5g5z/KaJNjSAUECfkqkfTiejMdrr+nopCQzDIxPdSh2pZGx7a",
+	"G+N1s3RjvFEICSDqR3mjUBX+Hl2057rQJsoi8Jvo899D+dEN3u8TvLnJnd8fNIw7LNARB+4cZj/pgcJd",
+	"uDuhhGdRrLFX/RxQdzezfhE6Qv2mdg69NtRO6C5V3n7eH7N5c06YKOCCVDAN2mva24aHYE7QNZmdR8b2",
+	"DLcRIy+1VCDn4AqhhWECm24Hf6kreW8EyS0vaWrMF9EmeiN50yb8k5tWXlqX/lrkSyWFbHSG97y2TekE",
+	"wfs77JzemDbURjKwFDeRkQqvPnf2zt1qhzjd4fEOnRAtijYvaY47Ie6/Debj9kLs3va9j4chaqLmLn+K",
+	"Rogb5uHdy3IF+P0Zb+94xdPszJuic0U5fMDlLCRErMxpSQp2yUpZV0wYZ2SNslGjytHJaGlMfXLvXmnH",
+	"LaU2J19Nv5raa1js7dL/fwD6+lkZpLYAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/templates"
+)
+
+// auditPageSize is the amount of audit events listed per page.
+const auditPageSize = 50
+
+func auditHandler(auditStore serverplate.AuditStore) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		bucketID, _ := strconv.ParseInt(query.Get("bucket_id"), 10, 32)
+		action := serverplate.AuditAction(query.Get("action"))
+		if !action.Valid() {
+			action = ""
+		}
+		source := serverplate.AuditSource(query.Get("source"))
+		if !source.Valid() {
+			source = ""
+		}
+		before, _ := strconv.ParseInt(query.Get("before"), 10, 64)
+
+		opts := serverplate.AuditListOptions{
+			BucketID: int32(max(bucketID, 0)),
+			Action:   action,
+			Actor:    strings.TrimSpace(query.Get("actor")),
+			Source:   source,
+			BeforeID: max(before, 0),
+			Limit:    auditPageSize + 1,
+		}
+		events, err := auditStore.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		vm := templates.AuditPageViewModel{
+			Action: string(opts.Action),
+			Actor:  opts.Actor,
+			Source: string(opts.Source),
+		}
+		if opts.BucketID > 0 {
+			vm.BucketID = strconv.Itoa(int(opts.BucketID))
+		}
+
+		if len(events) > auditPageSize {
+			events = events[:auditPageSize]
+			vm.NextURL = auditURL(query, events[len(events)-1].ID)
+		}
+		vm.Events = events
+
+		c := templates.AuditPage(vm)
+		return component(w, r, http.StatusOK, c)
+	}
+}
+
+// auditURL returns the url of the audit page listing the events older than the event with the id, keeping the rest
+// of the query.
+func auditURL(query url.Values, before int64) string {
+	q := maps.Clone(query)
+	q.Set("before", strconv.FormatInt(before, 10))
+
+	return "/audit?" + q.Encode()
+}
@@ -1,34 +1,92 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/davidonium/serverplate/internal/serverplate"
 	"github.com/davidonium/serverplate/internal/templates"
 )
 
+// recentlyPoppedLimit is the amount of popped names listed on the bucket details page.
+const recentlyPoppedLimit = 10
+
+// bucketListPageSize is the amount of buckets listed per page.
+const bucketListPageSize = 50
+
 func bucketListHandler(bucketStore serverplate.BucketStore) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		_, archived := r.URL.Query()["archived"]
+		query := r.URL.Query()
+		_, archived := query["archived"]
 
-		buckets, err := bucketStore.List(ctx, serverplate.ListOptions{
-			ArchivedOnly: archived,
-		})
+		sortBy := serverplate.BucketSortField(query.Get("sort"))
+		if !sortBy.Valid() {
+			sortBy = ""
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		offset = max(offset, 0)
+
+		selector, err := serverplate.ParseLabels(query.Get("selector"))
+		if err != nil {
+			return err
+		}
+
+		opts := serverplate.ListOptions{
+			ArchivedOnly:     archived,
+			Search:           strings.TrimSpace(query.Get("q")),
+			SortBy:           sortBy,
+			SortDesc:         query.Get("order") == "desc",
+			Limit:            bucketListPageSize + 1,
+			Offset:           offset,
+			IncludeRemaining: true,
+			LabelSelector:    selector,
+		}
+		buckets, err := bucketStore.List(ctx, opts)
 		if err != nil {
 			return err
 		}
 
-		c := templates.BucketListPage(templates.BucketListPageViewModel{
-			Buckets:  buckets,
+		vm := templates.BucketListPageViewModel{
 			Archived: archived,
-		})
+			Search:   opts.Search,
+			Selector: strings.TrimSpace(query.Get("selector")),
+			SortBy:   string(opts.SortBy),
+			SortDesc: opts.SortDesc,
+		}
+
+		if offset > 0 {
+			vm.PreviousURL = bucketListURL(query, max(offset-bucketListPageSize, 0))
+		}
+		if len(buckets) > bucketListPageSize {
+			buckets = buckets[:bucketListPageSize]
+			vm.NextURL = bucketListURL(query, offset+bucketListPageSize)
+		}
+		vm.Buckets = buckets
+
+		c := templates.BucketListPage(vm)
 		return component(w, r, http.StatusOK, c)
 	}
 }
 
+// bucketListURL returns the url of the bucket list page starting at offset, keeping the rest of the query.
+func bucketListURL(query url.Values, offset int) string {
+	q := maps.Clone(query)
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	} else {
+		q.Del("offset")
+	}
+
+	return "/buckets?" + q.Encode()
+}
+
 func bucketCreateHandler() appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		vm := templates.BucketCreatePageViewModel{}
@@ -37,103 +95,263 @@ func bucketCreateHandler() appHandlerFunc {
 	}
 }
 
-func bucketCreateSubmitHandler(bucketStore serverplate.BucketStore) appHandlerFunc {
+func bucketCreateSubmitHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		vm := templates.BucketCreatePageViewModel{
+			Name:                 strings.TrimSpace(r.FormValue("name")),
+			Description:          r.FormValue("description"),
+			Labels:               r.FormValue("labels"),
+			Retention:            r.FormValue("retention"),
+			ExpiresAt:            r.FormValue("expires_at"),
+			ArchiveWhenExhausted: r.FormValue("archive_when_exhausted") == "on",
+			Filters:              filterForm(r, "filter_"),
+		}
+
+		labels, labelsErr := serverplate.ParseLabels(vm.Labels)
+		opts := serverplate.CreateBucketOptions{
+			Name:                 vm.Name,
+			Description:          vm.Description,
+			Labels:               labels,
+			Filters:              vm.Filters.Filters(),
+			Retention:            vm.Retention,
+			ExpiresAt:            vm.ExpiresAt,
+			ArchiveWhenExhausted: vm.ArchiveWhenExhausted,
+		}
+
+		// the labels are reported along with the rest of the rejected fields instead of on their own.
+		var b serverplate.Bucket
+		var err error
+		if labelsErr != nil {
+			err = errors.Join(labelsErr, opts.Validate())
+		} else {
+			b, err = buckets.Create(ctx, opts)
+		}
+
+		switch {
+		case errors.Is(err, serverplate.ErrBucketNameTaken):
+			vm.Errors = templates.FormErrors{"name": "another bucket already has this name"}
+		case errors.Is(err, serverplate.ErrNoMatchingPairs):
+			vm.Errors = templates.FormErrors{"filters": "no name matches these filters, the bucket would be empty"}
+		case serverplate.IsInvalidInput(err):
+			vm.Errors = formErrors(err)
+		case err != nil:
+			return err
+		default:
+			redirect(w, r, fmt.Sprintf("/buckets/%d", b.ID))
+			return nil
+		}
+
+		// htmx swaps the form alone, the page is rendered when the form was submitted without it.
+		if isHTMX(r) {
+			return component(w, r, http.StatusUnprocessableEntity, templates.BucketCreateForm(vm))
+		}
+		return component(w, r, http.StatusUnprocessableEntity, templates.BucketCreatePage(vm))
+	}
+}
+
+func bucketDetailsHandler(
+	bucketStore serverplate.BucketStore,
+	buckets *serverplate.BucketService,
+	archivedRetention time.Duration,
+) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		b, err := buckets.Find(ctx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		count, err := bucketStore.RemainingValuesTotal(ctx, b)
+		if err != nil {
+			return err
+		}
+
+		recent, err := bucketStore.RecentlyPopped(ctx, b, recentlyPoppedLimit)
+		if err != nil {
+			return err
+		}
+
+		c := templates.BucketDetailsPage(templates.BucketDetailsPageViewModel{
+			Bucket:           b,
+			RemainingPairs:   count,
+			RecentlyPopped:   recent,
+			DefaultRetention: archivedRetention,
+		})
+		return component(w, r, http.StatusOK, c)
+	}
+}
+
+// bucketFillHandler renders the progress of the fill of the bucket, the page is reloaded once the fill ends.
+func bucketFillHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		b, err := buckets.Find(ctx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		if !b.Filling() {
+			w.Header().Set("HX-Refresh", "true")
+			w.WriteHeader(http.StatusOK)
+			return nil
+		}
+
+		return component(w, r, http.StatusOK, templates.BucketFillProgress(b))
+	}
+}
+
+func bucketPopHandler(bucketStore serverplate.BucketStore, buckets *serverplate.BucketService) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		name := r.FormValue("name")
-		description := r.FormValue("description")
+		b, err := buckets.Find(ctx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		vm := templates.BucketPopPartialViewModel{}
+		name, err := buckets.Pop(ctx, b)
+		switch {
+		case errors.Is(err, serverplate.ErrBucketArchived):
+			vm.Message = "The bucket is archived, names can no longer be popped."
+		case errors.Is(err, serverplate.ErrBucketFilling):
+			vm.Message = "The names of the bucket are still being written."
+		case errors.Is(err, serverplate.ErrBucketExhausted):
+			vm.Message = "Every name of the bucket has already been popped."
+		case err != nil:
+			return err
+		default:
+			vm.Name = name
+		}
 
-		lengthEnabled := r.FormValue("filter_length_enabled") == "on"
-		lengthMode := serverplate.LengthModeUpto
-		if r.FormValue("filter_length_mode") != "" {
-			lengthMode = serverplate.LengthMode(r.FormValue("filter_length_mode"))
+		vm.RemainingPairs, err = bucketStore.RemainingValuesTotal(ctx, b)
+		if err != nil {
+			return err
 		}
-		lengthValue := 14 // default
-		if val := r.FormValue("filter_length_value"); val != "" {
-			if parsed, err := strconv.Atoi(val); err == nil {
-				lengthValue = parsed
-			}
+
+		vm.RecentlyPopped, err = bucketStore.RecentlyPopped(ctx, b, recentlyPoppedLimit)
+		if err != nil {
+			return err
 		}
 
-		b := serverplate.Bucket{
-			Name:                name,
-			Description:         description,
-			FilterLengthEnabled: lengthEnabled,
-			FilterLengthMode:    lengthMode,
-			FilterLengthValue:   lengthValue,
+		return component(w, r, http.StatusOK, templates.BucketPopPartial(vm))
+	}
+}
+
+func bucketRenameHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return bucketUpdateHandler(buckets, func(r *http.Request) serverplate.UpdateBucketOptions {
+		return serverplate.UpdateBucketOptions{
+			Name: new(strings.TrimSpace(r.FormValue("name"))),
 		}
-		if err := bucketStore.Create(ctx, &b); err != nil {
+	})
+}
+
+// bucketUpdateHandler applies the changes read from the form by opts to the bucket and goes back to its page.
+func bucketUpdateHandler(
+	buckets *serverplate.BucketService,
+	opts func(r *http.Request) serverplate.UpdateBucketOptions,
+) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		b, err := buckets.Find(ctx, r.PathValue("id"))
+		if err != nil {
 			return err
 		}
 
-		if err := bucketStore.FillBucketValues(ctx, b, b.Filters()); err != nil {
+		if _, _, err := buckets.Update(ctx, b, opts(r)); err != nil {
 			return err
 		}
+
 		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", b.ID), http.StatusFound)
 		return nil
 	}
 }
 
-func bucketDetailsHandler(bucketStore serverplate.BucketStore) appHandlerFunc {
+func bucketCloneHandler(buckets *serverplate.BucketService) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		rawID := r.PathValue("id")
-		id, _ := strconv.ParseInt(rawID, 10, 32)
-		b, err := bucketStore.OneByID(ctx, int32(id))
+		src, err := buckets.Find(ctx, r.PathValue("id"))
 		if err != nil {
 			return err
 		}
 
-		count, err := bucketStore.RemainingValuesTotal(ctx, b)
+		b, err := buckets.Clone(ctx, src, serverplate.CloneBucketOptions{
+			Name:          strings.TrimSpace(r.FormValue("name")),
+			CopyRemaining: r.FormValue("values") == "copy_remaining",
+		})
 		if err != nil {
 			return err
 		}
 
-		c := templates.BucketDetailsPage(
-			templates.BucketDetailsPageViewModel{Bucket: b, RemainingPairs: count},
-		)
-		return component(w, r, http.StatusOK, c)
+		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", b.ID), http.StatusFound)
+		return nil
 	}
 }
 
-func bucketArchiveHandler(bucketStore serverplate.BucketStore) appHandlerFunc {
+func bucketRetentionHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return bucketUpdateHandler(buckets, func(r *http.Request) serverplate.UpdateBucketOptions {
+		return serverplate.UpdateBucketOptions{
+			Retention: new(r.FormValue("retention")),
+		}
+	})
+}
+
+func bucketAutoArchiveHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return bucketUpdateHandler(buckets, func(r *http.Request) serverplate.UpdateBucketOptions {
+		return serverplate.UpdateBucketOptions{
+			ExpiresAt:            new(r.FormValue("expires_at")),
+			ArchiveWhenExhausted: new(r.FormValue("archive_when_exhausted") == "on"),
+		}
+	})
+}
+
+func bucketArchiveHandler(buckets *serverplate.BucketService) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		rawID := r.PathValue("id")
-		id, _ := strconv.ParseInt(rawID, 10, 32)
-		b, err := bucketStore.OneByID(ctx, int32(id))
+		b, err := buckets.Find(ctx, r.PathValue("id"))
 		if err != nil {
 			return err
 		}
 
-		b.MarkArchived()
-
-		if err := bucketStore.Save(ctx, &b); err != nil {
+		if _, err := buckets.Archive(ctx, b); err != nil {
 			return err
 		}
 
-		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", id), http.StatusFound)
+		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", b.ID), http.StatusFound)
 		return nil
 	}
 }
 
-func bucketRecoverHandler(bucketStore serverplate.BucketStore) appHandlerFunc {
+func bucketRecoverHandler(buckets *serverplate.BucketService) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		rawID := r.PathValue("id")
-		id, _ := strconv.ParseInt(rawID, 10, 32)
-		b, err := bucketStore.OneByID(ctx, int32(id))
+		b, err := buckets.Find(ctx, r.PathValue("id"))
 		if err != nil {
 			return err
 		}
 
-		b.Recover()
+		if _, err := buckets.Recover(ctx, b); err != nil {
+			return err
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", b.ID), http.StatusFound)
+		return nil
+	}
+}
+
+func bucketDeleteHandler(buckets *serverplate.BucketService) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		b, err := buckets.Find(ctx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
 
-		if err := bucketStore.Save(ctx, &b); err != nil {
+		if err := buckets.Delete(ctx, b, r.FormValue("confirm")); err != nil {
 			return err
 		}
 
-		http.Redirect(w, r, fmt.Sprintf("/buckets/%d", id), http.StatusFound)
+		http.Redirect(w, r, "/buckets", http.StatusFound)
 		return nil
 	}
 }
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// filterForm reads the name filters rendered by templates.NameFilterFields from the request form.
+// The prefix is prepended to every field name, which lets the same fields live next to others in a form.
+// The returned options are not validated.
+func filterForm(r *http.Request, prefix string) serverplate.GenerateOptions {
+	value := func(name string) string {
+		return strings.TrimSpace(r.FormValue(prefix + name))
+	}
+
+	lengthValue, _ := strconv.Atoi(value("length_value"))
+	minLength, _ := strconv.Atoi(value("min_length"))
+
+	opts := serverplate.GenerateOptions{
+		LengthEnabled:    value("length_enabled") == "on",
+		LengthMode:       serverplate.LengthMode(value("length_mode")),
+		LengthValue:      lengthValue,
+		MinLength:        minLength,
+		AdjectiveInitial: strings.ToLower(value("adjective_initial")),
+		NounInitial:      strings.ToLower(value("noun_initial")),
+		Alliterative:     value("alliterative") == "on",
+		Prefix:           strings.ToLower(value("prefix")),
+		Suffix:           strings.ToLower(value("suffix")),
+		ExcludedChars:    strings.ToLower(value("excluded_chars")),
+	}
+
+	if opts.LengthMode == "" {
+		opts.LengthMode = serverplate.LengthModeUpto
+	}
+
+	return opts
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/templates"
+)
+
+// formErrors returns the messages of the fields rejected in err, keeping the first message of each field.
+func formErrors(err error) templates.FormErrors {
+	errs := templates.FormErrors{}
+	for _, f := range serverplate.FieldErrors(err) {
+		if _, ok := errs[f.Field]; !ok {
+			errs[f.Field] = f.Reason
+		}
+	}
+
+	return errs
+}
+
+// isHTMX reports whether the request was made by htmx, which swaps the response into the page.
+func isHTMX(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
+
+// redirect sends the browser to url. Requests made by htmx are redirected through the HX-Redirect header, htmx would
+// follow a plain redirect itself and swap the page it leads to into the current one.
+func redirect(w http.ResponseWriter, r *http.Request, url string) {
+	if isHTMX(r) {
+		w.Header().Set("HX-Redirect", url)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
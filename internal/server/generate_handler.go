@@ -2,7 +2,6 @@ package server
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/a-h/templ"
 	"github.com/davidonium/serverplate/internal/serverplate"
@@ -11,16 +10,14 @@ import (
 
 func generateHandler(generator *serverplate.Generator) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
-		lengthEnabled := r.FormValue("length_enabled")
-		lengthMode := r.FormValue("length_mode")
-		lengthValue, _ := strconv.Atoi(r.FormValue("length_value"))
+		opts := filterForm(r, "")
 		componentType := r.URL.Query().Get("component")
 
-		res, err := generator.Generate(r.Context(), serverplate.GenerateOptions{
-			LengthEnabled: lengthEnabled == "on",
-			LengthMode:    serverplate.LengthMode(lengthMode),
-			LengthValue:   lengthValue,
-		})
+		if err := serverplate.ValidateFilters(opts.Filters()); err != nil {
+			return err
+		}
+
+		res, err := generator.Generate(r.Context(), opts)
 		if err != nil {
 			return err
 		}
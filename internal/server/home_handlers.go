@@ -2,7 +2,6 @@ package server
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/davidonium/serverplate/internal/serverplate"
 	"github.com/davidonium/serverplate/internal/templates"
@@ -26,14 +25,9 @@ func homeHandler(pairStore serverplate.PairStore) appHandlerFunc {
 func configStatsHandler(pairStore serverplate.PairStore) appHandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := r.Context()
-		lengthEnabled := r.FormValue("length_enabled") == "on"
-		lengthMode := r.FormValue("length_mode")
-		lengthValue, _ := strconv.Atoi(r.FormValue("length_value"))
-
-		filters := serverplate.RandomPairFilters{}
-		if lengthEnabled {
-			filters.Length = lengthValue
-			filters.LengthMode = serverplate.LengthMode(lengthMode)
+		filters := filterForm(r, "").Filters()
+		if err := serverplate.ValidateFilters(filters); err != nil {
+			return err
 		}
 
 		stats, err := pairStore.Stats(ctx, filters)
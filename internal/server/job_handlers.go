@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/templates"
+)
+
+// recentJobRunsLimit is the amount of runs listed on the jobs page.
+const recentJobRunsLimit = 20
+
+func jobsHandler(jobRunner serverplate.JobRunner, jobRunStore serverplate.JobRunStore) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+		jobs, err := jobRunner.Jobs(ctx)
+		if err != nil {
+			return err
+		}
+
+		runs, err := jobRunStore.Recent(ctx, recentJobRunsLimit)
+		if err != nil {
+			return err
+		}
+
+		c := templates.JobsPage(templates.JobsPageViewModel{
+			Jobs:       jobs,
+			RecentRuns: runs,
+			Started:    r.URL.Query().Get("started"),
+		})
+		return component(w, r, http.StatusOK, c)
+	}
+}
+
+func jobRunHandler(jobRunner serverplate.JobRunner) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		name := r.PathValue("name")
+		if err := jobRunner.RunNow(name); err != nil {
+			return err
+		}
+
+		http.Redirect(w, r, "/jobs?started="+url.QueryEscape(name), http.StatusFound)
+		return nil
+	}
+}
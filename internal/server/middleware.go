@@ -1,10 +1,14 @@
 package server
 
 import (
+	"crypto/rand"
 	"log/slog"
+	"net"
 	"net/http"
 	"slices"
+	"strings"
 
+	"github.com/davidonium/serverplate/internal/serverplate"
 	"github.com/davidonium/serverplate/internal/vite"
 )
 
@@ -21,6 +25,47 @@ func viteMiddleware(assets *vite.Assets) MiddlewareFunc {
 	}
 }
 
+// anonymousActor is the actor recorded for the changes of the requests that do not tell who made them.
+const anonymousActor = "anonymous"
+
+// auditOriginMiddleware tells the audit log who is behind the changes made by the request. The proxy headers are
+// only read when trustProxyHeaders is set, otherwise the client could pose as anyone. The request id is sent back
+// in the X-Request-ID header so that a change can be traced to the request that made it.
+func auditOriginMiddleware(source serverplate.AuditSource, trustProxyHeaders bool) MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := serverplate.AuditOrigin{
+				Actor:  anonymousActor,
+				Source: source,
+				IP:     r.RemoteAddr,
+			}
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				origin.IP = host
+			}
+
+			if trustProxyHeaders {
+				if user := strings.TrimSpace(r.Header.Get("X-Forwarded-User")); user != "" {
+					origin.Actor = user
+				}
+				// the first address is the client, the next ones are the proxies it went through.
+				client, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ",")
+				if client = strings.TrimSpace(client); client != "" {
+					origin.IP = client
+				}
+				origin.RequestID = strings.TrimSpace(r.Header.Get("X-Request-ID"))
+			}
+			if origin.RequestID == "" {
+				origin.RequestID = rand.Text()
+			}
+
+			w.Header().Set("X-Request-ID", origin.RequestID)
+
+			ctx := serverplate.NewContextWithAuditOrigin(r.Context(), origin)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // appMiddleware returns an adapter to be able to return errors in http handlers. This way, a centralized place
 // for error handling can be set up through the use of the errorHandler argument.
 func appMiddleware(
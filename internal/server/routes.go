@@ -29,6 +29,7 @@ func addRoutes(
 
 	c := chainMiddleware([]MiddlewareFunc{
 		viteMiddleware(svcs.Assets),
+		auditOriginMiddleware(domain.AuditSourceWeb, svcs.Config.TrustProxyHeaders),
 	})
 	app := appMiddleware(svcs.Logger, WebErrorHandler(svcs.Logger, svcs.Config.Debug))
 
@@ -40,11 +41,27 @@ func addRoutes(
 	m.Handle("GET /generate", c(app(generateHandler(svcs.Generator))))
 	m.Handle("GET /config/stats", c(app(configStatsHandler(svcs.PairStore))))
 	m.Handle("GET /buckets", c(app(bucketListHandler(svcs.BucketStore))))
-	m.Handle("GET /buckets/{id}", c(app(bucketDetailsHandler(svcs.BucketStore))))
+	m.Handle("GET /buckets/{id}", c(app(bucketDetailsHandler(
+		svcs.BucketStore,
+		svcs.BucketService,
+		svcs.Config.ArchivedBucketsRetention,
+	))))
 	m.Handle("GET /buckets/create", c(app(bucketCreateHandler())))
-	m.Handle("POST /buckets", c(app(bucketCreateSubmitHandler(svcs.BucketStore))))
-	m.Handle("POST /buckets/{id}/archive", c(app(bucketArchiveHandler(svcs.BucketStore))))
-	m.Handle("POST /buckets/{id}/recover", c(app(bucketRecoverHandler(svcs.BucketStore))))
+	m.Handle("POST /buckets", c(app(bucketCreateSubmitHandler(svcs.BucketService))))
+	m.Handle("GET /buckets/{id}/fill", c(app(bucketFillHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/pop", c(app(bucketPopHandler(svcs.BucketStore, svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/rename", c(app(bucketRenameHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/clone", c(app(bucketCloneHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/retention", c(app(bucketRetentionHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/auto-archive", c(app(bucketAutoArchiveHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/archive", c(app(bucketArchiveHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/recover", c(app(bucketRecoverHandler(svcs.BucketService))))
+	m.Handle("POST /buckets/{id}/delete", c(app(bucketDeleteHandler(svcs.BucketService))))
+
+	m.Handle("GET /jobs", c(app(jobsHandler(svcs.JobRunner, svcs.JobRunStore))))
+	m.Handle("POST /jobs/{name}/run", c(app(jobRunHandler(svcs.JobRunner))))
+
+	m.Handle("GET /audit", c(app(auditHandler(svcs.AuditStore))))
 
 	m.Handle("/{path...}", c(app(notFoundHandler())))
 }
@@ -56,9 +73,13 @@ type ErrorHandler func(http.ResponseWriter, *http.Request, error)
 func WebErrorHandler(logger *slog.Logger, debug bool) ErrorHandler {
 	return func(w http.ResponseWriter, r *http.Request, err error) {
 		switch {
-		case errors.Is(err, domain.ErrBucketNotFound):
+		case errors.Is(err, domain.ErrBucketNotFound), errors.Is(err, domain.ErrJobNotFound):
+			message := "Bucket not found"
+			if errors.Is(err, domain.ErrJobNotFound) {
+				message = "Job not found"
+			}
 			c := templates.NotFoundPage(templates.NotFoundViewModel{
-				Message: "Bucket not found",
+				Message: message,
 			})
 			if err := component(w, r, http.StatusNotFound, c); err != nil {
 				logger.Error("failure rendering 404 page",
@@ -66,6 +87,31 @@ func WebErrorHandler(logger *slog.Logger, debug bool) ErrorHandler {
 					slog.String("request.uri", r.RequestURI),
 				)
 			}
+		case errors.Is(err, domain.ErrBucketNameTaken),
+			errors.Is(err, domain.ErrBucketArchived),
+			errors.Is(err, domain.ErrBucketNotArchived),
+			errors.Is(err, domain.ErrBucketFilling),
+			errors.Is(err, domain.ErrWordsVersionMismatch):
+			c := templates.BadRequestPage(templates.BadRequestViewModel{
+				Status:  http.StatusConflict,
+				Message: err.Error(),
+			})
+			if err := component(w, r, http.StatusConflict, c); err != nil {
+				logger.Error("failure rendering 409 page",
+					slog.Any("err", err),
+					slog.String("request.uri", r.RequestURI),
+				)
+			}
+		case domain.IsInvalidInput(err), errors.Is(err, domain.ErrBucketDeleteNotConfirmed):
+			c := templates.BadRequestPage(templates.BadRequestViewModel{
+				Message: err.Error(),
+			})
+			if err := component(w, r, http.StatusBadRequest, c); err != nil {
+				logger.Error("failure rendering 400 page",
+					slog.Any("err", err),
+					slog.String("request.uri", r.RequestURI),
+				)
+			}
 		default:
 			c := templates.InternalErrorPage(templates.InternalErrorViewModel{
 				Err:      err,
@@ -14,33 +14,50 @@ import (
 )
 
 type Services struct {
-	Logger      *slog.Logger
-	Config      env.Config
-	Assets      *vite.Assets
-	Generator   *serverplate.Generator
-	PairStore   serverplate.PairStore
-	BucketStore serverplate.BucketStore
+	Logger        *slog.Logger
+	Config        env.Config
+	Assets        *vite.Assets
+	Generator     *serverplate.Generator
+	PairStore     serverplate.PairStore
+	BucketStore   serverplate.BucketStore
+	BucketService *serverplate.BucketService
+	JobRunStore   serverplate.JobRunStore
+	JobRunner     serverplate.JobRunner
+	Events        *serverplate.EventBus
+	AuditStore    serverplate.AuditStore
 }
 
 func New(svcs *Services) *http.Server {
 	m := http.NewServeMux()
 	addRoutes(m, svcs)
 
-	handlers := api.New(svcs.Generator, svcs.BucketStore)
+	handlers := api.New(
+		svcs.Generator,
+		svcs.BucketService,
+		svcs.BucketStore,
+		svcs.Events,
+		svcs.AuditStore,
+		svcs.JobRunner,
+		svcs.Config.ArchivedBucketsRetention,
+	)
 	strictOptions := api.StrictHTTPServerOptions{
 		RequestErrorHandlerFunc:  api.RequestErrorHandler(),
 		ResponseErrorHandlerFunc: api.ErrorHandler(svcs.Logger, svcs.Config.Debug),
 	}
 	strict := api.NewStrictHandlerWithOptions(handlers, nil, strictOptions)
-	apiHandler := api.HandlerFromMuxWithBaseURL(strict, http.NewServeMux(), "/api")
+	apiHandler := api.HandlerFromMuxWithBaseURL(strict, http.NewServeMux(), api.BaseURL)
 
-	m.Handle("/api/", apiHandler)
+	m.Handle("/api/", auditOriginMiddleware(serverplate.AuditSourceAPI, svcs.Config.TrustProxyHeaders)(apiHandler))
 
-	return &http.Server{
+	srv := &http.Server{
 		Addr:              svcs.Config.ListenAddr,
 		Handler:           m,
 		ReadHeaderTimeout: 3 * time.Second,
 	}
+	// the event streams never go idle, they are ended so that the shutdown does not wait for them.
+	srv.RegisterOnShutdown(svcs.Events.Close)
+
+	return srv
 }
 
 func component(w http.ResponseWriter, r *http.Request, status int, c templ.Component) error {
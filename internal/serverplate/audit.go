@@ -0,0 +1,206 @@
+package serverplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditBucketCreated   AuditAction = "bucket.created"
+	AuditBucketUpdated   AuditAction = "bucket.updated"
+	AuditBucketArchived  AuditAction = "bucket.archived"
+	AuditBucketRecovered AuditAction = "bucket.recovered"
+	AuditBucketDeleted   AuditAction = "bucket.deleted"
+	AuditBucketPopped    AuditAction = "bucket.popped"
+	AuditBucketReserved  AuditAction = "bucket.reserved"
+	AuditLeaseConfirmed  AuditAction = "lease.confirmed"
+	AuditLeaseReleased   AuditAction = "lease.released"
+)
+
+// Valid reports whether the action is one of the known actions.
+func (a AuditAction) Valid() bool {
+	switch a {
+	case AuditBucketCreated, AuditBucketUpdated, AuditBucketArchived, AuditBucketRecovered, AuditBucketDeleted,
+		AuditBucketPopped, AuditBucketReserved, AuditLeaseConfirmed, AuditLeaseReleased:
+		return true
+	}
+	return false
+}
+
+// AuditSource is where a change was made from.
+type AuditSource string
+
+const (
+	AuditSourceWeb AuditSource = "web"
+	AuditSourceAPI AuditSource = "api"
+	// AuditSourceJob is used for the changes made by the background jobs, their actor is the name of the job.
+	AuditSourceJob AuditSource = "job"
+)
+
+// Valid reports whether the source is one of the known sources.
+func (s AuditSource) Valid() bool {
+	switch s {
+	case AuditSourceWeb, AuditSourceAPI, AuditSourceJob:
+		return true
+	}
+	return false
+}
+
+// AuditEvent records a change made to a bucket and who made it. Events are never changed once recorded.
+type AuditEvent struct {
+	ID     int64
+	Action AuditAction
+	// BucketID and BucketName identify the bucket when the change was made, it may be renamed or removed since.
+	BucketID   int32
+	BucketName string
+	AuditOrigin
+	// Before and After hold the JSON state the change went from and to, they are null when there is none, like
+	// before a bucket is created.
+	Before    json.RawMessage
+	After     json.RawMessage
+	CreatedAt time.Time
+}
+
+// AuditOrigin tells who is behind the changes made while serving a request or running a job.
+type AuditOrigin struct {
+	Actor  string
+	Source AuditSource
+	// IP and RequestID are empty for the changes not made through a request.
+	IP        string
+	RequestID string
+}
+
+type auditOriginContextKey struct{}
+
+// NewContextWithAuditOrigin returns a context whose changes are recorded as made by origin.
+func NewContextWithAuditOrigin(ctx context.Context, origin AuditOrigin) context.Context {
+	return context.WithValue(ctx, auditOriginContextKey{}, origin)
+}
+
+// AuditOriginFromContext returns the origin of the changes made with ctx, the zero origin when it has none.
+func AuditOriginFromContext(ctx context.Context) AuditOrigin {
+	origin, _ := ctx.Value(auditOriginContextKey{}).(AuditOrigin)
+	return origin
+}
+
+type AuditListOptions struct {
+	// BucketID only lists the events of the bucket, zero lists the events of every bucket.
+	BucketID int32
+	Action   AuditAction
+	Actor    string
+	Source   AuditSource
+	// Since and Until only list the events recorded in [Since, Until), the zero times leave the range open.
+	Since time.Time
+	Until time.Time
+	// BeforeID only lists the events older than the event with the id, to page through them. Zero lists the latest.
+	BeforeID int64
+	// Limit is the maximum amount of events listed, zero means no limit.
+	Limit int
+}
+
+type AuditStore interface {
+	// Record appends the event, setting its id and creation time.
+	Record(ctx context.Context, e *AuditEvent) error
+	// List returns the events matching the options, the most recent first.
+	List(ctx context.Context, opts AuditListOptions) ([]AuditEvent, error)
+}
+
+// Auditor records the changes made to the buckets along with the origin found in their context.
+type Auditor struct {
+	auditStore AuditStore
+}
+
+func NewAuditor(auditStore AuditStore) *Auditor {
+	return &Auditor{
+		auditStore: auditStore,
+	}
+}
+
+// Record appends an event for the change made to b, before and after are encoded as JSON and nil is kept as null.
+// The change already happened by the time it is recorded, callers return the error so that an unaudited change
+// is not reported as a success.
+func (a *Auditor) Record(ctx context.Context, action AuditAction, b Bucket, before, after any) error {
+	e := AuditEvent{
+		Action:      action,
+		BucketID:    b.ID,
+		BucketName:  b.Name,
+		AuditOrigin: AuditOriginFromContext(ctx),
+	}
+
+	var err error
+	if e.Before, err = json.Marshal(before); err != nil {
+		return fmt.Errorf("failed to encode the state before the change: %w", err)
+	}
+	if e.After, err = json.Marshal(after); err != nil {
+		return fmt.Errorf("failed to encode the state after the change: %w", err)
+	}
+
+	if err := a.auditStore.Record(ctx, &e); err != nil {
+		return fmt.Errorf("failed to record the %s audit event: %w", action, err)
+	}
+
+	return nil
+}
+
+// BucketSnapshot is the state of a bucket kept by the audit events.
+type BucketSnapshot struct {
+	Name                   string            `json:"name"`
+	Description            string            `json:"description"`
+	Labels                 map[string]string `json:"labels"`
+	FilterLengthEnabled    bool              `json:"filter_length_enabled"`
+	FilterLengthMode       LengthMode        `json:"filter_length_mode"`
+	FilterLengthValue      int               `json:"filter_length_value"`
+	FilterMinLength        int               `json:"filter_min_length"`
+	FilterAdjectiveInitial string            `json:"filter_adjective_initial"`
+	FilterNounInitial      string            `json:"filter_noun_initial"`
+	FilterAlliterative     bool              `json:"filter_alliterative"`
+	FilterPrefix           string            `json:"filter_prefix"`
+	FilterSuffix           string            `json:"filter_suffix"`
+	FilterExcludedChars    string            `json:"filter_excluded_chars"`
+	// Retention is nil when the bucket uses the default retention of the server.
+	Retention            *string    `json:"retention"`
+	ExpiresAt            *time.Time `json:"expires_at"`
+	ArchiveWhenExhausted bool       `json:"archive_when_exhausted"`
+	ArchivedAt           *time.Time `json:"archived_at"`
+	Seed                 *int64     `json:"seed"`
+	Lazy                 bool       `json:"lazy"`
+}
+
+func SnapshotBucket(b Bucket) BucketSnapshot {
+	s := BucketSnapshot{
+		Name:                   b.Name,
+		Description:            b.Description,
+		Labels:                 b.Labels,
+		FilterLengthEnabled:    b.FilterLengthEnabled,
+		FilterLengthMode:       b.FilterLengthMode,
+		FilterLengthValue:      b.FilterLengthValue,
+		FilterMinLength:        b.FilterMinLength,
+		FilterAdjectiveInitial: b.FilterAdjectiveInitial,
+		FilterNounInitial:      b.FilterNounInitial,
+		FilterAlliterative:     b.FilterAlliterative,
+		FilterPrefix:           b.FilterPrefix,
+		FilterSuffix:           b.FilterSuffix,
+		FilterExcludedChars:    b.FilterExcludedChars,
+		ExpiresAt:              b.ExpiresAt,
+		ArchiveWhenExhausted:   b.ArchiveWhenExhausted,
+		ArchivedAt:             b.ArchivedAt,
+		Seed:                   b.Seed,
+		Lazy:                   b.Lazy,
+	}
+	if b.Retention != nil {
+		s.Retention = new(FormatRetention(*b.Retention))
+	}
+
+	return s
+}
+
+// AuditedName is the state recorded for the changes that hand out or return a single name.
+type AuditedName struct {
+	Name string `json:"name"`
+	// LeaseID is only set for the names of a lease.
+	LeaseID string `json:"lease_id,omitempty"`
+}
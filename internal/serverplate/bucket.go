@@ -11,9 +11,64 @@ type Bucket struct {
 	UpdatedAt   *time.Time
 	ArchivedAt  *time.Time
 
-	FilterLengthEnabled bool
-	FilterLengthMode    LengthMode
-	FilterLengthValue   int
+	FilterLengthEnabled    bool
+	FilterLengthMode       LengthMode
+	FilterLengthValue      int
+	FilterMinLength        int
+	FilterAdjectiveInitial string
+	FilterNounInitial      string
+	FilterAlliterative     bool
+	FilterPrefix           string
+	FilterSuffix           string
+	FilterExcludedChars    string
+
+	// Labels are key/value pairs used to find buckets, like team=payments or env=prod.
+	Labels map[string]string
+
+	// Retention is how long the bucket is kept once archived before being removed. It is nil to use the default
+	// retention of the server and zero to keep the bucket forever.
+	Retention *time.Duration
+
+	// ExpiresAt is when the bucket gets archived automatically, nil when it never expires.
+	ExpiresAt *time.Time
+	// ArchiveWhenExhausted archives the bucket automatically once every name has been popped.
+	ArchiveWhenExhausted bool
+
+	// Seed is the seed the values of the bucket were shuffled with, nil when they were shuffled randomly.
+	// WordsVersion is the version of the word lists they were built from, only set along with Seed.
+	Seed         *int64
+	WordsVersion string
+
+	// Lazy buckets compute each name when it is popped instead of storing every name up front, see LazyValues.
+	// They always have a Seed, and they can only pop while the word lists keep the WordsVersion.
+	Lazy bool
+
+	// Fill is the progress of the values being written in the background after the bucket was created, it is nil
+	// once every value is written. Buckets cannot hand out names while they have a Fill.
+	Fill *BucketFill
+
+	// RemainingValues is only set by BucketStore.List when ListOptions.IncludeRemaining is true.
+	RemainingValues int64
+}
+
+// PoppedName is a name that was handed out by a bucket.
+type PoppedName struct {
+	Name string
+	// PoppedAt is nil for the names popped before the pop time was recorded.
+	PoppedAt *time.Time
+}
+
+// BucketFill is the progress of a bucket whose values are written in the background.
+type BucketFill struct {
+	// Total is the number of values the fill writes, zero until it starts.
+	Total int64
+	Done  int64
+	// Error is why the fill failed, the bucket keeps the values written until then.
+	Error string
+}
+
+func (f BucketFill) Failed() bool {
+	return f.Error != ""
 }
 
 func (b *Bucket) MarkArchived() {
@@ -29,14 +84,65 @@ func (b Bucket) Archived() bool {
 	return b.ArchivedAt != nil
 }
 
+// Filling reports whether the values of the bucket are still being written or their fill failed.
+func (b Bucket) Filling() bool {
+	return b.Fill != nil
+}
+
+// RemovalTime returns when the archived bucket is due to be removed given the default retention of the server. It
+// returns false when the bucket is not archived or it is kept forever.
+func (b Bucket) RemovalTime(defaultRetention time.Duration) (time.Time, bool) {
+	retention := defaultRetention
+	if b.Retention != nil {
+		retention = *b.Retention
+	}
+
+	if !b.Archived() || retention <= 0 {
+		return time.Time{}, false
+	}
+
+	return b.ArchivedAt.Add(retention), true
+}
+
+// Expired reports whether the bucket has an expiry that is over at now.
+func (b Bucket) Expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !b.ExpiresAt.After(now)
+}
+
 // Filters returns the RandomPairFilters configured for this bucket.
-// If length filtering is disabled, returns empty filters.
+// If length filtering is disabled, the length and length mode are left empty.
 func (b Bucket) Filters() RandomPairFilters {
-	if !b.FilterLengthEnabled {
-		return RandomPairFilters{}
+	f := RandomPairFilters{
+		MinLength:        b.FilterMinLength,
+		AdjectiveInitial: b.FilterAdjectiveInitial,
+		NounInitial:      b.FilterNounInitial,
+		Alliterative:     b.FilterAlliterative,
+		Prefix:           b.FilterPrefix,
+		Suffix:           b.FilterSuffix,
+		ExcludedChars:    b.FilterExcludedChars,
 	}
-	return RandomPairFilters{
-		Length:     b.FilterLengthValue,
-		LengthMode: b.FilterLengthMode,
+	if b.FilterLengthEnabled {
+		f.Length = b.FilterLengthValue
+		f.LengthMode = b.FilterLengthMode
 	}
+	return f
+}
+
+// SetFilters replaces the filters configured for this bucket, it is the inverse of Filters.
+func (b *Bucket) SetFilters(f RandomPairFilters) {
+	b.FilterLengthEnabled = f.Length > 0
+	b.FilterLengthValue = f.Length
+	b.FilterLengthMode = f.LengthMode
+	b.FilterMinLength = f.MinLength
+	b.FilterAdjectiveInitial = f.AdjectiveInitial
+	b.FilterNounInitial = f.NounInitial
+	b.FilterAlliterative = f.Alliterative
+	b.FilterPrefix = f.Prefix
+	b.FilterSuffix = f.Suffix
+	b.FilterExcludedChars = f.ExcludedChars
+}
+
+// HasFilters reports whether any filter is configured for this bucket.
+func (b Bucket) HasFilters() bool {
+	return b.Filters() != RandomPairFilters{}
 }
@@ -0,0 +1,502 @@
+package serverplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BucketService holds the rules for changing the buckets and handing out their names, shared by the web and api
+// frontends. Every change it makes is published to the event bus and recorded by the auditor.
+type BucketService struct {
+	generator   *Generator
+	bucketStore BucketStore
+	leaseStore  LeaseStore
+	filler      BucketFiller
+	events      *EventBus
+	auditor     *Auditor
+	// leaseTTL is the ttl of the reservations that do not ask for one, leaseMaxTTL the longest they can ask for.
+	leaseTTL    time.Duration
+	leaseMaxTTL time.Duration
+}
+
+func NewBucketService(
+	generator *Generator,
+	bucketStore BucketStore,
+	leaseStore LeaseStore,
+	filler BucketFiller,
+	events *EventBus,
+	auditor *Auditor,
+	leaseTTL time.Duration,
+	leaseMaxTTL time.Duration,
+) *BucketService {
+	return &BucketService{
+		generator:   generator,
+		bucketStore: bucketStore,
+		leaseStore:  leaseStore,
+		filler:      filler,
+		events:      events,
+		auditor:     auditor,
+		leaseTTL:    leaseTTL,
+		leaseMaxTTL: leaseMaxTTL,
+	}
+}
+
+// Find returns the bucket addressed by ref, which holds either the id or the name of the bucket. Values made only of
+// digits are always looked up as ids.
+func (s *BucketService) Find(ctx context.Context, ref string) (Bucket, error) {
+	if ref == "" || strings.Trim(ref, "0123456789") != "" {
+		return s.bucketStore.OneByName(ctx, ref)
+	}
+
+	id, err := strconv.ParseInt(ref, 10, 32)
+	if err != nil {
+		// out of the int32 range, no bucket can have such id.
+		return Bucket{}, ErrBucketNotFound
+	}
+
+	return s.bucketStore.OneByID(ctx, int32(id))
+}
+
+type CreateBucketOptions struct {
+	Name        string
+	Description string
+	Labels      map[string]string
+	Filters     RandomPairFilters
+	// Retention and ExpiresAt are parsed with ParseRetention and ParseExpiry, empty keeps the defaults.
+	Retention            string
+	ExpiresAt            string
+	ArchiveWhenExhausted bool
+	// Lazy computes the names when they are popped instead of writing them up front. Seed orders the names, lazy
+	// buckets without one get a random seed. WordsVersion optionally pins the word lists of a seeded bucket.
+	Lazy         bool
+	Seed         *int64
+	WordsVersion string
+}
+
+// Validate checks every option, the returned error is a ValidationErrors with all the rejected fields. Create
+// validates the options itself, Validate lets callers report the fields they reject along with these.
+func (o CreateBucketOptions) Validate() error {
+	_, err := o.bucket()
+	return err
+}
+
+// bucket returns the bucket described by the options once they are validated.
+func (o CreateBucketOptions) bucket() (Bucket, error) {
+	b := Bucket{
+		Name:                 o.Name,
+		Description:          o.Description,
+		Labels:               o.Labels,
+		ArchiveWhenExhausted: o.ArchiveWhenExhausted,
+	}
+	b.SetFilters(o.Filters)
+
+	var errs ValidationErrors
+	errs.collect(ValidateBucketName(b.Name))
+	errs.collect(ValidateBucketDescription(b.Description))
+	errs.collect(ValidateFilters(o.Filters))
+	errs.collect(ValidateLabels(b.Labels))
+
+	var err error
+	b.Retention, err = ParseRetention(o.Retention)
+	errs.collect(err)
+	b.ExpiresAt, err = ParseExpiry(o.ExpiresAt)
+	errs.collect(err)
+
+	return b, errs.err()
+}
+
+// Create validates and creates the bucket, every rejected option is reported at once in a ValidationErrors. Filters
+// no name matches are rejected with ErrNoMatchingPairs, the bucket would be empty. Its names are written in the
+// background, so the bucket is returned while still filling, except for lazy buckets which are ready right away.
+func (s *BucketService) Create(ctx context.Context, opts CreateBucketOptions) (Bucket, error) {
+	b, err := opts.bucket()
+	if err != nil {
+		return Bucket{}, err
+	}
+
+	stats, err := s.generator.PairStats(ctx, opts.Filters)
+	if err != nil {
+		return Bucket{}, err
+	}
+	if stats.PairCount == 0 {
+		return Bucket{}, fmt.Errorf("%w: the bucket would be empty", ErrNoMatchingPairs)
+	}
+	if opts.Lazy {
+		if err := checkLazyStride("lazy", stats); err != nil {
+			return Bucket{}, err
+		}
+	}
+
+	// the word lists are checked before creating the bucket so that a version mismatch leaves nothing behind.
+	if opts.Lazy || opts.Seed != nil {
+		b.WordsVersion, err = s.generator.WordsVersion(ctx, opts.WordsVersion)
+		if err != nil {
+			return Bucket{}, err
+		}
+		b.Seed = opts.Seed
+	}
+
+	if !opts.Lazy {
+		b.Fill = &BucketFill{}
+		if err := s.bucketStore.Create(ctx, &b); err != nil {
+			return Bucket{}, err
+		}
+
+		// the fill is queued before recording so that a failed record does not leave the bucket filling forever.
+		s.filler.Fill(b)
+		if err := s.auditor.Record(ctx, AuditBucketCreated, b, nil, SnapshotBucket(b)); err != nil {
+			return Bucket{}, err
+		}
+		return b, nil
+	}
+
+	b.Lazy = true
+	if b.Seed == nil {
+		b.Seed = new(rand.Int64())
+	}
+
+	if err := s.bucketStore.Create(ctx, &b); err != nil {
+		return Bucket{}, err
+	}
+
+	if err := s.bucketStore.FillBucketLazily(ctx, b, int64(stats.PairCount)); err != nil {
+		return Bucket{}, err
+	}
+
+	if err := s.auditor.Record(ctx, AuditBucketCreated, b, nil, SnapshotBucket(b)); err != nil {
+		return Bucket{}, err
+	}
+	return b, nil
+}
+
+type CloneBucketOptions struct {
+	Name string
+	// Description and Labels replace the ones of the source bucket when not nil.
+	Description *string
+	Labels      map[string]string
+	// CopyRemaining hands out the names the source bucket has left in the same order, otherwise every name matching
+	// the filters is handed out again in a new order.
+	CopyRemaining bool
+}
+
+// Clone creates a bucket with the filters and policies of src. The expiry is a point in time of src, only its
+// exhaustion policy carries over. A reshuffle of an eager bucket writes its names in the background like Create, so
+// the bucket is returned while still filling.
+func (s *BucketService) Clone(ctx context.Context, src Bucket, opts CloneBucketOptions) (Bucket, error) {
+	var errs ValidationErrors
+	errs.collect(ValidateBucketName(opts.Name))
+	if opts.Description != nil {
+		errs.collect(ValidateBucketDescription(*opts.Description))
+	}
+	if opts.Labels != nil {
+		errs.collect(ValidateLabels(opts.Labels))
+	}
+	if err := errs.err(); err != nil {
+		return Bucket{}, err
+	}
+
+	if src.Filling() {
+		return Bucket{}, ErrBucketFilling
+	}
+
+	b := Bucket{
+		Name:                 opts.Name,
+		Description:          src.Description,
+		Labels:               src.Labels,
+		Retention:            src.Retention,
+		ArchiveWhenExhausted: src.ArchiveWhenExhausted,
+		Lazy:                 src.Lazy,
+	}
+	b.SetFilters(src.Filters())
+
+	if opts.Description != nil {
+		b.Description = *opts.Description
+	}
+	if opts.Labels != nil {
+		b.Labels = opts.Labels
+	}
+
+	// copying the remaining names of a lazy bucket continues its walk, a reshuffle starts a new one.
+	var count int
+	if src.Lazy && opts.CopyRemaining {
+		b.Seed = src.Seed
+		b.WordsVersion = src.WordsVersion
+	} else if src.Lazy {
+		version, err := s.generator.WordsVersion(ctx, "")
+		if err != nil {
+			return Bucket{}, err
+		}
+		count, err = s.generator.PairCount(ctx, b.Filters())
+		if err != nil {
+			return Bucket{}, err
+		}
+		b.Seed = new(rand.Int64())
+		b.WordsVersion = version
+	}
+
+	if !opts.CopyRemaining && !b.Lazy {
+		b.Fill = &BucketFill{}
+		if err := s.bucketStore.Create(ctx, &b); err != nil {
+			return Bucket{}, err
+		}
+
+		// the fill is queued before recording so that a failed record does not leave the bucket filling forever.
+		s.filler.Fill(b)
+		if err := s.auditor.Record(ctx, AuditBucketCreated, b, nil, SnapshotBucket(b)); err != nil {
+			return Bucket{}, err
+		}
+		return b, nil
+	}
+
+	if err := s.bucketStore.Create(ctx, &b); err != nil {
+		return Bucket{}, err
+	}
+
+	var err error
+	if opts.CopyRemaining {
+		err = s.bucketStore.CopyRemainingValues(ctx, src, b)
+	} else {
+		err = s.bucketStore.FillBucketLazily(ctx, b, int64(count))
+	}
+	if err != nil {
+		return Bucket{}, fmt.Errorf("failed to fill the cloned bucket: %w", err)
+	}
+
+	if err := s.auditor.Record(ctx, AuditBucketCreated, b, nil, SnapshotBucket(b)); err != nil {
+		return Bucket{}, err
+	}
+	return b, nil
+}
+
+// UpdateBucketOptions holds the changes made to a bucket, nil fields are left unchanged.
+type UpdateBucketOptions struct {
+	Name        *string
+	Description *string
+	// Filters replace the filters of the bucket and the names it has left with the ones matching them.
+	Filters *RandomPairFilters
+	// Labels replace every label of the bucket when not nil, an empty map removes them.
+	Labels map[string]string
+	// Retention and ExpiresAt are parsed with ParseRetention and ParseExpiry, empty resets them to the defaults.
+	Retention            *string
+	ExpiresAt            *string
+	ArchiveWhenExhausted *bool
+}
+
+// Update changes the bucket, archived buckets are read only and every rejected option is reported at once in a
+// ValidationErrors. It returns the updated bucket along with the change in
+// the amount of names it has left, which only changes along with the filters.
+func (s *BucketService) Update(ctx context.Context, b Bucket, opts UpdateBucketOptions) (Bucket, int64, error) {
+	if b.Archived() {
+		return Bucket{}, 0, ErrBucketArchived
+	}
+
+	// the values written by the fill would not follow the new filters.
+	if opts.Filters != nil && b.Filling() {
+		return Bucket{}, 0, ErrBucketFilling
+	}
+
+	before := SnapshotBucket(b)
+
+	var errs ValidationErrors
+	var err error
+	if opts.Name != nil {
+		errs.collect(ValidateBucketName(*opts.Name))
+		b.Name = *opts.Name
+	}
+	if opts.Description != nil {
+		errs.collect(ValidateBucketDescription(*opts.Description))
+		b.Description = *opts.Description
+	}
+	if opts.Filters != nil {
+		errs.collect(ValidateFilters(*opts.Filters))
+		b.SetFilters(*opts.Filters)
+	}
+	if opts.Labels != nil {
+		errs.collect(ValidateLabels(opts.Labels))
+		b.Labels = opts.Labels
+	}
+	if opts.Retention != nil {
+		b.Retention, err = ParseRetention(*opts.Retention)
+		errs.collect(err)
+	}
+	if opts.ExpiresAt != nil {
+		b.ExpiresAt, err = ParseExpiry(*opts.ExpiresAt)
+		errs.collect(err)
+	}
+	if err := errs.err(); err != nil {
+		return Bucket{}, 0, err
+	}
+
+	if opts.Filters != nil && b.Lazy {
+		stats, err := s.generator.PairStats(ctx, *opts.Filters)
+		if err != nil {
+			return Bucket{}, 0, err
+		}
+		if err := checkLazyStride("filters", stats); err != nil {
+			return Bucket{}, 0, err
+		}
+	}
+
+	if opts.ArchiveWhenExhausted != nil {
+		b.ArchiveWhenExhausted = *opts.ArchiveWhenExhausted
+	}
+
+	// every option is written at once, a rejected name or words version leaves the bucket as it was.
+	delta, err := s.bucketStore.UpdateBucket(ctx, &b, UpdateOptions{
+		Labels:  opts.Labels != nil,
+		Filters: opts.Filters != nil,
+	})
+	if err != nil {
+		return Bucket{}, 0, err
+	}
+
+	if opts.Filters != nil {
+		s.events.Publish(BucketEvent{Type: BucketEventRemaining, BucketID: b.ID})
+	}
+
+	if err := s.auditor.Record(ctx, AuditBucketUpdated, b, before, SnapshotBucket(b)); err != nil {
+		return Bucket{}, 0, err
+	}
+	return b, delta, nil
+}
+
+// Pop hands out the next name of the bucket. It returns ErrBucketArchived, ErrBucketFilling or ErrBucketExhausted
+// when the bucket has no name to hand out.
+func (s *BucketService) Pop(ctx context.Context, b Bucket) (string, error) {
+	if err := usable(b); err != nil {
+		return "", err
+	}
+
+	name, err := s.bucketStore.PopName(ctx, b)
+	if err != nil {
+		return "", err
+	}
+
+	s.events.Publish(BucketEvent{Type: BucketEventPopped, BucketID: b.ID, Name: name})
+	if err := s.auditor.Record(ctx, AuditBucketPopped, b, nil, AuditedName{Name: name}); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Reserve leases the next name of the bucket until the ttl passes, it is parsed with ParseLeaseTTL. It fails like
+// Pop when the bucket has no name to hand out.
+func (s *BucketService) Reserve(ctx context.Context, b Bucket, ttl string) (Lease, error) {
+	d, err := ParseLeaseTTL(ttl, s.leaseTTL, s.leaseMaxTTL)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	if err := usable(b); err != nil {
+		return Lease{}, err
+	}
+
+	l, err := s.leaseStore.Reserve(ctx, b, d)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	s.events.Publish(BucketEvent{Type: BucketEventReserved, BucketID: b.ID, Name: l.Name})
+	if err := s.auditor.Record(ctx, AuditBucketReserved, b, nil, AuditedName{Name: l.Name, LeaseID: l.ID}); err != nil {
+		return Lease{}, err
+	}
+	return l, nil
+}
+
+// ConfirmLease finalizes the lease with the id, see LeaseStore.Confirm.
+func (s *BucketService) ConfirmLease(ctx context.Context, id string) (Lease, error) {
+	l, err := s.leaseStore.Confirm(ctx, id)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	b, err := s.bucketStore.OneByID(ctx, l.BucketID)
+	if err != nil {
+		return Lease{}, fmt.Errorf("failed to retrieve the bucket of the lease: %w", err)
+	}
+
+	if err := s.auditor.Record(ctx, AuditLeaseConfirmed, b, nil, AuditedName{Name: l.Name, LeaseID: l.ID}); err != nil {
+		return Lease{}, err
+	}
+	return l, nil
+}
+
+// usable checks that the names of the bucket can be handed out.
+func usable(b Bucket) error {
+	switch {
+	case b.Archived():
+		return ErrBucketArchived
+	case b.Filling():
+		return ErrBucketFilling
+	}
+
+	return nil
+}
+
+// Archive makes the bucket read only until it is recovered or removed once its retention is over. Archiving an
+// archived bucket leaves it unchanged, so its retention keeps counting from the first time.
+func (s *BucketService) Archive(ctx context.Context, b Bucket) (Bucket, error) {
+	if b.Archived() {
+		return b, nil
+	}
+
+	before := SnapshotBucket(b)
+	b.MarkArchived()
+
+	if err := s.bucketStore.Save(ctx, &b); err != nil {
+		return Bucket{}, fmt.Errorf("failed to save bucket: %w", err)
+	}
+
+	s.events.Publish(BucketEvent{Type: BucketEventArchived, BucketID: b.ID})
+	if err := s.auditor.Record(ctx, AuditBucketArchived, b, before, SnapshotBucket(b)); err != nil {
+		return Bucket{}, err
+	}
+	return b, nil
+}
+
+// Recover makes the archived bucket active again, recovering an active bucket leaves it unchanged.
+func (s *BucketService) Recover(ctx context.Context, b Bucket) (Bucket, error) {
+	if !b.Archived() {
+		return b, nil
+	}
+
+	before := SnapshotBucket(b)
+	b.Recover()
+
+	if err := s.bucketStore.Save(ctx, &b); err != nil {
+		return Bucket{}, fmt.Errorf("failed to save bucket: %w", err)
+	}
+
+	s.events.Publish(BucketEvent{Type: BucketEventRecovered, BucketID: b.ID})
+	if err := s.auditor.Record(ctx, AuditBucketRecovered, b, before, SnapshotBucket(b)); err != nil {
+		return Bucket{}, err
+	}
+	return b, nil
+}
+
+// Delete removes the archived bucket along with its names, confirm must be the name of the bucket. It returns
+// ErrBucketNotArchived when the bucket is not archived, or was recovered in the meantime.
+func (s *BucketService) Delete(ctx context.Context, b Bucket, confirm string) error {
+	if confirm != b.Name {
+		return fmt.Errorf("%w: the confirmation does not match the bucket name", ErrBucketDeleteNotConfirmed)
+	}
+
+	if !b.Archived() {
+		return ErrBucketNotArchived
+	}
+
+	if err := s.bucketStore.DeleteArchived(ctx, b.ID); err != nil {
+		if errors.Is(err, ErrBucketNotFound) {
+			// recovered or removed since it was retrieved.
+			return ErrBucketNotArchived
+		}
+		return fmt.Errorf("failed to delete the bucket: %w", err)
+	}
+
+	return s.auditor.Record(ctx, AuditBucketDeleted, b, SnapshotBucket(b), nil)
+}
@@ -0,0 +1,439 @@
+package serverplate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+)
+
+// recordingFiller keeps the buckets it was asked to fill without writing their names.
+type recordingFiller struct {
+	filled []serverplate.Bucket
+}
+
+func (f *recordingFiller) Fill(b serverplate.Bucket) {
+	f.filled = append(f.filled, b)
+}
+
+func newTestBucketService(t *testing.T) (*serverplate.BucketService, *recordingFiller, *memstore.AuditStore) {
+	t.Helper()
+
+	words := memstore.NewWords([]string{"brave", "calm"}, []string{"river", "mountain"})
+	bucketStore := memstore.NewBucketStore(words)
+	filler := &recordingFiller{}
+	auditStore := memstore.NewAuditStore()
+
+	s := serverplate.NewBucketService(
+		serverplate.NewGenerator(memstore.NewPairStore(words)),
+		bucketStore,
+		memstore.NewLeaseStore(bucketStore),
+		filler,
+		serverplate.NewEventBus(),
+		serverplate.NewAuditor(auditStore),
+		5*time.Minute,
+		time.Hour,
+	)
+
+	return s, filler, auditStore
+}
+
+func TestBucketServiceCreate(t *testing.T) {
+	ctx := context.Background()
+	s, filler, auditStore := newTestBucketService(t)
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers"})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if !b.Filling() {
+		t.Errorf("Create() = got a bucket that is not filling, want it filling")
+	}
+	if len(filler.filled) != 1 || filler.filled[0].ID != b.ID {
+		t.Errorf("Create() = got %d buckets filled, want the created bucket filled", len(filler.filled))
+	}
+
+	events, err := auditStore.List(ctx, serverplate.AuditListOptions{BucketID: b.ID})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Action != serverplate.AuditBucketCreated {
+		t.Errorf("List() = got %d events, want a single %s event", len(events), serverplate.AuditBucketCreated)
+	}
+
+	lazy, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "lazy-servers", Lazy: true})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if lazy.Filling() || lazy.Seed == nil || lazy.WordsVersion == "" {
+		t.Errorf("Create() = got a lazy bucket filling %v with seed %v, want it ready and seeded", lazy.Filling(), lazy.Seed)
+	}
+	if len(filler.filled) != 1 {
+		t.Errorf("Create() = got %d buckets filled, want the lazy bucket left to its permutation", len(filler.filled))
+	}
+}
+
+func TestBucketServiceCreateRejectsInvalidInput(t *testing.T) {
+	cases := []struct {
+		Name string
+		Opts serverplate.CreateBucketOptions
+		Err  error
+	}{
+		{
+			Name: "name",
+			Opts: serverplate.CreateBucketOptions{Name: "Servers"},
+			Err:  serverplate.ErrInvalidBucketName,
+		},
+		{
+			Name: "description",
+			Opts: serverplate.CreateBucketOptions{
+				Name:        "servers",
+				Description: strings.Repeat("a", serverplate.MaxBucketDescriptionLength+1),
+			},
+			Err: serverplate.ErrInvalidDescription,
+		},
+		{
+			Name: "filters",
+			Opts: serverplate.CreateBucketOptions{
+				Name:    "servers",
+				Filters: serverplate.RandomPairFilters{AdjectiveInitial: "ab"},
+			},
+			Err: serverplate.ErrInvalidFilters,
+		},
+		{
+			Name: "labels",
+			Opts: serverplate.CreateBucketOptions{Name: "servers", Labels: map[string]string{"Team": "a"}},
+			Err:  serverplate.ErrInvalidLabels,
+		},
+		{
+			Name: "retention",
+			Opts: serverplate.CreateBucketOptions{Name: "servers", Retention: "-1h"},
+			Err:  serverplate.ErrInvalidRetention,
+		},
+		{
+			Name: "expiry",
+			Opts: serverplate.CreateBucketOptions{Name: "servers", ExpiresAt: "tomorrow"},
+			Err:  serverplate.ErrInvalidExpiry,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx := context.Background()
+			s, filler, _ := newTestBucketService(t)
+
+			_, err := s.Create(ctx, tt.Opts)
+			if !errors.Is(err, tt.Err) {
+				t.Fatalf("Create() = got %v, want %v", err, tt.Err)
+			}
+			if !serverplate.IsInvalidInput(err) {
+				t.Errorf("IsInvalidInput() = got false for %v, want true", err)
+			}
+			if _, err := s.Find(ctx, "servers"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+				t.Errorf("Find() = got %v, want no bucket created", err)
+			}
+			if len(filler.filled) != 0 {
+				t.Errorf("Create() = got %d buckets filled, want none", len(filler.filled))
+			}
+		})
+	}
+}
+
+func TestBucketServiceCreateReportsEveryField(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestBucketService(t)
+
+	_, err := s.Create(ctx, serverplate.CreateBucketOptions{
+		Name:      "Servers",
+		Filters:   serverplate.RandomPairFilters{Length: 99, LengthMode: serverplate.LengthModeUpto},
+		Retention: "soon",
+		ExpiresAt: "tomorrow",
+	})
+
+	var fields []string
+	for _, f := range serverplate.FieldErrors(err) {
+		fields = append(fields, f.Field)
+	}
+	want := []string{"name", "filters.length", "retention", "expires_at"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("Create() = got fields %q rejected, want %q", fields, want)
+	}
+}
+
+func TestBucketServiceCreateRejectsEmptyBuckets(t *testing.T) {
+	ctx := context.Background()
+	s, filler, _ := newTestBucketService(t)
+
+	for _, lazy := range []bool{false, true} {
+		_, err := s.Create(ctx, serverplate.CreateBucketOptions{
+			Name:    "servers",
+			Filters: serverplate.RandomPairFilters{Prefix: "zz"},
+			Lazy:    lazy,
+		})
+		if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+			t.Errorf("Create(lazy: %v) = got %v, want ErrNoMatchingPairs", lazy, err)
+		}
+	}
+
+	if _, err := s.Find(ctx, "servers"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("Find() = got %v, want no bucket created", err)
+	}
+	if len(filler.filled) != 0 {
+		t.Errorf("Create() = got %d buckets filled, want none", len(filler.filled))
+	}
+}
+
+func TestBucketServiceLazyTooSelective(t *testing.T) {
+	ctx := context.Background()
+
+	var adjectives, nouns []string
+	for i := range 40 {
+		adjectives = append(adjectives, fmt.Sprintf("adj%d", i))
+	}
+	for i := range 30 {
+		nouns = append(nouns, fmt.Sprintf("noun%d", i))
+	}
+	words := memstore.NewWords(adjectives, nouns)
+	bucketStore := memstore.NewBucketStore(words)
+	s := serverplate.NewBucketService(
+		serverplate.NewGenerator(memstore.NewPairStore(words)),
+		bucketStore,
+		memstore.NewLeaseStore(bucketStore),
+		&recordingFiller{},
+		serverplate.NewEventBus(),
+		serverplate.NewAuditor(memstore.NewAuditStore()),
+		5*time.Minute,
+		time.Hour,
+	)
+
+	// a single name out of 1200 would make every pop walk most of the pairs.
+	sparse := serverplate.RandomPairFilters{Prefix: "adj0-noun0"}
+	_, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "sparse", Filters: sparse, Lazy: true})
+	if fields := serverplate.FieldErrors(err); len(fields) != 1 || fields[0].Field != "lazy" ||
+		!errors.Is(err, serverplate.ErrLazyTooSelective) {
+		t.Errorf("Create() = got %v, want the lazy field rejected", err)
+	}
+
+	if _, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "eager", Filters: sparse}); err != nil {
+		t.Errorf("Create() = unexpected error for an eager bucket: %v", err)
+	}
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{
+		Name:    "lazy",
+		Filters: serverplate.RandomPairFilters{Prefix: "adj0-"},
+		Lazy:    true,
+	})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	_, _, err = s.Update(ctx, b, serverplate.UpdateBucketOptions{Filters: &sparse})
+	if fields := serverplate.FieldErrors(err); len(fields) != 1 || fields[0].Field != "filters" ||
+		!errors.Is(err, serverplate.ErrLazyTooSelective) {
+		t.Errorf("Update() = got %v, want the filters rejected", err)
+	}
+}
+
+func TestBucketServiceFind(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestBucketService(t)
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers", Lazy: true})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	for _, ref := range []string{"servers", "1"} {
+		got, err := s.Find(ctx, ref)
+		if err != nil {
+			t.Fatalf("Find(%q) = unexpected error: %v", ref, err)
+		}
+		if got.ID != b.ID {
+			t.Errorf("Find(%q) = got bucket %d, want %d", ref, got.ID, b.ID)
+		}
+	}
+
+	for _, ref := range []string{"", "other", "2", "99999999999"} {
+		if _, err := s.Find(ctx, ref); !errors.Is(err, serverplate.ErrBucketNotFound) {
+			t.Errorf("Find(%q) = got %v, want ErrBucketNotFound", ref, err)
+		}
+	}
+}
+
+func TestBucketServiceArchivedIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	s, _, auditStore := newTestBucketService(t)
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers", Lazy: true})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	archived, err := s.Archive(ctx, b)
+	if err != nil {
+		t.Fatalf("Archive() = unexpected error: %v", err)
+	}
+
+	// archiving again keeps the time the retention counts from.
+	again, err := s.Archive(ctx, archived)
+	if err != nil {
+		t.Fatalf("Archive() = unexpected error: %v", err)
+	}
+	if !again.ArchivedAt.Equal(*archived.ArchivedAt) {
+		t.Errorf("Archive() = got archived at %v, want it kept at %v", again.ArchivedAt, archived.ArchivedAt)
+	}
+
+	if _, err := s.Pop(ctx, again); !errors.Is(err, serverplate.ErrBucketArchived) {
+		t.Errorf("Pop() = got %v, want ErrBucketArchived", err)
+	}
+	if _, err := s.Reserve(ctx, again, ""); !errors.Is(err, serverplate.ErrBucketArchived) {
+		t.Errorf("Reserve() = got %v, want ErrBucketArchived", err)
+	}
+	_, _, err = s.Update(ctx, again, serverplate.UpdateBucketOptions{Name: new("renamed")})
+	if !errors.Is(err, serverplate.ErrBucketArchived) {
+		t.Errorf("Update() = got %v, want ErrBucketArchived", err)
+	}
+
+	recovered, err := s.Recover(ctx, again)
+	if err != nil {
+		t.Fatalf("Recover() = unexpected error: %v", err)
+	}
+	if _, err := s.Pop(ctx, recovered); err != nil {
+		t.Errorf("Pop() = unexpected error after recovering: %v", err)
+	}
+
+	events, err := auditStore.List(ctx, serverplate.AuditListOptions{BucketID: b.ID})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+	var actions []serverplate.AuditAction
+	for _, e := range events {
+		actions = append(actions, e.Action)
+	}
+	want := []serverplate.AuditAction{
+		serverplate.AuditBucketPopped,
+		serverplate.AuditBucketRecovered,
+		serverplate.AuditBucketArchived,
+		serverplate.AuditBucketCreated,
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("List() = got actions %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("List() = got actions %v, want %v", actions, want)
+			break
+		}
+	}
+}
+
+// failingAuditStore fails every record, to check that unaudited changes are not reported as a success.
+type failingAuditStore struct {
+	*memstore.AuditStore
+}
+
+func (failingAuditStore) Record(context.Context, *serverplate.AuditEvent) error {
+	return errors.New("audit store is down")
+}
+
+func TestBucketServiceAuditFailure(t *testing.T) {
+	ctx := context.Background()
+
+	words := memstore.NewWords([]string{"brave", "calm"}, []string{"river", "mountain"})
+	bucketStore := memstore.NewBucketStore(words)
+	filler := &recordingFiller{}
+	s := serverplate.NewBucketService(
+		serverplate.NewGenerator(memstore.NewPairStore(words)),
+		bucketStore,
+		memstore.NewLeaseStore(bucketStore),
+		filler,
+		serverplate.NewEventBus(),
+		serverplate.NewAuditor(failingAuditStore{memstore.NewAuditStore()}),
+		5*time.Minute,
+		time.Hour,
+	)
+
+	if _, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers"}); err == nil {
+		t.Errorf("Create() = got no error, want the failure to record the audit event")
+	}
+	if len(filler.filled) != 1 {
+		t.Errorf("Create() = got %d buckets filled, want the created bucket filled anyway", len(filler.filled))
+	}
+
+	b, err := s.Find(ctx, "servers")
+	if err != nil {
+		t.Fatalf("Find() = unexpected error: %v", err)
+	}
+	if _, _, err := s.Update(ctx, b, serverplate.UpdateBucketOptions{Description: new("web")}); err == nil {
+		t.Errorf("Update() = got no error, want the failure to record the audit event")
+	}
+}
+
+func TestBucketServiceUpdate(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestBucketService(t)
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers", Lazy: true})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	// the update is rejected as a whole, the valid name is not saved either.
+	_, _, err = s.Update(ctx, b, serverplate.UpdateBucketOptions{Name: new("renamed"), Retention: new("soon")})
+	if !errors.Is(err, serverplate.ErrInvalidRetention) {
+		t.Fatalf("Update() = got %v, want ErrInvalidRetention", err)
+	}
+	if _, err := s.Find(ctx, "servers"); err != nil {
+		t.Errorf("Find() = unexpected error, want the bucket left unchanged: %v", err)
+	}
+
+	updated, delta, err := s.Update(ctx, b, serverplate.UpdateBucketOptions{
+		Name:    new("renamed"),
+		Filters: &serverplate.RandomPairFilters{AdjectiveInitial: "b"},
+	})
+	if err != nil {
+		t.Fatalf("Update() = unexpected error: %v", err)
+	}
+	if updated.Name != "renamed" || updated.FilterAdjectiveInitial != "b" {
+		t.Errorf("Update() = got %q filtered by %q, want renamed filtered by b", updated.Name, updated.FilterAdjectiveInitial)
+	}
+	if delta != -2 {
+		t.Errorf("Update() = got delta %d, want -2", delta)
+	}
+}
+
+func TestBucketServiceDelete(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestBucketService(t)
+
+	b, err := s.Create(ctx, serverplate.CreateBucketOptions{Name: "servers", Lazy: true})
+	if err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if err := s.Delete(ctx, b, "servers"); !errors.Is(err, serverplate.ErrBucketNotArchived) {
+		t.Errorf("Delete() = got %v, want ErrBucketNotArchived", err)
+	}
+
+	b, err = s.Archive(ctx, b)
+	if err != nil {
+		t.Fatalf("Archive() = unexpected error: %v", err)
+	}
+
+	if err := s.Delete(ctx, b, "other"); !errors.Is(err, serverplate.ErrBucketDeleteNotConfirmed) {
+		t.Errorf("Delete() = got %v, want ErrBucketDeleteNotConfirmed", err)
+	}
+
+	if err := s.Delete(ctx, b, "servers"); err != nil {
+		t.Fatalf("Delete() = unexpected error: %v", err)
+	}
+	if _, err := s.Find(ctx, "servers"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("Find() = got %v, want ErrBucketNotFound", err)
+	}
+}
@@ -8,16 +8,105 @@ import (
 type BucketStore interface {
 	List(ctx context.Context, opts ListOptions) ([]Bucket, error)
 	Create(ctx context.Context, b *Bucket) error
+	// SetLabels replaces every label of the bucket by the given ones.
+	SetLabels(ctx context.Context, bucketID int32, labels map[string]string) error
 	SetCursor(ctx context.Context, bucketID int32, cursor int32) error
 	OneByName(ctx context.Context, name string) (Bucket, error)
 	OneByID(ctx context.Context, id int32) (Bucket, error)
 	FillBucketValues(ctx context.Context, b Bucket, f RandomPairFilters) error
+	// FillBucketValuesInOrder fills the bucket with the given values keeping their order, for the orders computed
+	// outside of the store like the seeded ones.
+	FillBucketValuesInOrder(ctx context.Context, b Bucket, values []string) error
+	// FillBucketLazily makes b a lazy bucket handing out the count names matching its filters in the order given by
+	// its seed, count comes from the PairCount checked when creating the bucket so the walk is not counted again. It
+	// returns ErrWordsVersionMismatch when the word lists are not the ones of b.WordsVersion.
+	FillBucketLazily(ctx context.Context, b Bucket, count int64) error
+	// StartFill records the number of values the background fill of b writes, b must have been created with a Fill.
+	// The bucket stops filling right away when there are none. It returns ErrBucketNotFilling when b is not filling
+	// or its fill failed.
+	StartFill(ctx context.Context, b Bucket, total int64) error
+	// AppendFillValues writes the values after the ones the fill of b already wrote and records the progress, the
+	// bucket stops filling once the values written reach the total. It returns ErrBucketNotFilling like StartFill.
+	AppendFillValues(ctx context.Context, b Bucket, values []string) error
+	// KeepFilling records that the fill of b is still queued or running, so FailStalledFills leaves it alone while
+	// it waits for a worker or writes a large batch. It returns ErrBucketNotFilling like StartFill.
+	KeepFilling(ctx context.Context, b Bucket) error
+	// FailFill records why the fill of b failed, the bucket keeps its Fill and never hands out names.
+	FailFill(ctx context.Context, b Bucket, reason string) error
+	// FailStalledFills fails the fills that made no progress since before, like the ones of a server instance that
+	// stopped while filling. It returns the number of fills failed.
+	FailStalledFills(ctx context.Context, before time.Time) (int64, error)
+	// CopyRemainingValues fills the bucket to with the values the bucket from has not popped yet, in the same order.
+	// When from is lazy, to must have been created with the same seed and words version and becomes lazy too.
+	CopyRemainingValues(ctx context.Context, from, to Bucket) error
 	RemainingValuesTotal(ctx context.Context, b Bucket) (int64, error)
 	PopName(ctx context.Context, b Bucket) (string, error)
+	// RecentlyPopped returns up to limit popped names of the bucket, the most recent first.
+	RecentlyPopped(ctx context.Context, b Bucket, limit int) ([]PoppedName, error)
+	// UpdateFilters persists the filters of b and replaces the values that were not popped yet with the ones matching
+	// them, names already popped are never handed out again and the cursor keeps its position. The values of a seeded
+	// bucket follow its seed, which returns ErrWordsVersionMismatch when the word lists changed. Lazy buckets keep
+	// their position in LazyValues, so the new filters only apply to the names they have not walked past yet. It
+	// returns the change in the amount of remaining values, negative when the new filters are more restrictive.
+	UpdateFilters(ctx context.Context, b *Bucket) (int64, error)
+	// Save persists the name, description, retention, auto-archive policy and archival of the bucket. It returns
+	// ErrBucketNameTaken when another bucket has the name.
 	Save(ctx context.Context, b *Bucket) error
-	RemoveBucketsArchivedForMoreThan(ctx context.Context, t time.Duration) (int64, error)
+	// UpdateBucket persists the fields of b written by Save along with its labels like SetLabels when opts.Labels is
+	// set and its filters like UpdateFilters when opts.Filters is set, nothing is written when any of them fails. It
+	// returns the change in the amount of remaining values, which is zero unless the filters are updated.
+	UpdateBucket(ctx context.Context, b *Bucket, opts UpdateOptions) (int64, error)
+	// Archive marks the bucket archived at the given time without writing any other field, so that changes made since
+	// it was read are kept. It returns ErrBucketNotFound when there is no active bucket with the id.
+	Archive(ctx context.Context, id int32, at time.Time) error
+	// DeleteArchived removes the archived bucket with its values and labels. It returns ErrBucketNotFound when there
+	// is no archived bucket with the id, which also prevents removing a bucket recovered in the meantime.
+	DeleteArchived(ctx context.Context, id int32) error
+}
+
+// UpdateOptions tells UpdateBucket what to persist besides the fields written by Save.
+type UpdateOptions struct {
+	Labels  bool
+	Filters bool
+}
+
+// BucketFiller writes the values of the buckets created with a Fill in the background.
+type BucketFiller interface {
+	// Fill starts writing the values of b, the names matching its filters in the order given by its seed or in a
+	// random order when it has none.
+	Fill(b Bucket)
 }
 
 type ListOptions struct {
 	ArchivedOnly bool
+	// LabelSelector only lists the buckets that have every one of the labels with the same value.
+	LabelSelector map[string]string
+	// Search only lists the buckets whose name or description contain it, ignoring the case.
+	Search string
+	// SortBy is the field the buckets are sorted by, they are sorted by id when empty.
+	SortBy   BucketSortField
+	SortDesc bool
+	// Limit is the maximum amount of buckets listed, zero means no limit.
+	Limit  int
+	Offset int
+	// IncludeRemaining fills Bucket.RemainingValues in the same query that lists the buckets.
+	IncludeRemaining bool
+}
+
+type BucketSortField string
+
+const (
+	BucketSortName      BucketSortField = "name"
+	BucketSortCreatedAt BucketSortField = "created_at"
+	// BucketSortUpdatedAt sorts by the last time the bucket changed, buckets never updated use their creation time.
+	BucketSortUpdatedAt BucketSortField = "updated_at"
+)
+
+// Valid reports whether the field is one of the known sort fields, the empty field is valid and sorts by id.
+func (f BucketSortField) Valid() bool {
+	switch f {
+	case "", BucketSortName, BucketSortCreatedAt, BucketSortUpdatedAt:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,70 @@
+package serverplate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+func TestBucketRemovalTime(t *testing.T) {
+	archivedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	defaultRetention := 72 * time.Hour
+
+	cases := []struct {
+		Name      string
+		Bucket    serverplate.Bucket
+		Want      time.Time
+		Scheduled bool
+	}{
+		{
+			Name:   "active",
+			Bucket: serverplate.Bucket{},
+		},
+		{
+			Name:      "default retention",
+			Bucket:    serverplate.Bucket{ArchivedAt: &archivedAt},
+			Want:      archivedAt.Add(defaultRetention),
+			Scheduled: true,
+		},
+		{
+			Name:      "own retention",
+			Bucket:    serverplate.Bucket{ArchivedAt: &archivedAt, Retention: new(time.Hour)},
+			Want:      archivedAt.Add(time.Hour),
+			Scheduled: true,
+		},
+		{
+			Name:   "kept forever",
+			Bucket: serverplate.Bucket{ArchivedAt: &archivedAt, Retention: new(time.Duration(0))},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			got, ok := tt.Bucket.RemovalTime(defaultRetention)
+			if ok != tt.Scheduled || !got.Equal(tt.Want) {
+				t.Errorf("RemovalTime() = got %v %v, want %v %v", got, ok, tt.Want, tt.Scheduled)
+			}
+		})
+	}
+
+	if _, ok := (serverplate.Bucket{ArchivedAt: &archivedAt}).RemovalTime(0); ok {
+		t.Error("RemovalTime() = expected a zero default retention to keep the bucket forever")
+	}
+}
+
+func TestBucketExpired(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	if (serverplate.Bucket{}).Expired(now) {
+		t.Error("Expired() = expected a bucket without expiry to never expire")
+	}
+
+	if (serverplate.Bucket{ExpiresAt: new(now.Add(time.Minute))}).Expired(now) {
+		t.Error("Expired() = expected a bucket expiring later to not be expired")
+	}
+
+	if !(serverplate.Bucket{ExpiresAt: &now}).Expired(now) {
+		t.Error("Expired() = expected a bucket expiring now to be expired")
+	}
+}
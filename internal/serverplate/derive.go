@@ -0,0 +1,72 @@
+package serverplate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand/v2"
+)
+
+const (
+	// MaxDeriveKeyLength is the longest key a name can be derived from.
+	MaxDeriveKeyLength = 1024
+	// MaxDeriveSuffixLength is the longest collision suffix a derived name can have.
+	MaxDeriveSuffixLength = 16
+)
+
+// DeriveOptions describes how a name is derived from a key.
+type DeriveOptions struct {
+	// Key is the input the name is derived from, like an instance ID, a MAC address or a git SHA.
+	Key string
+	// Namespace keys the hash, the same key derives unrelated names in different namespaces.
+	Namespace string
+	// SuffixLength is the number of hex characters derived from the key appended to the name to tell apart the keys
+	// mapping to the same pair, zero leaves the name without a suffix.
+	SuffixLength int
+	Filters      RandomPairFilters
+	// WordsVersion optionally pins the word lists the name is derived from.
+	WordsVersion string
+}
+
+// ValidateDeriveOptions checks the key, suffix length and filters. The returned error is a ValidationErrors with
+// every invalid field found, the key and suffix length ones wrap ErrInvalidDeriveOptions.
+func ValidateDeriveOptions(opts DeriveOptions) error {
+	var errs ValidationErrors
+
+	if opts.Key == "" {
+		errs.add("key", ErrInvalidDeriveOptions, "key is required")
+	} else if len(opts.Key) > MaxDeriveKeyLength {
+		errs.add("key", ErrInvalidDeriveOptions, "key must be at most %d bytes long", MaxDeriveKeyLength)
+	}
+
+	if opts.SuffixLength < 0 || opts.SuffixLength > MaxDeriveSuffixLength {
+		errs.add("suffix_length", ErrInvalidDeriveOptions, "suffix length must be between 0 and %d", MaxDeriveSuffixLength)
+	}
+
+	errs.collect(ValidateFilters(opts.Filters))
+
+	return errs.err()
+}
+
+// DerivedName returns the name matching the filters the key maps to in the namespace, ErrNoMatchingPairs when no
+// pair matches. The same key, namespace, filters and word lists always give the same name.
+func (w WordLists) DerivedName(opts DeriveOptions) (string, error) {
+	mac := hmac.New(sha256.New, []byte(opts.Namespace))
+	mac.Write([]byte(opts.Key))
+	sum := mac.Sum(nil)
+
+	// the first half of the hash picks the pair and the second half, untouched by the pick, makes the suffix
+	r := seededRand{pcg: rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16]))}
+	p, err := w.pickPair(opts.Filters, r)
+	if err != nil {
+		return "", err
+	}
+
+	name := p.Adjective + "-" + p.Noun
+	if opts.SuffixLength > 0 {
+		name += "-" + hex.EncodeToString(sum[16:])[:opts.SuffixLength]
+	}
+
+	return name, nil
+}
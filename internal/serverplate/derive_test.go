@@ -0,0 +1,112 @@
+package serverplate_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// TestDerivedNameGolden pins the derived names, a change here renames every machine named from a key.
+func TestDerivedNameGolden(t *testing.T) {
+	cases := []struct {
+		Options serverplate.DeriveOptions
+		Want    string
+	}{
+		{
+			Options: serverplate.DeriveOptions{Key: "i-0abc"},
+			Want:    "calm-otter",
+		},
+		{
+			Options: serverplate.DeriveOptions{Key: "i-0abc", SuffixLength: 4},
+			Want:    "calm-otter-01c2",
+		},
+		{
+			Options: serverplate.DeriveOptions{Key: "i-0abc", Namespace: "prod"},
+			Want:    "eager-river",
+		},
+		{
+			Options: serverplate.DeriveOptions{Key: "i-0abd"},
+			Want:    "calm-ant",
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			got, err := seedWords.DerivedName(tt.Options)
+			if err != nil {
+				t.Fatalf("DerivedName() = unexpected error: %v", err)
+			}
+			if got != tt.Want {
+				t.Errorf("DerivedName() = options: %+v - got %q, want %q", tt.Options, got, tt.Want)
+			}
+		})
+	}
+}
+
+func TestDerivedNameFilters(t *testing.T) {
+	f := serverplate.RandomPairFilters{NounInitial: "o"}
+	for i := range 20 {
+		name, err := seedWords.DerivedName(serverplate.DeriveOptions{Key: fmt.Sprintf("host-%d", i), Filters: f})
+		if err != nil {
+			t.Fatalf("DerivedName() = unexpected error: %v", err)
+		}
+		if !strings.HasSuffix(name, "-otter") {
+			t.Errorf("DerivedName() = got %q, want a name matching the filters", name)
+		}
+	}
+
+	_, err := seedWords.DerivedName(serverplate.DeriveOptions{
+		Key:     "i-0abc",
+		Filters: serverplate.RandomPairFilters{Prefix: "zzz"},
+	})
+	if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+		t.Errorf("DerivedName() = unexpected error got %v want %v", err, serverplate.ErrNoMatchingPairs)
+	}
+}
+
+func TestValidateDeriveOptionsTable(t *testing.T) {
+	cases := []struct {
+		Input serverplate.DeriveOptions
+		Valid bool
+	}{
+		{
+			Input: serverplate.DeriveOptions{Key: "52:54:00:12:34:56"},
+			Valid: true,
+		},
+		{
+			Input: serverplate.DeriveOptions{Key: "i-0abc", SuffixLength: serverplate.MaxDeriveSuffixLength},
+			Valid: true,
+		},
+		{
+			Input: serverplate.DeriveOptions{},
+			Valid: false,
+		},
+		{
+			Input: serverplate.DeriveOptions{Key: strings.Repeat("a", serverplate.MaxDeriveKeyLength+1)},
+			Valid: false,
+		},
+		{
+			Input: serverplate.DeriveOptions{Key: "i-0abc", SuffixLength: -1},
+			Valid: false,
+		},
+		{
+			Input: serverplate.DeriveOptions{Key: "i-0abc", SuffixLength: serverplate.MaxDeriveSuffixLength + 1},
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			err := serverplate.ValidateDeriveOptions(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Errorf("ValidateDeriveOptions() = input: %+v - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidDeriveOptions) {
+				t.Errorf("ValidateDeriveOptions() = got %v, want it to wrap ErrInvalidDeriveOptions", err)
+			}
+		})
+	}
+}
@@ -1,11 +1,183 @@
 package serverplate
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	// ErrBucketNotFound is returned when a bucket cannot be found
 	ErrBucketNotFound = errors.New("bucket not found")
 
+	// ErrBucketExhausted is returned when popping from a bucket that has no remaining names
+	ErrBucketExhausted = errors.New("bucket has no remaining names")
+
+	// ErrBucketNameTaken is returned when another bucket already has the requested name
+	ErrBucketNameTaken = errors.New("bucket name is already taken")
+
+	// ErrInvalidBucketName is returned when a bucket name is not a lowercase RFC 1123 label
+	ErrInvalidBucketName = errors.New("invalid bucket name")
+
+	// ErrInvalidFilters is returned when the filters hold values that cannot be used to look for names
+	ErrInvalidFilters = errors.New("invalid filters")
+
+	// ErrInvalidLabels is returned when label keys or values are malformed
+	ErrInvalidLabels = errors.New("invalid labels")
+
+	// ErrInvalidRetention is returned when a retention period cannot be parsed or is negative
+	ErrInvalidRetention = errors.New("invalid retention")
+
+	// ErrInvalidExpiry is returned when a bucket expiry cannot be parsed
+	ErrInvalidExpiry = errors.New("invalid expiry")
+
+	// ErrInvalidDescription is returned when a bucket description is too long
+	ErrInvalidDescription = errors.New("invalid description")
+
+	// ErrBucketArchived is returned when changing or using the names of an archived bucket, which is read only
+	ErrBucketArchived = errors.New("bucket is archived")
+
+	// ErrBucketNotArchived is returned when removing a bucket that is not archived
+	ErrBucketNotArchived = errors.New("bucket is not archived")
+
+	// ErrBucketDeleteNotConfirmed is returned when removing a bucket without confirming its name
+	ErrBucketDeleteNotConfirmed = errors.New("bucket deletion not confirmed")
+
+	// ErrBucketFilling is returned when using the names of a bucket whose fill has not finished
+	ErrBucketFilling = errors.New("bucket is still filling")
+
+	// ErrBucketNotFilling is returned when writing the values of a fill to a bucket that is not filling anymore
+	ErrBucketNotFilling = errors.New("bucket is not filling")
+
+	// ErrJobNotFound is returned when there is no background job with the requested name
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrJobRunNotFound is returned when a job has never run
+	ErrJobRunNotFound = errors.New("job run not found")
+
+	// ErrLeaseNotFound is returned when there is no lease with the requested id
+	ErrLeaseNotFound = errors.New("lease not found")
+
+	// ErrLeaseExpired is returned when confirming a lease after its expiry
+	ErrLeaseExpired = errors.New("lease expired")
+
+	// ErrInvalidLeaseTTL is returned when a lease ttl cannot be parsed or is not positive
+	ErrInvalidLeaseTTL = errors.New("invalid lease ttl")
+
+	// ErrWordsVersionMismatch is returned when seeded names are requested for word lists other than the current ones
+	ErrWordsVersionMismatch = errors.New("word lists version mismatch")
+
+	// ErrInvalidDeriveOptions is returned when a name cannot be derived from the given key or suffix length
+	ErrInvalidDeriveOptions = errors.New("invalid derive options")
+
+	// ErrInvalidSamples is returned when a sampling run asks for too few or too many samples
+	ErrInvalidSamples = errors.New("invalid samples")
+
+	// ErrLazyTooSelective is returned when the filters of a lazy bucket match too few pairs to walk them lazily
+	ErrLazyTooSelective = errors.New("filters too selective for a lazy bucket")
+
 	// ErrNoMatchingPairs is returned when no pairs match the specified filters
 	ErrNoMatchingPairs = errors.New("no pairs match the specified filters")
 )
+
+// IsInvalidInput reports whether err was caused by a value rejected by the validation, as opposed to the state of a
+// bucket or a failure of the stores.
+func IsInvalidInput(err error) bool {
+	for _, target := range []error{
+		ErrInvalidBucketName,
+		ErrInvalidDescription,
+		ErrInvalidFilters,
+		ErrInvalidLabels,
+		ErrInvalidRetention,
+		ErrInvalidExpiry,
+		ErrInvalidLeaseTTL,
+		ErrLazyTooSelective,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldError is a value rejected by the validation along with the field it was read from.
+type FieldError struct {
+	// Field is the name of the field in the api requests, like "name" or "filters.length".
+	Field string
+	// Reason tells why the value was rejected, it is meant to be shown next to the field.
+	Reason string
+	// Err is the sentinel error of the kind of value rejected, like ErrInvalidFilters.
+	Err error
+}
+
+func newFieldError(field string, err error, format string, args ...any) *FieldError {
+	return &FieldError{Field: field, Reason: fmt.Sprintf(format, args...), Err: err}
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Reason)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors holds every field rejected while validating a request, so that they are all reported at once
+// instead of one at a time.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, fe := range e {
+		errs = append(errs, fe)
+	}
+	return errs
+}
+
+// add rejects the value of field, err is the sentinel error of the kind of value rejected.
+func (e *ValidationErrors) add(field string, err error, format string, args ...any) {
+	*e = append(*e, newFieldError(field, err, format, args...))
+}
+
+// collect appends the fields rejected in err, the error returned by one of the validations.
+func (e *ValidationErrors) collect(err error) {
+	*e = append(*e, FieldErrors(err)...)
+}
+
+// err returns the rejected fields as an error, nil when every field is valid.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// FieldErrors returns every field rejected in err, following the wrapped and joined errors. It returns nil when err
+// was not caused by the validation of a field.
+func FieldErrors(err error) []*FieldError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *FieldError:
+		return []*FieldError{e}
+	case interface{ Unwrap() []error }:
+		var fields []*FieldError
+		for _, err := range e.Unwrap() {
+			fields = append(fields, FieldErrors(err)...)
+		}
+		return fields
+	case interface{ Unwrap() error }:
+		return FieldErrors(e.Unwrap())
+	}
+
+	return nil
+}
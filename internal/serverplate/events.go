@@ -0,0 +1,110 @@
+package serverplate
+
+import (
+	"sync"
+	"time"
+)
+
+type BucketEventType string
+
+const (
+	BucketEventPopped    BucketEventType = "popped"
+	BucketEventReserved  BucketEventType = "reserved"
+	BucketEventReleased  BucketEventType = "released"
+	BucketEventArchived  BucketEventType = "archived"
+	BucketEventRecovered BucketEventType = "recovered"
+	// BucketEventRemaining is published when the remaining names of a bucket change without a name being handed out
+	// or returned, like when its filters change or its fill ends.
+	BucketEventRemaining BucketEventType = "remaining"
+)
+
+// BucketEvent is something that happened to a bucket. Name is only set for the events about a name.
+type BucketEvent struct {
+	Type     BucketEventType
+	BucketID int32
+	Name     string
+	At       time.Time
+}
+
+// eventBufferSize is the amount of events kept for a subscriber that is not reading them, the events published while
+// its buffer is full are dropped for it.
+const eventBufferSize = 64
+
+// EventBus hands the events published about a bucket to the subscribers of the bucket, within this process only.
+// Publishing never blocks, a subscriber that falls behind misses events.
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[int32]map[chan BucketEvent]struct{}
+	closed bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs: map[int32]map[chan BucketEvent]struct{}{},
+	}
+}
+
+// Publish sends the event to the subscribers of its bucket, its At is set to now when empty.
+func (e *EventBus) Publish(ev BucketEvent) {
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for ch := range e.subs[ev.BucketID] {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the events published about the bucket from now on, until cancel is called or the bus is closed,
+// which closes the channel.
+func (e *EventBus) Subscribe(bucketID int32) (events <-chan BucketEvent, cancel func()) {
+	ch := make(chan BucketEvent, eventBufferSize)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if e.subs[bucketID] == nil {
+		e.subs[bucketID] = map[chan BucketEvent]struct{}{}
+	}
+	e.subs[bucketID][ch] = struct{}{}
+
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if _, ok := e.subs[bucketID][ch]; !ok {
+			return
+		}
+
+		delete(e.subs[bucketID], ch)
+		if len(e.subs[bucketID]) == 0 {
+			delete(e.subs, bucketID)
+		}
+		close(ch)
+	}
+}
+
+// Close ends every subscription, so that the streams following them finish when the server shuts down.
+func (e *EventBus) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for bucketID, subs := range e.subs {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(e.subs, bucketID)
+	}
+	e.closed = true
+}
@@ -0,0 +1,52 @@
+package serverplate_test
+
+import (
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := serverplate.NewEventBus()
+
+	events, cancel := bus.Subscribe(1)
+	other, cancelOther := bus.Subscribe(2)
+	defer cancelOther()
+
+	bus.Publish(serverplate.BucketEvent{Type: serverplate.BucketEventPopped, BucketID: 1, Name: "brave-river"})
+
+	select {
+	case ev := <-events:
+		if ev.Type != serverplate.BucketEventPopped || ev.Name != "brave-river" || ev.At.IsZero() {
+			t.Errorf("Subscribe() = got %+v, want the popped event with its time", ev)
+		}
+	default:
+		t.Fatalf("Subscribe() = got no event, want the popped one")
+	}
+
+	select {
+	case ev := <-other:
+		t.Errorf("Subscribe() = got %+v for another bucket, want none", ev)
+	default:
+	}
+
+	// a subscriber that does not read misses events instead of blocking the publisher.
+	for range 1000 {
+		bus.Publish(serverplate.BucketEvent{Type: serverplate.BucketEventPopped, BucketID: 1})
+	}
+
+	cancel()
+	cancel()
+	for range events {
+	}
+
+	bus.Close()
+	if _, ok := <-other; ok {
+		t.Errorf("Close() = got an open subscription, want it closed")
+	}
+
+	closed, _ := bus.Subscribe(1)
+	if _, ok := <-closed; ok {
+		t.Errorf("Subscribe() = got an open subscription once closed, want it closed")
+	}
+}
@@ -3,6 +3,8 @@ package serverplate
 import (
 	"context"
 	"fmt"
+	"iter"
+	"math/rand/v2"
 )
 
 type LengthMode string
@@ -23,10 +25,23 @@ func NewGenerator(pairStore PairStore) *Generator {
 }
 
 func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (GenerateResult, error) {
-	filters := RandomPairFilters{}
-	if opts.LengthEnabled {
-		filters.Length = opts.LengthValue
-		filters.LengthMode = opts.LengthMode
+	filters := opts.Filters()
+
+	if opts.Seed != nil {
+		words, err := g.wordLists(ctx, opts.WordsVersion)
+		if err != nil {
+			return GenerateResult{}, err
+		}
+
+		p, err := words.SeededPair(filters, *opts.Seed)
+		if err != nil {
+			return GenerateResult{}, fmt.Errorf("could not generate a name pair: %w", err)
+		}
+
+		return GenerateResult{
+			Name:         fmt.Sprintf("%s-%s", p.Adjective, p.Noun),
+			WordsVersion: words.Version(),
+		}, nil
 	}
 
 	p, err := g.pairStore.OneRandom(ctx, filters)
@@ -39,12 +54,123 @@ func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (Generat
 	}, nil
 }
 
+// SeededNames returns every name matching the filters in the order given by seed, along with the version of the
+// word lists they were built from. When wordsVersion is not empty it must be the version of the current word lists,
+// otherwise ErrWordsVersionMismatch is returned.
+func (g *Generator) SeededNames(
+	ctx context.Context,
+	f RandomPairFilters,
+	seed int64,
+	wordsVersion string,
+) ([]string, string, error) {
+	words, err := g.wordLists(ctx, wordsVersion)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return words.SeededNames(f, seed), words.Version(), nil
+}
+
+// BucketNames returns the number of names matching the filters of the bucket and an iterator over them in the order
+// it hands them out, the order given by its seed or a random one when it has none. The names are built as they are
+// iterated so that a large bucket is never held in memory at once. It returns ErrWordsVersionMismatch when the bucket
+// is seeded from other word lists.
+func (g *Generator) BucketNames(ctx context.Context, b Bucket) (int, iter.Seq[string], error) {
+	seed := rand.Int64()
+	if b.Seed != nil {
+		seed = *b.Seed
+	}
+
+	words, err := g.wordLists(ctx, b.WordsVersion)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total, names := words.seededNames(b.Filters(), seed)
+	return total, names, nil
+}
+
+// Derive returns the name the key maps to, along with the version of the word lists it was derived from. When
+// opts.WordsVersion is not empty it must be the version of the current word lists, otherwise ErrWordsVersionMismatch
+// is returned.
+func (g *Generator) Derive(ctx context.Context, opts DeriveOptions) (GenerateResult, error) {
+	words, err := g.wordLists(ctx, opts.WordsVersion)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	name, err := words.DerivedName(opts)
+	if err != nil {
+		return GenerateResult{}, fmt.Errorf("could not derive a name: %w", err)
+	}
+
+	return GenerateResult{
+		Name:         name,
+		WordsVersion: words.Version(),
+	}, nil
+}
+
+// WordsVersion returns the version of the current word lists. When wordsVersion is not empty it must be that
+// version, otherwise ErrWordsVersionMismatch is returned.
+func (g *Generator) WordsVersion(ctx context.Context, wordsVersion string) (string, error) {
+	words, err := g.wordLists(ctx, wordsVersion)
+	if err != nil {
+		return "", err
+	}
+
+	return words.Version(), nil
+}
+
+func (g *Generator) wordLists(ctx context.Context, wordsVersion string) (WordLists, error) {
+	words, err := g.pairStore.Words(ctx)
+	if err != nil {
+		return WordLists{}, fmt.Errorf("could not load the word lists: %w", err)
+	}
+
+	if err := words.CheckVersion(wordsVersion); err != nil {
+		return WordLists{}, err
+	}
+
+	return words, nil
+}
+
 type GenerateResult struct {
 	Name string
+	// WordsVersion is the version of the word lists the name was picked from, only set for seeded and derived names.
+	WordsVersion string
 }
 
 type GenerateOptions struct {
-	LengthEnabled bool
-	LengthMode    LengthMode
-	LengthValue   int
+	LengthEnabled    bool
+	LengthMode       LengthMode
+	LengthValue      int
+	MinLength        int
+	AdjectiveInitial string
+	NounInitial      string
+	Alliterative     bool
+	Prefix           string
+	Suffix           string
+	ExcludedChars    string
+	// Seed picks the name deterministically when set, WordsVersion optionally pins the word lists it is picked from.
+	Seed         *int64
+	WordsVersion string
+}
+
+// Filters returns the RandomPairFilters described by the options.
+// If length filtering is disabled, the length and length mode are left empty.
+func (o GenerateOptions) Filters() RandomPairFilters {
+	f := RandomPairFilters{
+		MinLength:        o.MinLength,
+		AdjectiveInitial: o.AdjectiveInitial,
+		NounInitial:      o.NounInitial,
+		Alliterative:     o.Alliterative,
+		Prefix:           o.Prefix,
+		Suffix:           o.Suffix,
+		ExcludedChars:    o.ExcludedChars,
+	}
+	if o.LengthEnabled {
+		f.Length = o.LengthValue
+		f.LengthMode = o.LengthMode
+	}
+	return f
 }
@@ -0,0 +1,65 @@
+package serverplate
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a background task run periodically by the server.
+type Job struct {
+	Name string
+	// Schedule is the cron expression the job runs on.
+	Schedule string
+	// NextRunAt is nil when the scheduler is not running.
+	NextRunAt *time.Time
+	// LastRun is nil when the job never ran.
+	LastRun *JobRun
+}
+
+// JobRun is a single execution of a job.
+type JobRun struct {
+	ID        int64
+	Job       string
+	StartedAt time.Time
+	EndedAt   time.Time
+	// Error holds the message of the error the run failed with, empty when it succeeded.
+	Error string
+	// AffectedRows is the amount of records the run changed or removed.
+	AffectedRows int64
+}
+
+// Failed reports whether the run ended with an error.
+func (r JobRun) Failed() bool {
+	return r.Error != ""
+}
+
+// Duration returns how long the run took.
+func (r JobRun) Duration() time.Duration {
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
+// JobRunner lists the jobs of the server and triggers them outside their schedule.
+type JobRunner interface {
+	Jobs(ctx context.Context) ([]Job, error)
+	// RunNow starts the job in the background. It returns ErrJobNotFound when there is no job with the name.
+	RunNow(name string) error
+}
+
+type JobRunStore interface {
+	// Record persists a finished run, setting its id.
+	Record(ctx context.Context, run *JobRun) error
+	// Last returns the latest run of the job, ErrJobRunNotFound when it never ran.
+	Last(ctx context.Context, job string) (JobRun, error)
+	// Recent returns up to limit runs of every job, the most recent first.
+	Recent(ctx context.Context, limit int) ([]JobRun, error)
+}
+
+// JobLockStore holds leases on the jobs so that a single server instance runs each of them when several share the
+// same storage.
+type JobLockStore interface {
+	// Acquire takes the lock of the job for owner until ttl passes and reports whether it got it. The owner holding
+	// the lock extends it by acquiring it again, an expired lock is handed over to the first owner acquiring it.
+	Acquire(ctx context.Context, job, owner string, ttl time.Duration) (bool, error)
+	// Release frees the lock of the job if it is still held by owner.
+	Release(ctx context.Context, job, owner string) error
+}
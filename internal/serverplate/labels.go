@@ -0,0 +1,92 @@
+package serverplate
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// label keys and values follow the kubernetes syntax without the optional key prefix, keys are lowercase.
+// reference: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
+var (
+	labelKeyRegex   = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,61}[a-z0-9])?$`)
+	labelValueRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?)?$`)
+)
+
+// ValidateLabels checks the syntax of every label key and value, the returned error wraps ErrInvalidLabels.
+func ValidateLabels(labels map[string]string) error {
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		if !labelKeyRegex.MatchString(k) {
+			return newFieldError(
+				"labels",
+				ErrInvalidLabels,
+				"key %q must be lowercase alphanumeric, '-', '_' or '.' up to 63 characters",
+				k,
+			)
+		}
+
+		if !labelValueRegex.MatchString(labels[k]) {
+			return newFieldError(
+				"labels",
+				ErrInvalidLabels,
+				"value %q of key %q must be alphanumeric, '-', '_' or '.' up to 63 characters",
+				labels[k],
+				k,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ParseLabels parses comma separated key=value pairs like "team=payments,env=prod", which is the syntax used for
+// both the labels and the label selectors. The parsed labels are validated.
+func ParseLabels(s string) (map[string]string, error) {
+	labels := map[string]string{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, newFieldError("labels", ErrInvalidLabels, "%q must have the key=value form", pair)
+		}
+
+		k = strings.TrimSpace(k)
+		if _, ok := labels[k]; ok {
+			return nil, newFieldError("labels", ErrInvalidLabels, "key %q is repeated", k)
+		}
+		labels[k] = strings.TrimSpace(v)
+	}
+
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// FormatLabels returns the labels in the syntax accepted by ParseLabels, sorted by key.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// MatchLabels reports whether labels holds every label of the selector with the same value.
+func MatchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+
+	return true
+}
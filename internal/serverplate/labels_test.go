@@ -0,0 +1,120 @@
+package serverplate_test
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+func TestParseLabelsTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Want  map[string]string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Want:  map[string]string{},
+			Valid: true,
+		},
+		{
+			Input: "team=payments,env=prod",
+			Want:  map[string]string{"team": "payments", "env": "prod"},
+			Valid: true,
+		},
+		{
+			Input: " team = payments , env=prod, ",
+			Want:  map[string]string{"team": "payments", "env": "prod"},
+			Valid: true,
+		},
+		{
+			Input: "app.kubernetes_io-name=Web.Server_01",
+			Want:  map[string]string{"app.kubernetes_io-name": "Web.Server_01"},
+			Valid: true,
+		},
+		{
+			Input: "empty=",
+			Want:  map[string]string{"empty": ""},
+			Valid: true,
+		},
+		{
+			Input: "team",
+			Valid: false,
+		},
+		{
+			Input: "team=a,team=b",
+			Valid: false,
+		},
+		{
+			Input: "Team=payments",
+			Valid: false,
+		},
+		{
+			Input: "=payments",
+			Valid: false,
+		},
+		{
+			Input: "team=-payments",
+			Valid: false,
+		},
+		{
+			Input: "team=pay ments",
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			got, err := serverplate.ParseLabels(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Fatalf("ParseLabels() = input: %q - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidLabels) {
+				t.Errorf("ParseLabels() = got %v, want it to wrap ErrInvalidLabels", err)
+			}
+			if err == nil && !maps.Equal(got, tt.Want) {
+				t.Errorf("ParseLabels() = input: %q - got %v, want %v", tt.Input, got, tt.Want)
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod", "app": "web"}
+
+	got := serverplate.FormatLabels(labels)
+	if want := "app=web,env=prod,team=payments"; got != want {
+		t.Errorf("FormatLabels() = got %q, want %q", got, want)
+	}
+
+	parsed, err := serverplate.ParseLabels(got)
+	if err != nil || !maps.Equal(parsed, labels) {
+		t.Errorf("ParseLabels(FormatLabels()) = got %v, %v, want %v", parsed, err, labels)
+	}
+}
+
+func TestMatchLabelsTable(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod"}
+
+	cases := []struct {
+		Selector map[string]string
+		Match    bool
+	}{
+		{Selector: nil, Match: true},
+		{Selector: map[string]string{"env": "prod"}, Match: true},
+		{Selector: map[string]string{"env": "prod", "team": "payments"}, Match: true},
+		{Selector: map[string]string{"env": "staging"}, Match: false},
+		{Selector: map[string]string{"env": "prod", "owner": "alice"}, Match: false},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			if got := serverplate.MatchLabels(labels, tt.Selector); got != tt.Match {
+				t.Errorf("MatchLabels() = selector: %v - got %v, want %v", tt.Selector, got, tt.Match)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package serverplate
+
+import (
+	"math/bits"
+	"math/rand/v2"
+)
+
+// MaxLazyStride is the most pairs the filters of a lazy bucket can skip for every pair they match. A pop walks the
+// permutation until it reaches a matching pair, so sparser filters would make every pop walk a large part of it.
+const MaxLazyStride = 1000
+
+// checkLazyStride returns a FieldError for field when the filters counted by stats match fewer than one pair in
+// MaxLazyStride.
+func checkLazyStride(field string, stats Stats) error {
+	size := int64(stats.AdjectiveCount) * int64(stats.NounCount)
+	if size <= int64(stats.PairCount)*MaxLazyStride {
+		return nil
+	}
+
+	return newFieldError(
+		field,
+		ErrLazyTooSelective,
+		"the filters match %d of %d names, a lazy bucket needs at least one in %d",
+		stats.PairCount,
+		size,
+		MaxLazyStride,
+	)
+}
+
+// LazyValues walks the names of a lazy bucket. Every adjective-noun pair has an index, the index of the adjective
+// times the number of nouns plus the index of the noun in canonical order, and the bucket visits the indexes in the
+// order of a permutation keyed by its seed, skipping the pairs that do not match its filters. Nothing but the
+// position in the permutation has to be stored to know which names were handed out.
+type LazyValues struct {
+	words   WordLists
+	filters RandomPairFilters
+	perm    permutation
+}
+
+// LazyValues returns the lazy walk over the word lists for the filters and seed.
+func (w WordLists) LazyValues(f RandomPairFilters, seed int64) LazyValues {
+	return newLazyValues(w.canonical(), f, seed)
+}
+
+// newLazyValues returns the lazy walk over c, which must already be in canonical order.
+func newLazyValues(c WordLists, f RandomPairFilters, seed int64) LazyValues {
+	return LazyValues{
+		words:   c,
+		filters: f,
+		perm:    newPermutation(uint64(len(c.Adjectives))*uint64(len(c.Nouns)), seed),
+	}
+}
+
+// LazyWords holds the word lists lazy buckets walk, sorted and versioned once so that the stores can keep them
+// around instead of loading, sorting and hashing the lists on every pop.
+type LazyWords struct {
+	words   WordLists
+	version string
+}
+
+// NewLazyWords sorts the word lists and computes their version.
+func NewLazyWords(w WordLists) LazyWords {
+	c := w.canonical()
+	return LazyWords{words: c, version: c.Version()}
+}
+
+// Version returns the version of the word lists, see WordLists.Version.
+func (l LazyWords) Version() string {
+	return l.version
+}
+
+// CheckVersion returns ErrWordsVersionMismatch when version is not empty and is not the version of the word lists.
+func (l LazyWords) CheckVersion(version string) error {
+	return checkVersion(version, l.version)
+}
+
+// LazyValues returns the lazy walk over the word lists for the filters and seed.
+func (l LazyWords) LazyValues(f RandomPairFilters, seed int64) LazyValues {
+	return newLazyValues(l.words, f, seed)
+}
+
+// SeededNames returns every name matching the filters in the order given by seed, see WordLists.SeededNames.
+func (l LazyWords) SeededNames(f RandomPairFilters, seed int64) []string {
+	return l.words.SeededNames(f, seed)
+}
+
+// Size returns the number of positions of the walk, one for each pair whether it matches the filters or not.
+func (l LazyValues) Size() int64 {
+	return int64(l.perm.n)
+}
+
+// Next returns the first name matching the filters at or after position and the position following it. It returns
+// false when no name at or after position matches. The lazy buckets keep their filters within MaxLazyStride, so it
+// walks about that many positions at most on average.
+func (l LazyValues) Next(position int64) (string, int64, bool) {
+	for ; position < l.Size(); position++ {
+		if p, ok := l.pairAt(position); ok {
+			return p.Adjective + "-" + p.Noun, position + 1, true
+		}
+	}
+
+	return "", position, false
+}
+
+// Count returns the number of names matching the filters at or after position. Walking the permutation costs more
+// than matching the pairs in order, so it only walks the positions on the shorter side of position: the ones after
+// it, or the ones before it to take them out of the count of every matching name.
+func (l LazyValues) Count(position int64) int64 {
+	position = max(0, position)
+	if position >= l.Size()/2 {
+		var total int64
+		for ; position < l.Size(); position++ {
+			if _, ok := l.pairAt(position); ok {
+				total++
+			}
+		}
+		return total
+	}
+
+	var total int64
+	for _, a := range l.words.Adjectives {
+		for _, n := range l.words.Nouns {
+			if l.filters.Match(a, n) {
+				total++
+			}
+		}
+	}
+
+	for i := range position {
+		if _, ok := l.pairAt(i); ok {
+			total--
+		}
+	}
+
+	return total
+}
+
+// Match reports whether the name, as handed out by the walk, matches the filters.
+func (l LazyValues) Match(name string) bool {
+	return l.filters.MatchName(name)
+}
+
+func (l LazyValues) pairAt(position int64) (Pair, bool) {
+	i := l.perm.at(uint64(position))
+	nouns := uint64(len(l.words.Nouns))
+	p := Pair{Adjective: l.words.Adjectives[i/nouns], Noun: l.words.Nouns[i%nouns]}
+
+	return p, l.filters.Match(p.Adjective, p.Noun)
+}
+
+// lazyStream is the PCG stream the permutation keys are drawn from, changing it changes every lazy bucket.
+const lazyStream = 0x6c617a7976616c73
+
+const feistelRounds = 4
+
+// permutation is a bijection of [0, n) built from a balanced Feistel network over the smallest power of four not
+// below n. The indexes the network maps past n are sent through it again until they land in range, which keeps it a
+// bijection of [0, n) and takes fewer than four trips on average.
+type permutation struct {
+	n        uint64
+	halfBits uint
+	keys     [feistelRounds]uint64
+}
+
+func newPermutation(n uint64, seed int64) permutation {
+	p := permutation{n: n, halfBits: 1}
+	if n > 1 {
+		p.halfBits = uint(bits.Len64(n-1)+1) / 2
+	}
+
+	pcg := rand.NewPCG(uint64(seed), lazyStream)
+	for i := range p.keys {
+		p.keys[i] = pcg.Uint64()
+	}
+
+	return p
+}
+
+func (p permutation) at(i uint64) uint64 {
+	for {
+		i = p.encrypt(i)
+		if i < p.n {
+			return i
+		}
+	}
+}
+
+func (p permutation) encrypt(i uint64) uint64 {
+	mask := uint64(1)<<p.halfBits - 1
+	left, right := i>>p.halfBits, i&mask
+	for _, k := range p.keys {
+		left, right = right, left^(mix64(right^k)&mask)
+	}
+
+	return left<<p.halfBits | right
+}
+
+// mix64 is the finalizer of SplitMix64, every input bit flips about half the output bits.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,135 @@
+package serverplate_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// lazyNames walks l from position to the end.
+func lazyNames(l serverplate.LazyValues, position int64) []string {
+	var names []string
+	for {
+		name, next, ok := l.Next(position)
+		if !ok {
+			return names
+		}
+		names = append(names, name)
+		position = next
+	}
+}
+
+// TestLazyValuesGolden pins the lazy order, a change here reorders the names left in every lazy bucket.
+func TestLazyValuesGolden(t *testing.T) {
+	want := []string{
+		"brave-ant",
+		"eager-otter",
+		"eager-ant",
+		"brave-river",
+		"calm-river",
+		"calm-otter",
+		"calm-ant",
+		"eager-river",
+		"brave-otter",
+	}
+
+	l := seedWords.LazyValues(serverplate.RandomPairFilters{}, 42)
+	if got := lazyNames(l, 0); !slices.Equal(got, want) {
+		t.Errorf("Next() = got %q, want %q", got, want)
+	}
+	if l.Size() != 9 || l.Count(0) != 9 {
+		t.Errorf("Size() and Count() = got %d and %d, want 9 and 9", l.Size(), l.Count(0))
+	}
+}
+
+// TestLazyValuesEveryPairOnce checks the permutation is a bijection for sizes that are and are not powers of four.
+func TestLazyValuesEveryPairOnce(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {1, 2}, {2, 2}, {3, 3}, {4, 16}, {13, 11}, {40, 31}} {
+		var w serverplate.WordLists
+		for i := range size[0] {
+			w.Adjectives = append(w.Adjectives, fmt.Sprintf("a%02d", i))
+		}
+		for i := range size[1] {
+			w.Nouns = append(w.Nouns, fmt.Sprintf("n%02d", i))
+		}
+
+		for seed := range int64(5) {
+			got := lazyNames(w.LazyValues(serverplate.RandomPairFilters{}, seed), 0)
+			want := w.SeededNames(serverplate.RandomPairFilters{}, seed)
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("Next() = size %v seed %d got %q, want every pair once", size, seed, got)
+			}
+		}
+	}
+}
+
+func TestLazyValuesFilters(t *testing.T) {
+	f := serverplate.RandomPairFilters{ExcludedChars: "v"}
+	l := seedWords.LazyValues(f, 7)
+
+	all := lazyNames(l, 0)
+	for _, name := range all {
+		if !l.Match(name) {
+			t.Errorf("Next() = got %q, want only names matching the filters", name)
+		}
+	}
+	if int64(len(all)) != l.Count(0) || len(all) != len(seedWords.SeededNames(f, 7)) {
+		t.Errorf("Next() = got %d names, want the %d matching pairs", len(all), l.Count(0))
+	}
+
+	// the walk picks up where the previous name left it
+	_, next, _ := l.Next(0)
+	if got := lazyNames(l, next); !slices.Equal(got, all[1:]) || l.Count(next) != int64(len(all)-1) {
+		t.Errorf("Next() = from %d got %q and count %d, want %q", next, got, l.Count(next), all[1:])
+	}
+
+	if _, _, ok := seedWords.LazyValues(serverplate.RandomPairFilters{Prefix: "zzz"}, 7).Next(0); ok {
+		t.Error("Next() = expected no name when no pair matches")
+	}
+}
+
+func TestLazyValuesCount(t *testing.T) {
+	var w serverplate.WordLists
+	for i := range 13 {
+		w.Adjectives = append(w.Adjectives, fmt.Sprintf("a%02d", i))
+	}
+	for i := range 11 {
+		w.Nouns = append(w.Nouns, fmt.Sprintf("n%02d", i))
+	}
+
+	f := serverplate.RandomPairFilters{ExcludedChars: "3"}
+	l := w.LazyValues(f, 11)
+
+	// the count from each position must be the same whichever side of it is walked
+	for position := range l.Size() + 1 {
+		var want int64
+		for i := position; i < l.Size(); i++ {
+			if _, next, ok := l.Next(i); ok && next == i+1 {
+				want++
+			}
+		}
+		if got := l.Count(position); got != want {
+			t.Errorf("Count() = from %d got %d want %d", position, got, want)
+		}
+	}
+}
+
+func TestLazyWords(t *testing.T) {
+	lw := serverplate.NewLazyWords(seedWords)
+	if lw.Version() != seedWords.Version() {
+		t.Errorf("Version() = got %q want %q", lw.Version(), seedWords.Version())
+	}
+	if err := lw.CheckVersion("0123456789abcdef"); !errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+		t.Errorf("CheckVersion() = unexpected error got %v want %v", err, serverplate.ErrWordsVersionMismatch)
+	}
+
+	f := serverplate.RandomPairFilters{ExcludedChars: "v"}
+	if got, want := lazyNames(lw.LazyValues(f, 7), 0), lazyNames(seedWords.LazyValues(f, 7), 0); !slices.Equal(got, want) {
+		t.Errorf("LazyValues() = got %q, want the walk of the word lists %q", got, want)
+	}
+}
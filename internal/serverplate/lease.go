@@ -0,0 +1,45 @@
+package serverplate
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is a name reserved from a bucket that is handed out for good once confirmed. The name goes back to the
+// bucket when the lease expires without being confirmed.
+type Lease struct {
+	ID       string
+	BucketID int32
+	Name     string
+	// ConfirmedAt is nil while the lease is pending.
+	ConfirmedAt *time.Time
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// Confirmed reports whether the name of the lease was finalized.
+func (l Lease) Confirmed() bool {
+	return l.ConfirmedAt != nil
+}
+
+// Expired reports whether the lease can no longer be confirmed at now, confirmed leases never expire.
+func (l Lease) Expired(now time.Time) bool {
+	return !l.Confirmed() && !l.ExpiresAt.After(now)
+}
+
+type LeaseStore interface {
+	// Reserve pops the next name of the bucket and leases it until ttl passes. It returns ErrBucketExhausted when
+	// the bucket has no remaining names.
+	Reserve(ctx context.Context, b Bucket, ttl time.Duration) (Lease, error)
+	// Confirm finalizes the lease, confirming it again returns it unchanged. It returns ErrLeaseNotFound when there
+	// is no lease with the id and ErrLeaseExpired when it expired before being confirmed.
+	Confirm(ctx context.Context, id string) (Lease, error)
+	// ReturnExpired puts the names of the leases that expired without being confirmed back into their buckets and
+	// forgets those leases, the confirmed ones are kept. The names go after the ones a bucket holds, so a lazy bucket
+	// hands them out before computing new ones. The names of archived buckets and the ones that no longer match the
+	// filters of their bucket stay handed out. It returns the leases whose names were returned.
+	ReturnExpired(ctx context.Context) ([]Lease, error)
+	// CountPending returns the number of leases of the bucket that were not confirmed, the expired ones whose names
+	// were not returned yet included.
+	CountPending(ctx context.Context, bucketID int32) (int64, error)
+}
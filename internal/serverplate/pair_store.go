@@ -2,14 +2,84 @@ package serverplate
 
 import (
 	"context"
+	"strings"
+	"unicode/utf8"
 )
 
 type PairStore interface {
 	OneRandom(context.Context, RandomPairFilters) (Pair, error)
 	Stats(context.Context, RandomPairFilters) (Stats, error)
+	// Words returns every adjective and noun, in no particular order.
+	Words(context.Context) (WordLists, error)
 }
 
+// RandomPairFilters constrains the adjective-noun pairs that can be picked. Zero values disable each filter.
 type RandomPairFilters struct {
 	Length     int
 	LengthMode LengthMode
+	// MinLength is the minimum length of the name, combined with Length in LengthModeUpto it builds a range.
+	MinLength int
+	// AdjectiveInitial and NounInitial are the letters the adjective and the noun must start with.
+	AdjectiveInitial string
+	NounInitial      string
+	// Alliterative only allows pairs whose adjective and noun start with the same letter.
+	Alliterative bool
+	// Prefix and Suffix are fixed strings the whole name must start or end with.
+	Prefix string
+	Suffix string
+	// ExcludedChars holds characters that must not appear in the name, useful to avoid confusing ones like l or 1.
+	ExcludedChars string
+}
+
+// MatchName reports whether a name handed out by a bucket, an adjective and a noun joined by a dash, satisfies the
+// filters.
+func (f RandomPairFilters) MatchName(name string) bool {
+	adjective, noun, ok := strings.Cut(name, "-")
+	return ok && f.Match(adjective, noun)
+}
+
+// Match reports whether the name built from adjective and noun satisfies the filters. Stores that cannot express
+// the filters in their query language use it, it is also the reference the SQL implementations must agree with.
+func (f RandomPairFilters) Match(adjective, noun string) bool {
+	name := adjective + "-" + noun
+	length := utf8.RuneCountInString(name)
+
+	if f.Length > 0 {
+		switch f.LengthMode {
+		case LengthModeExactly:
+			if length != f.Length {
+				return false
+			}
+		case LengthModeUpto:
+			if length > f.Length {
+				return false
+			}
+		}
+	}
+
+	if f.MinLength > 0 && length < f.MinLength {
+		return false
+	}
+
+	if f.AdjectiveInitial != "" && !strings.HasPrefix(adjective, f.AdjectiveInitial) {
+		return false
+	}
+
+	if f.NounInitial != "" && !strings.HasPrefix(noun, f.NounInitial) {
+		return false
+	}
+
+	if f.Alliterative && (adjective == "" || noun == "" || adjective[0] != noun[0]) {
+		return false
+	}
+
+	if !strings.HasPrefix(name, f.Prefix) || !strings.HasSuffix(name, f.Suffix) {
+		return false
+	}
+
+	if f.ExcludedChars != "" && strings.ContainsAny(name, f.ExcludedChars) {
+		return false
+	}
+
+	return true
 }
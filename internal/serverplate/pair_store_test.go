@@ -0,0 +1,99 @@
+package serverplate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+func TestRandomPairFiltersMatchTable(t *testing.T) {
+	cases := []struct {
+		Filters   serverplate.RandomPairFilters
+		Adjective string
+		Noun      string
+		Match     bool
+	}{
+		{
+			Filters:   serverplate.RandomPairFilters{},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     true,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Length: 11, LengthMode: serverplate.LengthModeExactly},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     true,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Length: 10, LengthMode: serverplate.LengthModeUpto},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{MinLength: 12},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{AdjectiveInitial: "b", NounInitial: "o"},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     true,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{NounInitial: "b"},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Alliterative: true},
+			Adjective: "brave",
+			Noun:      "badger",
+			Match:     true,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Alliterative: true},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Prefix: "brave-o", Suffix: "ter"},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     true,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{Suffix: "brave"},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{ExcludedChars: "l1o0"},
+			Adjective: "brave",
+			Noun:      "otter",
+			Match:     false,
+		},
+		{
+			Filters:   serverplate.RandomPairFilters{ExcludedChars: "l1o0"},
+			Adjective: "brave",
+			Noun:      "ant",
+			Match:     true,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			ok := tt.Filters.Match(tt.Adjective, tt.Noun)
+			if ok != tt.Match {
+				t.Errorf("Match() = %s-%s with %+v - got %v, want %v", tt.Adjective, tt.Noun, tt.Filters, ok, tt.Match)
+			}
+		})
+	}
+}
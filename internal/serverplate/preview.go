@@ -0,0 +1,91 @@
+package serverplate
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"unicode/utf8"
+)
+
+// MaxPreviewSamples is the largest number of sample names a preview can return.
+const MaxPreviewSamples = 100
+
+// BucketPreview describes the names a bucket created with some filters would hold, without creating it.
+type BucketPreview struct {
+	// PairCount is the number of names matching the filters, the bucket would be empty when it is 0.
+	PairCount int
+	// Samples holds names matching the filters picked at random, fewer than asked when not enough names match.
+	Samples []string
+	// Lengths holds how many of the matching names have each length, sorted by length.
+	Lengths []LengthCount
+}
+
+type LengthCount struct {
+	Length int
+	Count  int
+}
+
+// Preview reports the names matching the filters, along with samples of them picked at random. Unlike Sample, no
+// matching names is not an error, the preview reports it with a PairCount of 0.
+func (g *Generator) Preview(ctx context.Context, f RandomPairFilters, samples int) (BucketPreview, error) {
+	if samples < 0 || samples > MaxPreviewSamples {
+		return BucketPreview{}, newFieldError(
+			"samples", ErrInvalidSamples, "samples must be between 0 and %d", MaxPreviewSamples,
+		)
+	}
+
+	words, err := g.pairStore.Words(ctx)
+	if err != nil {
+		return BucketPreview{}, fmt.Errorf("could not load the word lists: %w", err)
+	}
+
+	// the samples are picked in a single walk over the matching names, keeping each one with the same odds.
+	p := BucketPreview{Samples: []string{}, Lengths: []LengthCount{}}
+	lengths := map[int]int{}
+	words.eachPair(f, func(pair Pair) bool {
+		name := pair.Adjective + "-" + pair.Noun
+		lengths[utf8.RuneCountInString(name)]++
+		p.PairCount++
+
+		if len(p.Samples) < samples {
+			p.Samples = append(p.Samples, name)
+		} else if k := rand.IntN(p.PairCount); k < samples {
+			p.Samples[k] = name
+		}
+		return true
+	})
+
+	rand.Shuffle(len(p.Samples), func(i, j int) {
+		p.Samples[i], p.Samples[j] = p.Samples[j], p.Samples[i]
+	})
+
+	for length, count := range lengths {
+		p.Lengths = append(p.Lengths, LengthCount{Length: length, Count: count})
+	}
+	slices.SortFunc(p.Lengths, func(a, b LengthCount) int {
+		return a.Length - b.Length
+	})
+
+	return p, nil
+}
+
+// PairCount returns the number of names matching the filters.
+func (g *Generator) PairCount(ctx context.Context, f RandomPairFilters) (int, error) {
+	stats, err := g.PairStats(ctx, f)
+	if err != nil {
+		return 0, err
+	}
+
+	return stats.PairCount, nil
+}
+
+// PairStats returns the number of names matching the filters along with the size of the word lists.
+func (g *Generator) PairStats(ctx context.Context, f RandomPairFilters) (Stats, error) {
+	stats, err := g.pairStore.Stats(ctx, f)
+	if err != nil {
+		return Stats{}, fmt.Errorf("could not count the matching pairs: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package serverplate_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+func TestPreview(t *testing.T) {
+	ctx := context.Background()
+	g := serverplate.NewGenerator(&cyclePairStore{words: seedWords})
+	f := serverplate.RandomPairFilters{NounInitial: "o"}
+
+	p, err := g.Preview(ctx, f, 2)
+	if err != nil {
+		t.Fatalf("Preview() = unexpected error: %v", err)
+	}
+
+	if p.PairCount != 3 {
+		t.Errorf("Preview() = got %d pairs, want 3", p.PairCount)
+	}
+
+	// calm-otter has 10 characters, brave-otter and eager-otter 11.
+	want := []serverplate.LengthCount{{Length: 10, Count: 1}, {Length: 11, Count: 2}}
+	if !slices.Equal(p.Lengths, want) {
+		t.Errorf("Preview() = got lengths %v, want %v", p.Lengths, want)
+	}
+
+	if len(p.Samples) != 2 || p.Samples[0] == p.Samples[1] {
+		t.Errorf("Preview() = got samples %q, want 2 distinct names", p.Samples)
+	}
+	for _, name := range p.Samples {
+		if !strings.HasSuffix(name, "-otter") {
+			t.Errorf("Preview() = got sample %q, want only names matching the filters", name)
+		}
+	}
+
+	// asking for more samples than there are names returns every name.
+	p, err = g.Preview(ctx, f, 10)
+	if err != nil {
+		t.Fatalf("Preview() = unexpected error: %v", err)
+	}
+	slices.Sort(p.Samples)
+	if want := []string{"brave-otter", "calm-otter", "eager-otter"}; !slices.Equal(p.Samples, want) {
+		t.Errorf("Preview() = got samples %q, want %q", p.Samples, want)
+	}
+}
+
+func TestPreviewNoMatches(t *testing.T) {
+	ctx := context.Background()
+	g := serverplate.NewGenerator(&cyclePairStore{words: seedWords})
+
+	p, err := g.Preview(ctx, serverplate.RandomPairFilters{Prefix: "zz"}, 10)
+	if err != nil {
+		t.Fatalf("Preview() = unexpected error: %v", err)
+	}
+	if p.PairCount != 0 || len(p.Samples) != 0 || len(p.Lengths) != 0 {
+		t.Errorf("Preview() = got %+v, want an empty preview", p)
+	}
+
+	for _, samples := range []int{-1, serverplate.MaxPreviewSamples + 1} {
+		_, err := g.Preview(ctx, serverplate.RandomPairFilters{}, samples)
+		if !errors.Is(err, serverplate.ErrInvalidSamples) {
+			t.Errorf("Preview(%d) = got %v, want ErrInvalidSamples", samples, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package serverplate
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// MaxSamples is the largest number of names a sampling run can pick.
+const MaxSamples = 10000
+
+// SamplingStats reports how the names picked at random are distributed, to spot a biased PairStore.OneRandom.
+type SamplingStats struct {
+	Samples int
+	// PairCount is the number of pairs matching the filters and DistinctPairs the number of them picked at least once.
+	PairCount     int
+	DistinctPairs int
+	Adjectives    WordDistribution
+	Nouns         WordDistribution
+}
+
+// WordDistribution compares how often each word was picked with how often a uniform pick would pick it.
+type WordDistribution struct {
+	// Words is sorted by descending count, the words that cannot be part of a matching pair are left out.
+	Words []WordFrequency
+	// ChiSquare is Pearson's chi-squared statistic of the counts, a uniform pick keeps it close to DegreesOfFreedom.
+	ChiSquare        float64
+	DegreesOfFreedom int
+}
+
+type WordFrequency struct {
+	Word     string
+	Count    int
+	Expected float64
+}
+
+// Sample picks samples names matching the filters the way Generate does and reports their distribution.
+func (g *Generator) Sample(ctx context.Context, f RandomPairFilters, samples int) (SamplingStats, error) {
+	if samples < 1 || samples > MaxSamples {
+		return SamplingStats{}, newFieldError("samples", ErrInvalidSamples, "samples must be between 1 and %d", MaxSamples)
+	}
+
+	words, err := g.pairStore.Words(ctx)
+	if err != nil {
+		return SamplingStats{}, fmt.Errorf("could not load the word lists: %w", err)
+	}
+
+	// the share of the matching pairs each word is part of gives its expected count
+	adjectivePairs := map[string]int{}
+	nounPairs := map[string]int{}
+	var total int
+	words.eachPair(f, func(p Pair) bool {
+		adjectivePairs[p.Adjective]++
+		nounPairs[p.Noun]++
+		total++
+		return true
+	})
+
+	if total == 0 {
+		return SamplingStats{}, ErrNoMatchingPairs
+	}
+
+	adjectiveCounts := map[string]int{}
+	nounCounts := map[string]int{}
+	picked := map[Pair]struct{}{}
+	for range samples {
+		p, err := g.pairStore.OneRandom(ctx, f)
+		if err != nil {
+			return SamplingStats{}, fmt.Errorf("could not pick a name pair: %w", err)
+		}
+
+		adjectiveCounts[p.Adjective]++
+		nounCounts[p.Noun]++
+		picked[p] = struct{}{}
+	}
+
+	return SamplingStats{
+		Samples:       samples,
+		PairCount:     total,
+		DistinctPairs: len(picked),
+		Adjectives:    distribution(adjectiveCounts, adjectivePairs, total, samples),
+		Nouns:         distribution(nounCounts, nounPairs, total, samples),
+	}, nil
+}
+
+func distribution(counts, pairs map[string]int, total, samples int) WordDistribution {
+	var d WordDistribution
+	for word, n := range pairs {
+		wf := WordFrequency{
+			Word:     word,
+			Count:    counts[word],
+			Expected: float64(samples) * float64(n) / float64(total),
+		}
+		diff := float64(wf.Count) - wf.Expected
+		d.ChiSquare += diff * diff / wf.Expected
+		d.Words = append(d.Words, wf)
+	}
+
+	d.DegreesOfFreedom = len(d.Words) - 1
+	slices.SortFunc(d.Words, func(a, b WordFrequency) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Word, b.Word))
+	})
+
+	return d
+}
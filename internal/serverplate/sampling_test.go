@@ -0,0 +1,88 @@
+package serverplate_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// cyclePairStore picks the pairs in a fixed cycle, which makes the counts of a sampling run predictable.
+type cyclePairStore struct {
+	serverplate.PairStore
+	words serverplate.WordLists
+	pairs []serverplate.Pair
+	next  int
+}
+
+func (s *cyclePairStore) OneRandom(context.Context, serverplate.RandomPairFilters) (serverplate.Pair, error) {
+	p := s.pairs[s.next%len(s.pairs)]
+	s.next++
+	return p, nil
+}
+
+func (s *cyclePairStore) Words(context.Context) (serverplate.WordLists, error) {
+	return s.words, nil
+}
+
+func TestSample(t *testing.T) {
+	ctx := context.Background()
+	store := &cyclePairStore{
+		words: seedWords,
+		pairs: []serverplate.Pair{
+			{Adjective: "brave", Noun: "otter"},
+			{Adjective: "brave", Noun: "otter"},
+			{Adjective: "calm", Noun: "otter"},
+		},
+	}
+	g := serverplate.NewGenerator(store)
+
+	stats, err := g.Sample(ctx, serverplate.RandomPairFilters{NounInitial: "o"}, 30)
+	if err != nil {
+		t.Fatalf("Sample() = unexpected error: %v", err)
+	}
+
+	if stats.Samples != 30 || stats.PairCount != 3 || stats.DistinctPairs != 2 {
+		t.Errorf("Sample() = got %d samples, %d pairs and %d distinct picks, want 30, 3 and 2",
+			stats.Samples,
+			stats.PairCount,
+			stats.DistinctPairs,
+		)
+	}
+
+	// each adjective is part of one of the three matching pairs, brave comes up twice as often as expected
+	want := []serverplate.WordFrequency{
+		{Word: "brave", Count: 20, Expected: 10},
+		{Word: "calm", Count: 10, Expected: 10},
+		{Word: "eager", Count: 0, Expected: 10},
+	}
+	if got := stats.Adjectives.Words; !slices.Equal(got, want) {
+		t.Errorf("Sample() = got adjectives %+v, want %+v", got, want)
+	}
+	if got := stats.Adjectives.ChiSquare; math.Abs(got-20) > 1e-9 || stats.Adjectives.DegreesOfFreedom != 2 {
+		t.Errorf("Sample() = got chi-squared %v with %d degrees of freedom, want 20 with 2",
+			got,
+			stats.Adjectives.DegreesOfFreedom,
+		)
+	}
+
+	// otter is the only noun of the matching pairs
+	if got := stats.Nouns; len(got.Words) != 1 || got.Words[0].Count != 30 || got.DegreesOfFreedom != 0 {
+		t.Errorf("Sample() = got nouns %+v, want otter alone", got)
+	}
+
+	for _, samples := range []int{0, serverplate.MaxSamples + 1} {
+		_, err := g.Sample(ctx, serverplate.RandomPairFilters{}, samples)
+		if !errors.Is(err, serverplate.ErrInvalidSamples) {
+			t.Errorf("Sample() = samples %d unexpected error got %v want %v", samples, err, serverplate.ErrInvalidSamples)
+		}
+	}
+
+	_, err = g.Sample(ctx, serverplate.RandomPairFilters{Prefix: "zzz"}, 10)
+	if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+		t.Errorf("Sample() = unexpected error got %v want %v", err, serverplate.ErrNoMatchingPairs)
+	}
+}
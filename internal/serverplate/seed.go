@@ -0,0 +1,168 @@
+package serverplate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// WordLists holds the adjectives and nouns names are built from.
+type WordLists struct {
+	Adjectives []string
+	Nouns      []string
+}
+
+// canonical returns copies of the lists sorted byte by byte without duplicates. Seeded generation starts from this
+// order so that it does not depend on the order, or the collation, each storage backend returns the words in.
+func (w WordLists) canonical() WordLists {
+	sorted := func(words []string) []string {
+		words = slices.Clone(words)
+		slices.Sort(words)
+		return slices.Compact(words)
+	}
+
+	return WordLists{
+		Adjectives: sorted(w.Adjectives),
+		Nouns:      sorted(w.Nouns),
+	}
+}
+
+// Version identifies the content of the word lists, seeded names are only reproducible with the same version.
+func (w WordLists) Version() string {
+	c := w.canonical()
+
+	h := sha256.New()
+	h.Write([]byte("adjectives\n" + strings.Join(c.Adjectives, "\n")))
+	h.Write([]byte("\nnouns\n" + strings.Join(c.Nouns, "\n")))
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// CheckVersion returns ErrWordsVersionMismatch when version is not empty and is not the version of the word lists.
+func (w WordLists) CheckVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+
+	return checkVersion(version, w.Version())
+}
+
+func checkVersion(version, current string) error {
+	if version != "" && version != current {
+		return fmt.Errorf("%w: requested %q, the current word lists are %q", ErrWordsVersionMismatch, version, current)
+	}
+
+	return nil
+}
+
+// eachPair calls fn for every pair matching the filters in canonical order, iteration stops when fn returns false.
+func (w WordLists) eachPair(f RandomPairFilters, fn func(Pair) bool) {
+	c := w.canonical()
+	for _, a := range c.Adjectives {
+		for _, n := range c.Nouns {
+			if f.Match(a, n) && !fn(Pair{Adjective: a, Noun: n}) {
+				return
+			}
+		}
+	}
+}
+
+// SeededNames returns every name matching the filters, shuffled in the order given by seed. The same seed, filters
+// and word lists always give the same order.
+func (w WordLists) SeededNames(f RandomPairFilters, seed int64) []string {
+	total, seq := w.seededNames(f, seed)
+	if total == 0 {
+		return nil
+	}
+
+	return slices.AppendSeq(make([]string, 0, total), seq)
+}
+
+// seededNames returns the number of names matching the filters and an iterator over them in the order of
+// SeededNames. Only the indexes of the pairs are shuffled up front, each name is built as the iteration reaches it.
+func (w WordLists) seededNames(f RandomPairFilters, seed int64) (int, iter.Seq[string]) {
+	c := w.canonical()
+	nouns := len(c.Nouns)
+
+	var pairs []int
+	for i, a := range c.Adjectives {
+		for j, n := range c.Nouns {
+			if f.Match(a, n) {
+				pairs = append(pairs, i*nouns+j)
+			}
+		}
+	}
+
+	r := newSeededRand(seed)
+	for i := len(pairs) - 1; i > 0; i-- {
+		j := r.uintN(uint64(i + 1))
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+
+	return len(pairs), func(yield func(string) bool) {
+		for _, k := range pairs {
+			if !yield(c.Adjectives[k/nouns] + "-" + c.Nouns[k%nouns]) {
+				return
+			}
+		}
+	}
+}
+
+// SeededPair returns the pair matching the filters picked by seed, ErrNoMatchingPairs when no pair matches.
+func (w WordLists) SeededPair(f RandomPairFilters, seed int64) (Pair, error) {
+	return w.pickPair(f, newSeededRand(seed))
+}
+
+// pickPair returns the matching pair at the index drawn from r, ErrNoMatchingPairs when no pair matches.
+func (w WordLists) pickPair(f RandomPairFilters, r seededRand) (Pair, error) {
+	var total uint64
+	w.eachPair(f, func(Pair) bool {
+		total++
+		return true
+	})
+
+	if total == 0 {
+		return Pair{}, ErrNoMatchingPairs
+	}
+
+	k := r.uintN(total)
+
+	var pair Pair
+	w.eachPair(f, func(p Pair) bool {
+		if k == 0 {
+			pair = p
+			return false
+		}
+		k--
+		return true
+	})
+
+	return pair, nil
+}
+
+// seededStream is the PCG stream of the seeded generation, changing it changes every seeded name.
+const seededStream = 0x7365727665727061
+
+// seededRand derives bounded values from the PCG output itself instead of going through rand.Rand, whose
+// algorithms are not guaranteed to stay the same between Go releases.
+type seededRand struct {
+	pcg *rand.PCG
+}
+
+func newSeededRand(seed int64) seededRand {
+	return seededRand{pcg: rand.NewPCG(uint64(seed), seededStream)}
+}
+
+// uintN returns a uniform value in [0, n), rejecting the draws that would bias the modulo.
+func (r seededRand) uintN(n uint64) uint64 {
+	threshold := -n % n
+	for {
+		if x := r.pcg.Uint64(); x >= threshold {
+			return x % n
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package serverplate_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+var seedWords = serverplate.WordLists{
+	Adjectives: []string{"calm", "brave", "eager"},
+	Nouns:      []string{"river", "otter", "ant"},
+}
+
+// TestSeededNamesGolden pins the seeded order, a change here breaks the buckets rebuilt from a seed.
+func TestSeededNamesGolden(t *testing.T) {
+	want := []string{
+		"brave-ant",
+		"calm-river",
+		"calm-otter",
+		"brave-river",
+		"calm-ant",
+		"eager-river",
+		"eager-ant",
+		"eager-otter",
+		"brave-otter",
+	}
+
+	if got := seedWords.SeededNames(serverplate.RandomPairFilters{}, 42); !slices.Equal(got, want) {
+		t.Errorf("SeededNames() = got %q, want %q", got, want)
+	}
+
+	p, err := seedWords.SeededPair(serverplate.RandomPairFilters{}, 42)
+	if err != nil {
+		t.Fatalf("SeededPair() = unexpected error: %v", err)
+	}
+	if p != (serverplate.Pair{Adjective: "brave", Noun: "otter"}) {
+		t.Errorf("SeededPair() = got %+v, want brave-otter", p)
+	}
+
+	if got := seedWords.Version(); got != "32de67f06a46fe4f" {
+		t.Errorf("Version() = got %q, want %q", got, "32de67f06a46fe4f")
+	}
+}
+
+func TestSeededNamesIgnoresWordOrder(t *testing.T) {
+	shuffled := serverplate.WordLists{
+		Adjectives: []string{"eager", "calm", "brave", "calm"},
+		Nouns:      []string{"ant", "river", "otter"},
+	}
+
+	if shuffled.Version() != seedWords.Version() {
+		t.Errorf("Version() = got %q, want %q for the same words", shuffled.Version(), seedWords.Version())
+	}
+
+	f := serverplate.RandomPairFilters{ExcludedChars: "v"}
+	for seed := range int64(20) {
+		got := shuffled.SeededNames(f, seed)
+		if want := seedWords.SeededNames(f, seed); !slices.Equal(got, want) {
+			t.Errorf("SeededNames() = seed %d got %q, want %q", seed, got, want)
+		}
+	}
+}
+
+func TestSeededNamesSeeds(t *testing.T) {
+	f := serverplate.RandomPairFilters{}
+	if slices.Equal(seedWords.SeededNames(f, 1), seedWords.SeededNames(f, 2)) {
+		t.Error("SeededNames() = expected different seeds to give different orders")
+	}
+
+	other := serverplate.WordLists{Adjectives: seedWords.Adjectives, Nouns: []string{"ant", "otter"}}
+	if other.Version() == seedWords.Version() {
+		t.Error("Version() = expected different word lists to have different versions")
+	}
+
+	_, err := seedWords.SeededPair(serverplate.RandomPairFilters{Prefix: "zzz"}, 42)
+	if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+		t.Errorf("SeededPair() = unexpected error got %v want %v", err, serverplate.ErrNoMatchingPairs)
+	}
+}
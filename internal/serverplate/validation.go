@@ -1,6 +1,11 @@
 package serverplate
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 // generated names must be dns subdomain compliant just like kubernetes resources, with the added constraint of them being lowercase
 // validates that all characters are lowercase, alphanumberic and the name must start and end with alphanumeric characters.
@@ -13,6 +18,201 @@ func ValidateName(name string) bool {
 	return nameRegex.MatchString(name)
 }
 
+// ValidateBucketName checks that the name can be given to a bucket, the returned error wraps ErrInvalidBucketName.
+// Names made only of digits are rejected because the api looks them up as bucket ids.
+func ValidateBucketName(name string) error {
+	if !ValidateName(name) {
+		return newFieldError(
+			"name",
+			ErrInvalidBucketName,
+			"%q must be lowercase alphanumeric or '-' up to 63 characters, starting and ending with an alphanumeric",
+			name,
+		)
+	}
+
+	if strings.Trim(name, "0123456789") == "" {
+		return newFieldError("name", ErrInvalidBucketName, "%q must not be made only of digits", name)
+	}
+
+	return nil
+}
+
+// MaxBucketDescriptionLength is the longest description a bucket can have, in characters.
+const MaxBucketDescriptionLength = 2048
+
+// ValidateBucketDescription checks that the description fits a bucket, the returned error wraps
+// ErrInvalidDescription.
+func ValidateBucketDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxBucketDescriptionLength {
+		return newFieldError(
+			"description",
+			ErrInvalidDescription,
+			"description must not exceed %d characters",
+			MaxBucketDescriptionLength,
+		)
+	}
+
+	return nil
+}
+
+// ParseRetention parses a bucket retention period written as a duration like "72h", "0" keeps the bucket forever
+// and an empty string returns nil to use the default retention. The returned error wraps ErrInvalidRetention.
+func ParseRetention(s string) (*time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, newFieldError(
+			"retention",
+			ErrInvalidRetention,
+			"%q must be a duration like 72h or 0 to keep the bucket forever",
+			s,
+		)
+	}
+
+	if d < 0 {
+		return nil, newFieldError("retention", ErrInvalidRetention, "%q must not be negative", s)
+	}
+
+	return new(d.Round(time.Second)), nil
+}
+
+// expiryLocalLayout is the layout of the datetime-local html inputs, the times without offset are taken as UTC.
+const expiryLocalLayout = "2006-01-02T15:04"
+
+// ParseExpiry parses a bucket expiry written as an RFC 3339 time or as a time without offset like
+// "2026-10-20T18:00", which is taken as UTC. An empty string returns nil for buckets that never expire. The returned
+// error wraps ErrInvalidExpiry.
+func ParseExpiry(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse(expiryLocalLayout, s)
+	}
+	if err != nil {
+		return nil, newFieldError(
+			"expires_at",
+			ErrInvalidExpiry,
+			"%q must be a time like 2026-10-20T18:00:00Z or empty to never expire",
+			s,
+		)
+	}
+
+	return new(t.UTC()), nil
+}
+
+// ParseLeaseTTL parses how long a reserved name waits to be confirmed, written as a duration like "10m". An empty
+// string returns defaultTTL. The ttl must be positive and not longer than maxTTL, the returned error wraps
+// ErrInvalidLeaseTTL.
+func ParseLeaseTTL(s string, defaultTTL, maxTTL time.Duration) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return defaultTTL, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, newFieldError("ttl", ErrInvalidLeaseTTL, "%q must be a duration like 10m", s)
+	}
+
+	if d <= 0 {
+		return 0, newFieldError("ttl", ErrInvalidLeaseTTL, "%q must be positive", s)
+	}
+
+	if d > maxTTL {
+		return 0, newFieldError("ttl", ErrInvalidLeaseTTL, "%q must not be longer than %s", s, FormatRetention(maxTTL))
+	}
+
+	return d, nil
+}
+
+// FormatRetention returns the retention in the syntax accepted by ParseRetention without the trailing zero units,
+// like "72h" instead of "72h0m0s".
+func FormatRetention(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
 func ValidateNameSegment(s string) bool {
 	return segmentRegex.MatchString(s)
 }
+
+var (
+	initialRegex       = regexp.MustCompile(`^[a-z]$`)
+	affixRegex         = regexp.MustCompile(`^[a-z0-9-]*$`)
+	excludedCharsRegex = regexp.MustCompile(`^[a-z0-9]*$`)
+)
+
+// MaxFilterLength is the longest length the filters can ask for, generated names are RFC 1123 labels so no longer
+// name exists.
+const MaxFilterLength = 63
+
+// ValidateFilters checks that the filters hold values that can be used to look for names. The returned error is a
+// ValidationErrors with every invalid filter found, each of them wraps ErrInvalidFilters.
+func ValidateFilters(f RandomPairFilters) error {
+	var errs ValidationErrors
+
+	switch f.LengthMode {
+	case "", LengthModeExactly, LengthModeUpto:
+	default:
+		errs.add(
+			"filters.length_mode",
+			ErrInvalidFilters,
+			"unknown length mode %q, it must be %s or %s",
+			f.LengthMode,
+			LengthModeUpto,
+			LengthModeExactly,
+		)
+	}
+
+	if f.Length < 0 || f.Length > MaxFilterLength || (f.LengthMode != "" && f.Length == 0) {
+		errs.add("filters.length", ErrInvalidFilters, "length must be between 1 and %d", MaxFilterLength)
+	} else if f.Length > 0 && f.LengthMode == "" {
+		errs.add("filters.length_mode", ErrInvalidFilters, "length mode is required along with a length")
+	}
+
+	if f.MinLength < 0 || f.MinLength > MaxFilterLength {
+		errs.add("filters.min_length", ErrInvalidFilters, "min length must be between 0 and %d", MaxFilterLength)
+	} else if f.Length > 0 && f.MinLength > f.Length {
+		errs.add("filters.min_length", ErrInvalidFilters, "min length must not be greater than length")
+	}
+
+	if f.AdjectiveInitial != "" && !initialRegex.MatchString(f.AdjectiveInitial) {
+		errs.add("filters.adjective_initial", ErrInvalidFilters, "adjective initial must be a single lowercase letter")
+	}
+
+	if f.NounInitial != "" && !initialRegex.MatchString(f.NounInitial) {
+		errs.add("filters.noun_initial", ErrInvalidFilters, "noun initial must be a single lowercase letter")
+	}
+
+	if !affixRegex.MatchString(f.Prefix) {
+		errs.add("filters.prefix", ErrInvalidFilters, "prefix may only contain lowercase letters, digits and dashes")
+	}
+
+	if !affixRegex.MatchString(f.Suffix) {
+		errs.add("filters.suffix", ErrInvalidFilters, "suffix may only contain lowercase letters, digits and dashes")
+	}
+
+	if !excludedCharsRegex.MatchString(f.ExcludedChars) {
+		errs.add(
+			"filters.excluded_chars",
+			ErrInvalidFilters,
+			"excluded characters may only contain lowercase letters and digits",
+		)
+	}
+
+	return errs.err()
+}
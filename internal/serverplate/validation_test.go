@@ -1,8 +1,13 @@
 package serverplate_test
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/davidonium/serverplate/internal/serverplate"
 )
@@ -105,3 +110,404 @@ func TestValidateNameSegmentTable(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFiltersTable(t *testing.T) {
+	cases := []struct {
+		Input serverplate.RandomPairFilters
+		Valid bool
+	}{
+		{
+			Input: serverplate.RandomPairFilters{},
+			Valid: true,
+		},
+		{
+			Input: serverplate.RandomPairFilters{
+				Length:           14,
+				LengthMode:       serverplate.LengthModeUpto,
+				MinLength:        8,
+				AdjectiveInitial: "b",
+				NounInitial:      "m",
+				Alliterative:     true,
+				Prefix:           "br",
+				Suffix:           "in",
+				ExcludedChars:    "l1o0",
+			},
+			Valid: true,
+		},
+		{
+			Input: serverplate.RandomPairFilters{Length: 14, LengthMode: "around"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{
+				Length:     8,
+				LengthMode: serverplate.LengthModeUpto,
+				MinLength:  10,
+			},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{MinLength: -1},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{AdjectiveInitial: "ab"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{NounInitial: "B"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{Prefix: "with space"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{Suffix: "_"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{ExcludedChars: "-"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{Length: 64, LengthMode: serverplate.LengthModeExactly},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{LengthMode: serverplate.LengthModeUpto},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{Length: 14},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{LengthMode: "around"},
+			Valid: false,
+		},
+		{
+			Input: serverplate.RandomPairFilters{MinLength: 64},
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			err := serverplate.ValidateFilters(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Errorf("ValidateFilters() = input: %+v - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidFilters) {
+				t.Errorf("ValidateFilters() = got %v, want it to wrap ErrInvalidFilters", err)
+			}
+		})
+	}
+}
+
+func TestValidateFiltersFieldErrors(t *testing.T) {
+	err := serverplate.ValidateFilters(serverplate.RandomPairFilters{
+		Length:        8,
+		LengthMode:    serverplate.LengthModeUpto,
+		MinLength:     10,
+		NounInitial:   "B",
+		Prefix:        "with space",
+		Suffix:        "ok",
+		ExcludedChars: "-",
+	})
+
+	var fields []string
+	for _, f := range serverplate.FieldErrors(err) {
+		if !errors.Is(f, serverplate.ErrInvalidFilters) {
+			t.Errorf("FieldErrors() = got %v for %q, want it to wrap ErrInvalidFilters", f, f.Field)
+		}
+		fields = append(fields, f.Field)
+	}
+
+	want := []string{"filters.min_length", "filters.noun_initial", "filters.prefix", "filters.excluded_chars"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("FieldErrors() = got fields %q, want %q", fields, want)
+	}
+}
+
+func TestFieldErrorsFollowsWrappedErrors(t *testing.T) {
+	_, labelsErr := serverplate.ParseLabels("team")
+	err := fmt.Errorf("failed to create the bucket: %w", errors.Join(
+		serverplate.ValidateBucketName("Servers"),
+		labelsErr,
+		errors.New("unrelated"),
+	))
+
+	var fields []string
+	for _, f := range serverplate.FieldErrors(err) {
+		fields = append(fields, f.Field)
+	}
+	if want := []string{"name", "labels"}; !slices.Equal(fields, want) {
+		t.Errorf("FieldErrors() = got fields %q, want %q", fields, want)
+	}
+
+	if got := serverplate.FieldErrors(errors.New("unrelated")); got != nil {
+		t.Errorf("FieldErrors() = got %v for an error without fields, want nil", got)
+	}
+}
+
+func TestValidateBucketNameTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "production-servers",
+			Valid: true,
+		},
+		{
+			Input: "servers01",
+			Valid: true,
+		},
+		{
+			Input: "01",
+			Valid: false,
+		},
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			Input: "Production",
+			Valid: false,
+		},
+		{
+			Input: "servers-",
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			err := serverplate.ValidateBucketName(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Errorf("ValidateBucketName() = input: %q - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidBucketName) {
+				t.Errorf("ValidateBucketName() = got %v, want it to wrap ErrInvalidBucketName", err)
+			}
+		})
+	}
+}
+
+func TestValidateBucketDescriptionTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: true,
+		},
+		{
+			Input: "names for the production servers",
+			Valid: true,
+		},
+		{
+			Input: strings.Repeat("a", serverplate.MaxBucketDescriptionLength),
+			Valid: true,
+		},
+		{
+			Input: strings.Repeat("a", serverplate.MaxBucketDescriptionLength+1),
+			Valid: false,
+		},
+		{
+			// characters are counted rather than bytes, each of these takes two bytes.
+			Input: strings.Repeat("é", serverplate.MaxBucketDescriptionLength),
+			Valid: true,
+		},
+		{
+			Input: strings.Repeat("é", serverplate.MaxBucketDescriptionLength+1),
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			err := serverplate.ValidateBucketDescription(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Errorf(
+					"ValidateBucketDescription() = input of %d characters - got %v, want valid %v",
+					utf8.RuneCountInString(tt.Input),
+					err,
+					tt.Valid,
+				)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidDescription) {
+				t.Errorf("ValidateBucketDescription() = got %v, want it to wrap ErrInvalidDescription", err)
+			}
+		})
+	}
+}
+
+func TestParseRetentionTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Want  *time.Duration
+		Valid bool
+	}{
+		{
+			Input: "",
+			Want:  nil,
+			Valid: true,
+		},
+		{
+			Input: "0",
+			Want:  new(time.Duration(0)),
+			Valid: true,
+		},
+		{
+			Input: "720h",
+			Want:  new(720 * time.Hour),
+			Valid: true,
+		},
+		{
+			Input: " 90m ",
+			Want:  new(90 * time.Minute),
+			Valid: true,
+		},
+		{
+			Input: "1500ms",
+			Want:  new(2 * time.Second),
+			Valid: true,
+		},
+		{
+			Input: "-1h",
+			Valid: false,
+		},
+		{
+			Input: "3 days",
+			Valid: false,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			got, err := serverplate.ParseRetention(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Fatalf("ParseRetention() = input: %q - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil {
+				if !errors.Is(err, serverplate.ErrInvalidRetention) {
+					t.Errorf("ParseRetention() = got %v, want it to wrap ErrInvalidRetention", err)
+				}
+				return
+			}
+
+			if (got == nil) != (tt.Want == nil) || (got != nil && *got != *tt.Want) {
+				t.Errorf("ParseRetention() = input: %q - got %v, want %v", tt.Input, got, tt.Want)
+			}
+		})
+	}
+}
+
+func TestParseLeaseTTLTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Want  time.Duration
+		Valid bool
+	}{
+		{
+			Input: "",
+			Want:  5 * time.Minute,
+			Valid: true,
+		},
+		{
+			Input: " 30s ",
+			Want:  30 * time.Second,
+			Valid: true,
+		},
+		{
+			Input: "1h",
+			Want:  time.Hour,
+			Valid: true,
+		},
+		{
+			Input: "0",
+			Valid: false,
+		},
+		{
+			Input: "-1m",
+			Valid: false,
+		},
+		{
+			Input: "2h",
+			Valid: false,
+		},
+		{
+			Input: "soon",
+			Valid: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Input, func(t *testing.T) {
+			got, err := serverplate.ParseLeaseTTL(tt.Input, 5*time.Minute, time.Hour)
+			if (err == nil) != tt.Valid {
+				t.Fatalf("ParseLeaseTTL() = input: %q - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil {
+				if !errors.Is(err, serverplate.ErrInvalidLeaseTTL) {
+					t.Errorf("ParseLeaseTTL() = got %v, want it to wrap ErrInvalidLeaseTTL", err)
+				}
+				return
+			}
+
+			if got != tt.Want {
+				t.Errorf("ParseLeaseTTL() = input: %q - got %v, want %v", tt.Input, got, tt.Want)
+			}
+		})
+	}
+}
+
+func TestFormatRetention(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                          "0s",
+		72 * time.Hour:             "72h",
+		90 * time.Minute:           "1h30m",
+		time.Hour + 30*time.Second: "1h0m30s",
+		45 * time.Second:           "45s",
+		2 * time.Minute:            "2m",
+	}
+
+	for d, want := range cases {
+		if got := serverplate.FormatRetention(d); got != want {
+			t.Errorf("FormatRetention() = input: %v - got %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseExpiryTable(t *testing.T) {
+	cases := []struct {
+		Input string
+		Want  *time.Time
+		Valid bool
+	}{
+		{Input: "", Want: nil, Valid: true},
+		{Input: "2026-10-20T18:00:00Z", Want: new(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)), Valid: true},
+		{Input: "2026-10-20T20:00:00+02:00", Want: new(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)), Valid: true},
+		{Input: " 2026-10-20T18:00 ", Want: new(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)), Valid: true},
+		{Input: "tomorrow", Valid: false},
+		{Input: "2026-10-20", Valid: false},
+	}
+
+	for i, tt := range cases {
+		t.Run(fmt.Sprintf("Test Case #%d", i), func(t *testing.T) {
+			got, err := serverplate.ParseExpiry(tt.Input)
+			if (err == nil) != tt.Valid {
+				t.Fatalf("ParseExpiry() = input: %q - got %v, want valid %v", tt.Input, err, tt.Valid)
+			}
+			if err != nil && !errors.Is(err, serverplate.ErrInvalidExpiry) {
+				t.Errorf("ParseExpiry() = got %v, want it to wrap ErrInvalidExpiry", err)
+			}
+			if (got == nil) != (tt.Want == nil) || (got != nil && !got.Equal(*tt.Want)) {
+				t.Errorf("ParseExpiry() = input: %q - got %v, want %v", tt.Input, got, tt.Want)
+			}
+		})
+	}
+}
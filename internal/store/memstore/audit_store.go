@@ -0,0 +1,68 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// AuditStore keeps the audit events in memory, everything is lost once the process stops.
+type AuditStore struct {
+	mu     sync.Mutex
+	lastID int64
+	// events holds the events in the order they were recorded.
+	events []serverplate.AuditEvent
+}
+
+func NewAuditStore() *AuditStore {
+	return &AuditStore{}
+}
+
+func (s *AuditStore) Record(_ context.Context, e *serverplate.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	e.ID = s.lastID
+	e.CreatedAt = time.Now()
+	if len(e.Before) == 0 {
+		e.Before = []byte("null")
+	}
+	if len(e.After) == 0 {
+		e.After = []byte("null")
+	}
+
+	s.events = append(s.events, *e)
+
+	return nil
+}
+
+func (s *AuditStore) List(_ context.Context, opts serverplate.AuditListOptions) ([]serverplate.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []serverplate.AuditEvent{}
+	for _, e := range slices.Backward(s.events) {
+		if opts.Limit > 0 && len(events) == opts.Limit {
+			break
+		}
+
+		switch {
+		case opts.BucketID != 0 && e.BucketID != opts.BucketID,
+			opts.Action != "" && e.Action != opts.Action,
+			opts.Actor != "" && e.Actor != opts.Actor,
+			opts.Source != "" && e.Source != opts.Source,
+			!opts.Since.IsZero() && e.CreatedAt.Before(opts.Since),
+			!opts.Until.IsZero() && !e.CreatedAt.Before(opts.Until),
+			opts.BeforeID > 0 && e.ID >= opts.BeforeID:
+			continue
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,713 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type bucketEntry struct {
+	bucket serverplate.Bucket
+	// values holds the shuffled names of the bucket, the value at index i has the order id i+1.
+	values []string
+	// poppedAt holds the time each value was popped at by its order id.
+	poppedAt map[int32]time.Time
+	// lazy is only set for lazy buckets, whose values only hold the names already computed.
+	lazy *lazyState
+}
+
+// lazyState is where a lazy bucket is in its walk and how many names matching its filters are left after it.
+type lazyState struct {
+	values    serverplate.LazyValues
+	position  int64
+	remaining int64
+}
+
+// remaining must be called while holding the lock.
+func (e *bucketEntry) remaining() int64 {
+	if e.bucket.Cursor < 1 {
+		return 0
+	}
+
+	var lazy int64
+	if e.lazy != nil {
+		lazy = e.lazy.remaining
+	}
+
+	return lazy + int64(max(0, len(e.values)-int(e.bucket.Cursor)+1))
+}
+
+// pop hands out the value at the cursor, computing the next name of lazy buckets once no stored value is left. It
+// must be called while holding the lock.
+func (e *bucketEntry) pop() (string, error) {
+	cursor := int(e.bucket.Cursor)
+	if cursor >= 1 && cursor > len(e.values) && e.lazy != nil && e.lazy.remaining > 0 {
+		if name, next, ok := e.lazy.values.Next(e.lazy.position); ok {
+			e.values = append(e.values, name)
+			e.lazy.position = next
+			e.lazy.remaining--
+		}
+	}
+
+	if cursor < 1 || cursor > len(e.values) {
+		return "", serverplate.ErrBucketExhausted
+	}
+
+	now := time.Now()
+	e.poppedAt[e.bucket.Cursor] = now
+	e.bucket.Cursor++
+	e.bucket.UpdatedAt = &now
+
+	return e.values[cursor-1], nil
+}
+
+// BucketStore keeps buckets and their values in memory, everything is lost once the process stops.
+type BucketStore struct {
+	mu      sync.Mutex
+	words   *Words
+	lastID  int32
+	buckets map[int32]*bucketEntry
+}
+
+func NewBucketStore(words *Words) *BucketStore {
+	return &BucketStore{
+		words:   words,
+		buckets: map[int32]*bucketEntry{},
+	}
+}
+
+func (s *BucketStore) List(
+	_ context.Context,
+	opts serverplate.ListOptions,
+) ([]serverplate.Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	search := strings.ToLower(opts.Search)
+
+	buckets := make([]serverplate.Bucket, 0, len(s.buckets))
+	for _, e := range s.buckets {
+		if e.bucket.Archived() != opts.ArchivedOnly {
+			continue
+		}
+
+		if search != "" &&
+			!strings.Contains(strings.ToLower(e.bucket.Name), search) &&
+			!strings.Contains(strings.ToLower(e.bucket.Description), search) {
+			continue
+		}
+
+		if !serverplate.MatchLabels(e.bucket.Labels, opts.LabelSelector) {
+			continue
+		}
+
+		b := e.bucket
+		if opts.IncludeRemaining {
+			b.RemainingValues = e.remaining()
+		}
+		buckets = append(buckets, b)
+	}
+
+	slices.SortFunc(buckets, func(a, b serverplate.Bucket) int {
+		c := compareBuckets(a, b, opts.SortBy)
+		if opts.SortDesc {
+			return -c
+		}
+		return c
+	})
+
+	buckets = buckets[min(opts.Offset, len(buckets)):]
+	if opts.Limit > 0 && opts.Limit < len(buckets) {
+		buckets = buckets[:opts.Limit]
+	}
+
+	return buckets, nil
+}
+
+// compareBuckets compares by the sort field first and by id to break the ties.
+func compareBuckets(a, b serverplate.Bucket, field serverplate.BucketSortField) int {
+	var c int
+	switch field {
+	case serverplate.BucketSortName:
+		c = strings.Compare(a.Name, b.Name)
+	case serverplate.BucketSortCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case serverplate.BucketSortUpdatedAt:
+		c = lastChange(a).Compare(lastChange(b))
+	}
+
+	return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+}
+
+func lastChange(b serverplate.Bucket) time.Time {
+	if b.UpdatedAt != nil {
+		return *b.UpdatedAt
+	}
+	return b.CreatedAt
+}
+
+func (s *BucketStore) Create(_ context.Context, b *serverplate.Bucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byName(b.Name); ok {
+		return serverplate.ErrBucketNameTaken
+	}
+
+	s.lastID++
+	b.ID = s.lastID
+	b.CreatedAt = time.Now()
+
+	stored := *b
+	stored.Labels = cloneLabels(b.Labels)
+	if b.Fill != nil {
+		stored.Fill = new(*b.Fill)
+	}
+	s.buckets[b.ID] = &bucketEntry{bucket: stored, poppedAt: map[int32]time.Time{}}
+
+	return nil
+}
+
+func (s *BucketStore) SetLabels(_ context.Context, bucketID int32, labels map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[bucketID]
+	if !ok {
+		return nil
+	}
+
+	e.bucket.Labels = cloneLabels(labels)
+
+	return nil
+}
+
+// cloneLabels copies the labels so the stored map is never shared with the caller, the stored
+// maps are replaced instead of modified which makes returning them safe.
+func cloneLabels(labels map[string]string) map[string]string {
+	cloned := make(map[string]string, len(labels))
+	maps.Copy(cloned, labels)
+	return cloned
+}
+
+func (s *BucketStore) SetCursor(_ context.Context, bucketID int32, cursor int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[bucketID]
+	if !ok {
+		return nil
+	}
+
+	e.bucket.Cursor = cursor
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) OneByName(_ context.Context, name string) (serverplate.Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.byName(name)
+	if !ok {
+		return serverplate.Bucket{}, serverplate.ErrBucketNotFound
+	}
+
+	return e.bucket, nil
+}
+
+func (s *BucketStore) OneByID(_ context.Context, id int32) (serverplate.Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[id]
+	if !ok {
+		return serverplate.Bucket{}, serverplate.ErrBucketNotFound
+	}
+
+	return e.bucket, nil
+}
+
+func (s *BucketStore) FillBucketValues(
+	_ context.Context,
+	b serverplate.Bucket,
+	f serverplate.RandomPairFilters,
+) error {
+	var values []string
+	s.words.eachPair(f, func(p serverplate.Pair) bool {
+		values = append(values, p.Adjective+"-"+p.Noun)
+		return true
+	})
+
+	rand.Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return serverplate.ErrBucketNotFound
+	}
+
+	e.values = append(e.values, values...)
+	e.bucket.Cursor = 1
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) FillBucketValuesInOrder(_ context.Context, b serverplate.Bucket, values []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return serverplate.ErrBucketNotFound
+	}
+
+	e.values = append(e.values, values...)
+	e.bucket.Cursor = 1
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+// filling returns the entry of the bucket whose fill is running, it must be called while holding the lock. The fill
+// of the stored bucket is never changed in place since the buckets handed out share it.
+func (s *BucketStore) filling(id int32) (*bucketEntry, error) {
+	e, ok := s.buckets[id]
+	if !ok || e.bucket.Fill == nil || e.bucket.Fill.Failed() {
+		return nil, serverplate.ErrBucketNotFilling
+	}
+
+	return e, nil
+}
+
+func (s *BucketStore) StartFill(_ context.Context, b serverplate.Bucket, total int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.filling(b.ID)
+	if err != nil {
+		return err
+	}
+
+	e.bucket.Fill = &serverplate.BucketFill{Total: total}
+	if total <= 0 {
+		e.bucket.Fill = nil
+	}
+	e.bucket.Cursor = 1
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) AppendFillValues(_ context.Context, b serverplate.Bucket, values []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.filling(b.ID)
+	if err != nil {
+		return err
+	}
+
+	e.values = append(e.values, values...)
+	e.bucket.Fill = &serverplate.BucketFill{Total: e.bucket.Fill.Total, Done: e.bucket.Fill.Done + int64(len(values))}
+	if e.bucket.Fill.Done >= e.bucket.Fill.Total {
+		e.bucket.Fill = nil
+	}
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) KeepFilling(_ context.Context, b serverplate.Bucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.filling(b.ID)
+	if err != nil {
+		return err
+	}
+
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) FailFill(_ context.Context, b serverplate.Bucket, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.filling(b.ID)
+	if err != nil {
+		return err
+	}
+
+	e.bucket.Fill = &serverplate.BucketFill{Total: e.bucket.Fill.Total, Done: e.bucket.Fill.Done, Error: reason}
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) FailStalledFills(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var failed int64
+	for _, e := range s.buckets {
+		if e.bucket.Fill == nil || e.bucket.Fill.Failed() {
+			continue
+		}
+		progressAt := e.bucket.CreatedAt
+		if e.bucket.UpdatedAt != nil {
+			progressAt = *e.bucket.UpdatedAt
+		}
+		if !progressAt.Before(before) {
+			continue
+		}
+
+		fill := *e.bucket.Fill
+		fill.Error = "the fill stopped making progress"
+		e.bucket.Fill = &fill
+		e.bucket.UpdatedAt = new(time.Now())
+		failed++
+	}
+
+	return failed, nil
+}
+
+func (s *BucketStore) FillBucketLazily(_ context.Context, b serverplate.Bucket, count int64) error {
+	if b.Seed == nil {
+		return fmt.Errorf("lazy bucket %d has no seed", b.ID)
+	}
+
+	words := serverplate.WordLists{Adjectives: s.words.adjectives, Nouns: s.words.nouns}
+	if err := words.CheckVersion(b.WordsVersion); err != nil {
+		return err
+	}
+
+	l := words.LazyValues(b.Filters(), *b.Seed)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return serverplate.ErrBucketNotFound
+	}
+
+	e.lazy = &lazyState{values: l, remaining: count}
+	e.bucket.Lazy = true
+	e.bucket.Cursor = 1
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) CopyRemainingValues(_ context.Context, from, to serverplate.Bucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	src, ok := s.buckets[from.ID]
+	if !ok {
+		return nil
+	}
+	dst, ok := s.buckets[to.ID]
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	if src.bucket.Cursor > 0 && int(src.bucket.Cursor) <= len(src.values) {
+		values = slices.Clone(src.values[src.bucket.Cursor-1:])
+	}
+
+	dst.values = values
+	dst.poppedAt = map[int32]time.Time{}
+	dst.lazy = nil
+	if src.lazy != nil {
+		dst.lazy = new(*src.lazy)
+		dst.bucket.Lazy = true
+	}
+	dst.bucket.Cursor = 1
+	dst.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) RemainingValuesTotal(_ context.Context, b serverplate.Bucket) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return 0, nil
+	}
+
+	return e.remaining(), nil
+}
+
+func (s *BucketStore) PopName(_ context.Context, b serverplate.Bucket) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return "", serverplate.ErrBucketNotFound
+	}
+
+	return e.pop()
+}
+
+func (s *BucketStore) RecentlyPopped(
+	_ context.Context,
+	b serverplate.Bucket,
+	limit int,
+) ([]serverplate.PoppedName, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return nil, nil
+	}
+
+	var names []serverplate.PoppedName
+	for id := min(e.bucket.Cursor-1, int32(len(e.values))); id >= 1 && len(names) < limit; id-- {
+		n := serverplate.PoppedName{Name: e.values[id-1]}
+		if t, ok := e.poppedAt[id]; ok {
+			n.PoppedAt = &t
+		}
+		names = append(names, n)
+	}
+
+	return names, nil
+}
+
+func (s *BucketStore) UpdateFilters(_ context.Context, b *serverplate.Bucket) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return 0, serverplate.ErrBucketNotFound
+	}
+
+	return s.updateFilters(e, b)
+}
+
+// updateFilters stores the filters of b and replaces the values past the cursor, see UpdateFilters. It only changes
+// the entry once nothing can fail anymore and must be called while holding the lock.
+func (s *BucketStore) updateFilters(e *bucketEntry, b *serverplate.Bucket) (int64, error) {
+	f := b.Filters()
+	cursor := max(e.bucket.Cursor, 1)
+	popped := e.values[:min(int(cursor)-1, len(e.values))]
+
+	if e.lazy != nil {
+		delta, err := s.updateLazyFilters(e, *b, popped)
+		if err != nil {
+			return 0, err
+		}
+
+		e.bucket.SetFilters(f)
+		e.bucket.Cursor = cursor
+		e.bucket.UpdatedAt = new(time.Now())
+
+		b.Cursor = e.bucket.Cursor
+		b.UpdatedAt = e.bucket.UpdatedAt
+
+		return delta, nil
+	}
+
+	seen := make(map[string]bool, len(popped))
+	for _, v := range popped {
+		seen[v] = true
+	}
+
+	var values []string
+	if b.Seed != nil {
+		// keeps the bucket reproducible from its seed.
+		words := serverplate.WordLists{Adjectives: s.words.adjectives, Nouns: s.words.nouns}
+		if err := words.CheckVersion(b.WordsVersion); err != nil {
+			return 0, err
+		}
+		for _, v := range words.SeededNames(f, *b.Seed) {
+			if !seen[v] {
+				values = append(values, v)
+			}
+		}
+	} else {
+		s.words.eachPair(f, func(p serverplate.Pair) bool {
+			if v := p.Adjective + "-" + p.Noun; !seen[v] {
+				values = append(values, v)
+			}
+			return true
+		})
+
+		rand.Shuffle(len(values), func(i, j int) {
+			values[i], values[j] = values[j], values[i]
+		})
+	}
+
+	delta := int64(len(values) - (len(e.values) - len(popped)))
+
+	e.values = append(slices.Clip(popped), values...)
+	e.bucket.SetFilters(f)
+	e.bucket.Cursor = cursor
+	e.bucket.UpdatedAt = new(time.Now())
+
+	b.Cursor = e.bucket.Cursor
+	b.UpdatedAt = e.bucket.UpdatedAt
+
+	return delta, nil
+}
+
+// updateLazyFilters restarts the walk of the lazy bucket with the filters of b from where it is and removes the
+// stored values past the popped ones, the names of expired leases, that do not match them. It returns the change in
+// the remaining values and must be called while holding the lock.
+func (s *BucketStore) updateLazyFilters(e *bucketEntry, b serverplate.Bucket, popped []string) (int64, error) {
+	if b.Seed == nil {
+		return 0, fmt.Errorf("lazy bucket %d has no seed", b.ID)
+	}
+
+	words := serverplate.WordLists{Adjectives: s.words.adjectives, Nouns: s.words.nouns}
+	if err := words.CheckVersion(b.WordsVersion); err != nil {
+		return 0, err
+	}
+
+	l := words.LazyValues(b.Filters(), *b.Seed)
+	remaining := l.Count(e.lazy.position)
+	delta := remaining - e.lazy.remaining
+
+	values := slices.Clip(popped)
+	for _, v := range e.values[len(popped):] {
+		if l.Match(v) {
+			values = append(values, v)
+		} else {
+			delta--
+		}
+	}
+
+	e.values = values
+	e.lazy = &lazyState{values: l, position: e.lazy.position, remaining: remaining}
+
+	return delta, nil
+}
+
+func (s *BucketStore) Save(_ context.Context, b *serverplate.Bucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		return nil
+	}
+
+	if other, ok := s.byName(b.Name); ok && other != e {
+		return serverplate.ErrBucketNameTaken
+	}
+
+	e.save(*b)
+
+	return nil
+}
+
+// save stores the fields of b written by Save, it must be called while holding the lock.
+func (e *bucketEntry) save(b serverplate.Bucket) {
+	e.bucket.Name = b.Name
+	e.bucket.Description = b.Description
+	e.bucket.ArchivedAt = b.ArchivedAt
+	e.bucket.Retention = b.Retention
+	e.bucket.ExpiresAt = b.ExpiresAt
+	e.bucket.ArchiveWhenExhausted = b.ArchiveWhenExhausted
+	e.bucket.UpdatedAt = new(time.Now())
+}
+
+func (s *BucketStore) UpdateBucket(
+	_ context.Context,
+	b *serverplate.Bucket,
+	opts serverplate.UpdateOptions,
+) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[b.ID]
+	if !ok {
+		if opts.Filters {
+			return 0, serverplate.ErrBucketNotFound
+		}
+		return 0, nil
+	}
+
+	// the checks that can fail go first so that a rejected update leaves the entry as it was.
+	if other, ok := s.byName(b.Name); ok && other != e {
+		return 0, serverplate.ErrBucketNameTaken
+	}
+
+	var delta int64
+	if opts.Filters {
+		var err error
+		if delta, err = s.updateFilters(e, b); err != nil {
+			return 0, err
+		}
+	}
+
+	if opts.Labels {
+		e.bucket.Labels = cloneLabels(b.Labels)
+	}
+	e.save(*b)
+
+	return delta, nil
+}
+
+func (s *BucketStore) Archive(_ context.Context, id int32, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[id]
+	if !ok || e.bucket.Archived() {
+		return serverplate.ErrBucketNotFound
+	}
+
+	e.bucket.ArchivedAt = &at
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return nil
+}
+
+func (s *BucketStore) DeleteArchived(_ context.Context, id int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.buckets[id]
+	if !ok || !e.bucket.Archived() {
+		return serverplate.ErrBucketNotFound
+	}
+
+	delete(s.buckets, id)
+
+	return nil
+}
+
+// byName must be called while holding the lock.
+func (s *BucketStore) byName(name string) (*bucketEntry, bool) {
+	for _, e := range s.buckets {
+		if e.bucket.Name == name {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,136 @@
+package memstore_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/memstore"
+	"github.com/davidonium/serverplate/internal/store/storetest"
+)
+
+func TestBucketStore(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave", "calm"}, []string{"river", "mountain"})
+	store := memstore.NewBucketStore(words)
+
+	b := &serverplate.Bucket{
+		Name: "test-bucket",
+	}
+
+	if err := store.Create(ctx, b); err != nil {
+		t.Errorf("Create() = expected to succeed but got err: %v", err)
+	}
+
+	if err := store.Create(ctx, b); err == nil {
+		t.Error(
+			"Create() = expected to fail when creating a bucket with an already existing name but succeeded",
+		)
+	}
+
+	bk, err := store.OneByName(ctx, "test-bucket")
+	if err != nil {
+		t.Errorf("OneByName() = expected to retrieve an existing bucket but failed: %v", err)
+	}
+
+	if bk.Name != "test-bucket" {
+		t.Errorf(
+			"OneByName() = unexpected bucket name retrieved. got '%s' want '%s'",
+			bk.Name,
+			"test-bucket",
+		)
+	}
+
+	if _, err := store.OneByName(ctx, "missing-bucket"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("OneByName() = expected ErrBucketNotFound for a missing bucket, got: %v", err)
+	}
+}
+
+func TestPairStoreNoMatchingPairs(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave"}, []string{"river"})
+	store := memstore.NewPairStore(words)
+
+	_, err := store.OneRandom(ctx, serverplate.RandomPairFilters{
+		Length:     3,
+		LengthMode: serverplate.LengthModeExactly,
+	})
+	if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+		t.Errorf("OneRandom() = expected ErrNoMatchingPairs, got: %v", err)
+	}
+}
+
+func TestPairStoreCachedMatches(t *testing.T) {
+	ctx := context.Background()
+	words := memstore.NewWords([]string{"brave", "calm"}, []string{"river", "mountain"})
+	store := memstore.NewPairStore(words)
+
+	// more filter sets than the cache keeps, each one is picked from twice to hit the cache.
+	for i := range 300 {
+		f := serverplate.RandomPairFilters{Suffix: "n", MinLength: i % 20}
+		want := 0
+		for _, name := range []string{"brave-river", "brave-mountain", "calm-river", "calm-mountain"} {
+			adjective, noun, _ := strings.Cut(name, "-")
+			if f.Match(adjective, noun) {
+				want++
+			}
+		}
+
+		for range 2 {
+			stats, err := store.Stats(ctx, f)
+			if err != nil || stats.PairCount != want {
+				t.Fatalf("Stats(%+v) = got %d pairs and err %v, want %d", f, stats.PairCount, err, want)
+			}
+
+			p, err := store.OneRandom(ctx, f)
+			if want == 0 {
+				if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+					t.Fatalf("OneRandom(%+v) = got %v, want ErrNoMatchingPairs", f, err)
+				}
+				continue
+			}
+			if err != nil || !f.Match(p.Adjective, p.Noun) {
+				t.Fatalf("OneRandom(%+v) = got %+v and err %v, want a matching pair", f, p, err)
+			}
+		}
+	}
+}
+
+func newConformanceStores(_ *testing.T, adjectives, nouns []string) storetest.Stores {
+	words := memstore.NewWords(adjectives, nouns)
+	buckets := memstore.NewBucketStore(words)
+	return storetest.Stores{
+		Buckets:  buckets,
+		Pairs:    memstore.NewPairStore(words),
+		JobRuns:  memstore.NewJobRunStore(),
+		JobLocks: memstore.NewJobLockStore(),
+		Leases:   memstore.NewLeaseStore(buckets),
+		Audit:    memstore.NewAuditStore(),
+	}
+}
+
+func TestBucketStoreConformance(t *testing.T) {
+	storetest.RunBucketStoreSuite(t, newConformanceStores)
+}
+
+func TestPairStoreConformance(t *testing.T) {
+	storetest.RunPairStoreSuite(t, newConformanceStores)
+}
+
+func TestJobRunStoreConformance(t *testing.T) {
+	storetest.RunJobRunStoreSuite(t, newConformanceStores)
+}
+
+func TestJobLockStoreConformance(t *testing.T) {
+	storetest.RunJobLockStoreSuite(t, newConformanceStores)
+}
+
+func TestLeaseStoreConformance(t *testing.T) {
+	storetest.RunLeaseStoreSuite(t, newConformanceStores)
+}
+
+func TestAuditStoreConformance(t *testing.T) {
+	storetest.RunAuditStoreSuite(t, newConformanceStores)
+}
@@ -0,0 +1,46 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type jobLock struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// JobLockStore keeps the job locks in memory, it only coordinates the runners sharing the same instance of it.
+type JobLockStore struct {
+	mu    sync.Mutex
+	locks map[string]jobLock
+}
+
+func NewJobLockStore() *JobLockStore {
+	return &JobLockStore{locks: map[string]jobLock{}}
+}
+
+func (s *JobLockStore) Acquire(_ context.Context, job, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := s.locks[job]; ok && l.owner != owner && l.expiresAt.After(now) {
+		return false, nil
+	}
+
+	s.locks[job] = jobLock{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (s *JobLockStore) Release(_ context.Context, job, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.locks[job]; ok && l.owner == owner {
+		delete(s.locks, job)
+	}
+
+	return nil
+}
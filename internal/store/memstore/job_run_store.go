@@ -0,0 +1,67 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// JobRunStore keeps the job runs in memory, everything is lost once the process stops.
+type JobRunStore struct {
+	mu     sync.Mutex
+	lastID int64
+	// runs holds the runs in the order they were recorded.
+	runs []serverplate.JobRun
+}
+
+func NewJobRunStore() *JobRunStore {
+	return &JobRunStore{}
+}
+
+func (s *JobRunStore) Record(_ context.Context, run *serverplate.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	run.ID = s.lastID
+	s.runs = append(s.runs, *run)
+
+	return nil
+}
+
+func (s *JobRunStore) Last(_ context.Context, job string) (serverplate.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		last  serverplate.JobRun
+		found bool
+	)
+	for _, r := range s.runs {
+		if r.Job == job && (!found || !r.StartedAt.Before(last.StartedAt)) {
+			last = r
+			found = true
+		}
+	}
+
+	if !found {
+		return serverplate.JobRun{}, serverplate.ErrJobRunNotFound
+	}
+
+	return last, nil
+}
+
+func (s *JobRunStore) Recent(_ context.Context, limit int) ([]serverplate.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := slices.Clone(s.runs)
+	slices.SortStableFunc(runs, func(a, b serverplate.JobRun) int {
+		return cmp.Or(b.StartedAt.Compare(a.StartedAt), cmp.Compare(b.ID, a.ID))
+	})
+
+	return runs[:min(limit, len(runs))], nil
+}
@@ -0,0 +1,144 @@
+package memstore
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// LeaseStore keeps the leases in memory, the names it reserves come from the buckets of the given BucketStore.
+type LeaseStore struct {
+	buckets *BucketStore
+	// leases is guarded by the lock of buckets, reserving and returning names change both at once.
+	leases map[string]serverplate.Lease
+}
+
+func NewLeaseStore(buckets *BucketStore) *LeaseStore {
+	return &LeaseStore{
+		buckets: buckets,
+		leases:  map[string]serverplate.Lease{},
+	}
+}
+
+func (s *LeaseStore) Reserve(_ context.Context, b serverplate.Bucket, ttl time.Duration) (serverplate.Lease, error) {
+	s.buckets.mu.Lock()
+	defer s.buckets.mu.Unlock()
+
+	e, ok := s.buckets.buckets[b.ID]
+	if !ok {
+		return serverplate.Lease{}, serverplate.ErrBucketNotFound
+	}
+
+	name, err := e.pop()
+	if err != nil {
+		return serverplate.Lease{}, err
+	}
+
+	now := time.Now()
+	l := serverplate.Lease{
+		ID:        rand.Text(),
+		BucketID:  b.ID,
+		Name:      name,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	s.leases[l.ID] = l
+
+	return l, nil
+}
+
+func (s *LeaseStore) Confirm(_ context.Context, id string) (serverplate.Lease, error) {
+	s.buckets.mu.Lock()
+	defer s.buckets.mu.Unlock()
+
+	l, ok := s.leases[id]
+	if !ok {
+		return serverplate.Lease{}, serverplate.ErrLeaseNotFound
+	}
+
+	now := time.Now()
+	if l.Confirmed() {
+		return l, nil
+	}
+	if l.Expired(now) {
+		return serverplate.Lease{}, serverplate.ErrLeaseExpired
+	}
+
+	l.ConfirmedAt = &now
+	s.leases[id] = l
+
+	return l, nil
+}
+
+func (s *LeaseStore) ReturnExpired(_ context.Context) ([]serverplate.Lease, error) {
+	s.buckets.mu.Lock()
+	defer s.buckets.mu.Unlock()
+
+	now := time.Now()
+
+	var returned []serverplate.Lease
+	for id, l := range s.leases {
+		if !l.Expired(now) {
+			continue
+		}
+
+		delete(s.leases, id)
+
+		// the name of an archived bucket or one that no longer matches its filters stays handed out.
+		e, ok := s.buckets.buckets[l.BucketID]
+		if !ok || e.bucket.Archived() || !e.bucket.Filters().MatchName(l.Name) {
+			continue
+		}
+		if e.unpop(l.Name) {
+			returned = append(returned, l)
+		}
+	}
+
+	return returned, nil
+}
+
+func (s *LeaseStore) CountPending(_ context.Context, bucketID int32) (int64, error) {
+	s.buckets.mu.Lock()
+	defer s.buckets.mu.Unlock()
+
+	var count int64
+	for _, l := range s.leases {
+		if l.BucketID == bucketID && !l.Confirmed() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// unpop moves the popped value to the end of the bucket, past the values that were already waiting, and reports
+// whether it was found. It must be called while holding the lock.
+func (e *bucketEntry) unpop(name string) bool {
+	popped := min(max(int(e.bucket.Cursor)-1, 0), len(e.values))
+
+	i := slices.Index(e.values[:popped], name)
+	if i == -1 {
+		return false
+	}
+
+	// the order ids after the value shift by one, and so do the pop times kept by them.
+	poppedAt := make(map[int32]time.Time, len(e.poppedAt))
+	for id, t := range e.poppedAt {
+		switch {
+		case id < int32(i+1):
+			poppedAt[id] = t
+		case id > int32(i+1):
+			poppedAt[id-1] = t
+		}
+	}
+
+	e.values = append(slices.Delete(e.values, i, i+1), name)
+	e.poppedAt = poppedAt
+	e.bucket.Cursor--
+	e.bucket.UpdatedAt = new(time.Now())
+
+	return true
+}
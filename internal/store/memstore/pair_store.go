@@ -0,0 +1,51 @@
+package memstore
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type PairStore struct {
+	words *Words
+}
+
+func NewPairStore(words *Words) *PairStore {
+	return &PairStore{words: words}
+}
+
+func (s *PairStore) OneRandom(
+	_ context.Context,
+	f serverplate.RandomPairFilters,
+) (serverplate.Pair, error) {
+	m := s.words.matching(f)
+	if m.total == 0 {
+		return serverplate.Pair{}, serverplate.ErrNoMatchingPairs
+	}
+
+	return s.words.pair(m, rand.IntN(m.total)), nil
+}
+
+func (s *PairStore) Stats(
+	_ context.Context,
+	f serverplate.RandomPairFilters,
+) (serverplate.Stats, error) {
+	return serverplate.Stats{
+		PairCount:      s.count(f),
+		AdjectiveCount: len(s.words.adjectives),
+		NounCount:      len(s.words.nouns),
+	}, nil
+}
+
+func (s *PairStore) Words(context.Context) (serverplate.WordLists, error) {
+	return serverplate.WordLists{
+		Adjectives: slices.Clone(s.words.adjectives),
+		Nouns:      slices.Clone(s.words.nouns),
+	}, nil
+}
+
+func (s *PairStore) count(f serverplate.RandomPairFilters) int {
+	return s.words.matching(f).total
+}
@@ -0,0 +1,128 @@
+package memstore
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// maxCachedMatches is the number of filter sets whose matching pairs are kept, the cache is emptied once it is full.
+const maxCachedMatches = 128
+
+// Words holds the adjectives and nouns the in-memory stores combine into names. The words are read only once created
+// so it can be shared between stores, the pairs matching each filter set are cached to pick them without a scan.
+type Words struct {
+	adjectives []string
+	nouns      []string
+
+	mu      sync.Mutex
+	matches map[serverplate.RandomPairFilters]*matches
+}
+
+func NewWords(adjectives, nouns []string) *Words {
+	return &Words{
+		adjectives: adjectives,
+		nouns:      nouns,
+		matches:    map[serverplate.RandomPairFilters]*matches{},
+	}
+}
+
+// ReadWords builds Words from readers holding one word per line, the same format used by the seed files.
+func ReadWords(adjectives, nouns io.Reader) (*Words, error) {
+	adjs, err := readLines(adjectives)
+	if err != nil {
+		return nil, err
+	}
+
+	ns, err := readLines(nouns)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWords(adjs, ns), nil
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// eachPair calls f for every adjective-noun combination matching the filters, in adjective then noun order.
+// Iteration stops when f returns false.
+func (w *Words) eachPair(f serverplate.RandomPairFilters, fn func(serverplate.Pair) bool) {
+	for _, a := range w.adjectives {
+		for _, n := range w.nouns {
+			if !f.Match(a, n) {
+				continue
+			}
+
+			if !fn(serverplate.Pair{Adjective: a, Noun: n}) {
+				return
+			}
+		}
+	}
+}
+
+// matches holds the pairs matching a filter set as indexes, the index of the adjective times the number of nouns plus
+// the index of the noun. indexes is nil when every pair matches, the k-th match is then the pair at index k.
+type matches struct {
+	total   int
+	indexes []int32
+}
+
+// matching returns the pairs matching the filters, scanning the pairs only the first time the filters are seen.
+func (w *Words) matching(f serverplate.RandomPairFilters) *matches {
+	w.mu.Lock()
+	m, ok := w.matches[f]
+	w.mu.Unlock()
+	if ok {
+		return m
+	}
+
+	m = &matches{}
+	if f == (serverplate.RandomPairFilters{}) {
+		m.total = len(w.adjectives) * len(w.nouns)
+	} else {
+		m.indexes = []int32{}
+		for i, a := range w.adjectives {
+			for j, n := range w.nouns {
+				if f.Match(a, n) {
+					m.indexes = append(m.indexes, int32(i*len(w.nouns)+j))
+				}
+			}
+		}
+		m.total = len(m.indexes)
+	}
+
+	w.mu.Lock()
+	if len(w.matches) >= maxCachedMatches {
+		clear(w.matches)
+	}
+	w.matches[f] = m
+	w.mu.Unlock()
+
+	return m
+}
+
+// pair returns the k-th pair matching the filters, in the order of eachPair.
+func (w *Words) pair(m *matches, k int) serverplate.Pair {
+	if m.indexes != nil {
+		k = int(m.indexes[k])
+	}
+
+	return serverplate.Pair{Adjective: w.adjectives[k/len(w.nouns)], Noun: w.nouns[k%len(w.nouns)]}
+}
@@ -0,0 +1,1491 @@
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type bucketRow struct {
+	ID                   int32          `db:"id"`
+	Name                 string         `db:"name"`
+	Description          sql.NullString `db:"description"`
+	Cursor               sql.NullInt32  `db:"cursor"`
+	ArchivedAt           sql.NullTime   `db:"archived_at"`
+	CreatedAt            time.Time      `db:"created_at"`
+	UpdatedAt            sql.NullTime   `db:"updated_at"`
+	FilterLengthEnabled  bool           `db:"filter_length_enabled"`
+	FilterLengthMode     sql.NullString `db:"filter_length_mode"`
+	FilterLengthValue    sql.NullInt32  `db:"filter_length_value"`
+	FilterMinLength      sql.NullInt32  `db:"filter_min_length"`
+	FilterAdjInitial     sql.NullString `db:"filter_adjective_initial"`
+	FilterNounInitial    sql.NullString `db:"filter_noun_initial"`
+	FilterAlliterative   bool           `db:"filter_alliterative"`
+	FilterPrefix         sql.NullString `db:"filter_prefix"`
+	FilterSuffix         sql.NullString `db:"filter_suffix"`
+	FilterExcludedChars  sql.NullString `db:"filter_excluded_chars"`
+	RetentionSeconds     sql.NullInt64  `db:"retention_seconds"`
+	ExpiresAt            sql.NullTime   `db:"expires_at"`
+	ArchiveWhenExhausted bool           `db:"archive_when_exhausted"`
+	Seed                 sql.NullInt64  `db:"seed"`
+	WordsVersion         sql.NullString `db:"words_version"`
+	LazyPosition         sql.NullInt64  `db:"lazy_position"`
+	LazyRemaining        sql.NullInt64  `db:"lazy_remaining"`
+	FillTotal            sql.NullInt64  `db:"fill_total"`
+	FillDone             int64          `db:"fill_done"`
+	FillError            sql.NullString `db:"fill_error"`
+	RemainingValues      int64          `db:"remaining_values"`
+	Labels               labelsColumn   `db:"labels"`
+}
+
+type BucketStore struct {
+	db     *DB
+	logger *slog.Logger
+}
+
+func NewBucketStore(logger *slog.Logger, db *DB) *BucketStore {
+	return &BucketStore{logger: logger, db: db}
+}
+
+const createBucketSQL = `
+INSERT INTO buckets
+	(
+		name,
+		description,
+		filter_length_enabled,
+		filter_length_mode,
+		filter_length_value,
+		filter_min_length,
+		filter_adjective_initial,
+		filter_noun_initial,
+		filter_alliterative,
+		filter_prefix,
+		filter_suffix,
+		filter_excluded_chars,
+		retention_seconds,
+		expires_at,
+		archive_when_exhausted,
+		seed,
+		words_version,
+		fill_total
+	)
+VALUES
+	(
+		:name,
+		:description,
+		:filter_length_enabled,
+		:filter_length_mode,
+		:filter_length_value,
+		:filter_min_length,
+		:filter_adjective_initial,
+		:filter_noun_initial,
+		:filter_alliterative,
+		:filter_prefix,
+		:filter_suffix,
+		:filter_excluded_chars,
+		:retention_seconds,
+		:expires_at,
+		:archive_when_exhausted,
+		:seed,
+		:words_version,
+		:fill_total
+	)
+RETURNING
+	id, created_at`
+
+func (s *BucketStore) Create(ctx context.Context, b *serverplate.Bucket) error {
+	args := filterArgs(*b)
+	args["name"] = b.Name
+	args["description"] = b.Description
+	args["retention_seconds"] = retentionSeconds(b.Retention)
+	args["expires_at"] = b.ExpiresAt
+	args["archive_when_exhausted"] = b.ArchiveWhenExhausted
+	args["seed"] = b.Seed
+	args["words_version"] = nullableString(b.WordsVersion)
+	args["fill_total"] = nil
+	if b.Fill != nil {
+		args["fill_total"] = b.Fill.Total
+	}
+
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		stmt, err := tx.PrepareNamedContext(ctx, createBucketSQL)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		var row struct {
+			ID        int32     `db:"id"`
+			CreatedAt time.Time `db:"created_at"`
+		}
+		if err := stmt.GetContext(ctx, &row, args); err != nil {
+			if isUniqueViolation(err) {
+				return serverplate.ErrBucketNameTaken
+			}
+			return err
+		}
+
+		if err := insertLabels(ctx, tx, row.ID, b.Labels); err != nil {
+			return err
+		}
+
+		b.ID = row.ID
+		b.CreatedAt = row.CreatedAt
+		return nil
+	})
+}
+
+// isUniqueViolation reports whether err was caused by a unique index, the only one on buckets is the name.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+const removeLabelsSQL = `DELETE FROM bucket_labels WHERE bucket_id = :bucket_id`
+
+const insertLabelSQL = `
+INSERT INTO bucket_labels
+	(bucket_id, key, value)
+VALUES
+	(:bucket_id, :key, :value)`
+
+func (s *BucketStore) SetLabels(ctx context.Context, bucketID int32, labels map[string]string) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		return replaceLabels(ctx, tx, bucketID, labels)
+	})
+}
+
+func replaceLabels(ctx context.Context, tx *sqlx.Tx, bucketID int32, labels map[string]string) error {
+	if _, err := tx.NamedExecContext(ctx, removeLabelsSQL, map[string]any{"bucket_id": bucketID}); err != nil {
+		return fmt.Errorf("failed to remove the labels: %w", err)
+	}
+
+	return insertLabels(ctx, tx, bucketID, labels)
+}
+
+func insertLabels(ctx context.Context, tx *sqlx.Tx, bucketID int32, labels map[string]string) error {
+	for k, v := range labels {
+		args := map[string]any{
+			"bucket_id": bucketID,
+			"key":       k,
+			"value":     v,
+		}
+		if _, err := tx.NamedExecContext(ctx, insertLabelSQL, args); err != nil {
+			return fmt.Errorf("failed to insert the label %q: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// filterArgs returns the named arguments holding the filter columns of the bucket.
+func filterArgs(b serverplate.Bucket) map[string]any {
+	return map[string]any{
+		"filter_length_enabled":    b.FilterLengthEnabled,
+		"filter_length_mode":       nullableString(string(b.FilterLengthMode)),
+		"filter_length_value":      nullableInt(b.FilterLengthValue, b.FilterLengthEnabled),
+		"filter_min_length":        nullableInt(b.FilterMinLength, b.FilterMinLength > 0),
+		"filter_adjective_initial": nullableString(b.FilterAdjectiveInitial),
+		"filter_noun_initial":      nullableString(b.FilterNounInitial),
+		"filter_alliterative":      b.FilterAlliterative,
+		"filter_prefix":            nullableString(b.FilterPrefix),
+		"filter_suffix":            nullableString(b.FilterSuffix),
+		"filter_excluded_chars":    nullableString(b.FilterExcludedChars),
+	}
+}
+
+const fillBucketValuesSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	:bucket_id AS bucket_id,
+	a.value || '-' || n.value AS value,
+	ROW_NUMBER() OVER (ORDER BY RANDOM()) AS order_id
+FROM
+	adjectives a
+CROSS JOIN
+	nouns n
+WHERE
+	%s`
+
+func (s *BucketStore) FillBucketValues(
+	ctx context.Context,
+	b serverplate.Bucket,
+	f serverplate.RandomPairFilters,
+) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		whereSQL, args := buildPairFilterWhereSQL(f)
+		args["bucket_id"] = b.ID
+		sql := fmt.Sprintf(fillBucketValuesSQL, whereSQL)
+		if _, err := tx.NamedExecContext(ctx, sql, args); err != nil {
+			return err
+		}
+
+		if err := s.setCursor(ctx, tx, b.ID, 1); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// fillBucketValuesInOrderSQL expands the array of values, the value at index i gets the order id i+1.
+const fillBucketValuesInOrderSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	:bucket_id AS bucket_id,
+	v.value,
+	v.position AS order_id
+FROM
+	unnest(CAST(:values AS TEXT[])) WITH ORDINALITY AS v(value, position)`
+
+func (s *BucketStore) FillBucketValuesInOrder(ctx context.Context, b serverplate.Bucket, values []string) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		args := map[string]any{
+			"bucket_id": b.ID,
+			"values":    pq.Array(values),
+		}
+		if _, err := tx.NamedExecContext(ctx, fillBucketValuesInOrderSQL, args); err != nil {
+			return fmt.Errorf("failed to add the values: %w", err)
+		}
+
+		return s.setCursor(ctx, tx, b.ID, 1)
+	})
+}
+
+// startFillSQL only matches the buckets whose fill is running, a total of zero ends it right away.
+const startFillSQL = `
+UPDATE
+	buckets
+SET
+	fill_total = CASE WHEN CAST(:fill_total AS BIGINT) > 0 THEN CAST(:fill_total AS BIGINT) END,
+	fill_done = 0,
+	cursor = 1,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id
+AND
+	fill_total IS NOT NULL
+AND
+	fill_error IS NULL`
+
+func (s *BucketStore) StartFill(ctx context.Context, b serverplate.Bucket, total int64) error {
+	args := map[string]any{
+		"bucket_id":  b.ID,
+		"fill_total": total,
+	}
+
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		return execFill(ctx, tx, startFillSQL, args)
+	})
+}
+
+// appendFillValuesSQL numbers the values after the ones the fill already wrote, the value at index i of the array
+// gets the order id fill_done+i+1.
+const appendFillValuesSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	b.id AS bucket_id,
+	v.value,
+	b.fill_done + v.position AS order_id
+FROM
+	buckets b,
+	unnest(CAST(:values AS TEXT[])) WITH ORDINALITY AS v(value, position)
+WHERE
+	b.id = :bucket_id`
+
+// advanceFillSQL records the values written, the fill ends once they reach its total.
+const advanceFillSQL = `
+UPDATE
+	buckets
+SET
+	fill_total = CASE WHEN fill_done + :written < fill_total THEN fill_total END,
+	fill_done = fill_done + :written,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id
+AND
+	fill_total IS NOT NULL
+AND
+	fill_error IS NULL`
+
+func (s *BucketStore) AppendFillValues(ctx context.Context, b serverplate.Bucket, values []string) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		args := map[string]any{
+			"bucket_id": b.ID,
+			"values":    pq.Array(values),
+			"written":   len(values),
+		}
+		if _, err := tx.NamedExecContext(ctx, appendFillValuesSQL, args); err != nil {
+			return fmt.Errorf("failed to add the values: %w", err)
+		}
+
+		return execFill(ctx, tx, advanceFillSQL, args)
+	})
+}
+
+// keepFillingSQL only touches updated_at, the progress FailStalledFills looks at.
+const keepFillingSQL = `
+UPDATE
+	buckets
+SET
+	updated_at = NOW()
+WHERE
+	id = :bucket_id
+AND
+	fill_total IS NOT NULL
+AND
+	fill_error IS NULL`
+
+func (s *BucketStore) KeepFilling(ctx context.Context, b serverplate.Bucket) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		return execFill(ctx, tx, keepFillingSQL, map[string]any{"bucket_id": b.ID})
+	})
+}
+
+const failFillSQL = `
+UPDATE
+	buckets
+SET
+	fill_error = :fill_error,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id
+AND
+	fill_total IS NOT NULL
+AND
+	fill_error IS NULL`
+
+func (s *BucketStore) FailFill(ctx context.Context, b serverplate.Bucket, reason string) error {
+	args := map[string]any{
+		"bucket_id":  b.ID,
+		"fill_error": reason,
+	}
+
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		return execFill(ctx, tx, failFillSQL, args)
+	})
+}
+
+// execFill runs a statement changing the fill of a bucket, it returns ErrBucketNotFilling when it changed nothing.
+func execFill(ctx context.Context, tx *sqlx.Tx, query string, args map[string]any) error {
+	r, err := tx.NamedExecContext(ctx, query, args)
+	if err != nil {
+		return err
+	}
+
+	n, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return serverplate.ErrBucketNotFilling
+	}
+
+	return nil
+}
+
+const failStalledFillsSQL = `
+UPDATE
+	buckets
+SET
+	fill_error = 'the fill stopped making progress',
+	updated_at = NOW()
+WHERE
+	fill_total IS NOT NULL
+AND
+	fill_error IS NULL
+AND
+	COALESCE(updated_at, created_at) < :before`
+
+func (s *BucketStore) FailStalledFills(ctx context.Context, before time.Time) (int64, error) {
+	var failed int64
+	err := s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		r, err := tx.NamedExecContext(ctx, failStalledFillsSQL, map[string]any{"before": before})
+		if err != nil {
+			return err
+		}
+
+		failed, err = r.RowsAffected()
+		return err
+	})
+
+	return failed, err
+}
+
+const fillBucketLazilySQL = `
+UPDATE
+	buckets
+SET
+	lazy_position = 0,
+	lazy_remaining = :lazy_remaining,
+	cursor = 1,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id`
+
+func (s *BucketStore) FillBucketLazily(ctx context.Context, b serverplate.Bucket, count int64) error {
+	if b.Seed == nil {
+		return fmt.Errorf("lazy bucket %d has no seed", b.ID)
+	}
+
+	if _, err := s.db.words.get(ctx, s.db, b.WordsVersion); err != nil {
+		return err
+	}
+
+	args := map[string]any{
+		"bucket_id":      b.ID,
+		"lazy_remaining": count,
+	}
+	if _, err := s.db.NamedExecContext(ctx, fillBucketLazilySQL, args); err != nil {
+		return fmt.Errorf("failed to make the bucket lazy: %w", err)
+	}
+
+	return nil
+}
+
+// copyRemainingValuesSQL copies the values the source bucket has not popped yet keeping their order, the first
+// copied value gets the order id 1.
+const copyRemainingValuesSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	:to_bucket_id AS bucket_id,
+	bv.value,
+	bv.order_id - b.cursor + 1 AS order_id
+FROM
+	bucket_values bv
+JOIN
+	buckets b ON b.id = bv.bucket_id
+WHERE
+	bv.bucket_id = :from_bucket_id
+AND
+	bv.order_id >= b.cursor`
+
+// copyLazyStateSQL makes the bucket to continue the walk of the bucket from where it is, it leaves to eager when from
+// is eager.
+const copyLazyStateSQL = `
+UPDATE
+	buckets
+SET
+	lazy_position = (SELECT lazy_position FROM buckets WHERE id = :from_bucket_id),
+	lazy_remaining = (SELECT lazy_remaining FROM buckets WHERE id = :from_bucket_id)
+WHERE
+	id = :to_bucket_id`
+
+func (s *BucketStore) CopyRemainingValues(ctx context.Context, from, to serverplate.Bucket) error {
+	return s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		args := map[string]any{
+			"from_bucket_id": from.ID,
+			"to_bucket_id":   to.ID,
+		}
+		if _, err := tx.NamedExecContext(ctx, copyRemainingValuesSQL, args); err != nil {
+			return fmt.Errorf("failed to copy the remaining values: %w", err)
+		}
+
+		if _, err := tx.NamedExecContext(ctx, copyLazyStateSQL, args); err != nil {
+			return fmt.Errorf("failed to copy the lazy state: %w", err)
+		}
+
+		return s.setCursor(ctx, tx, to.ID, 1)
+	})
+}
+
+const setCursorSQL = `
+UPDATE
+	buckets
+SET
+	cursor = :cursor,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id`
+
+func (s *BucketStore) SetCursor(ctx context.Context, bucketID int32, cursor int32) error {
+	return s.setCursor(ctx, s.db, bucketID, cursor)
+}
+
+func (s *BucketStore) setCursor(
+	ctx context.Context,
+	db sqlx.ExtContext,
+	bucketID int32,
+	cursor int32,
+) error {
+	args := map[string]any{
+		"bucket_id": bucketID,
+		"cursor":    cursor,
+	}
+	if _, err := sqlx.NamedExecContext(ctx, db, setCursorSQL, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// lockBucketCursorSQL locks the bucket row for the rest of the transaction, which serializes the pops and the
+// filter updates of a bucket. Pops had to wait for each other anyway to move the cursor.
+const lockBucketCursorSQL = `
+SELECT
+	cursor
+FROM
+	buckets
+WHERE
+	id = :bucket_id
+FOR UPDATE`
+
+const currentBucketNameValueSQL = `
+SELECT
+	id,
+	value,
+	order_id
+FROM
+	bucket_values
+WHERE
+	bucket_id = :bucket_id
+AND
+	order_id >= :cursor
+ORDER BY
+	order_id ASC
+LIMIT 1`
+
+const advanceCursorSQL = `
+UPDATE
+	buckets
+SET
+	cursor = :next_cursor,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id`
+
+const markPoppedSQL = `
+UPDATE
+	bucket_values
+SET
+	popped_at = NOW()
+WHERE
+	bucket_id = :bucket_id
+AND
+	order_id = :order_id`
+
+// poppedValue is the bucket value handed out by popNextValue.
+type poppedValue struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"value"`
+	OrderID int32  `db:"order_id"`
+}
+
+// popNextValue locks the bucket, advances its cursor past the next value within tx and records the pop time.
+func popNextValue(ctx context.Context, tx *sqlx.Tx, words *wordsCache, bucketID int32) (poppedValue, error) {
+	var row poppedValue
+
+	cursor, err := lockBucketCursor(ctx, tx, bucketID)
+	if err != nil {
+		return row, err
+	}
+
+	if !cursor.Valid {
+		return row, serverplate.ErrBucketExhausted
+	}
+
+	stmt, err := tx.PrepareNamedContext(ctx, currentBucketNameValueSQL)
+	if err != nil {
+		return row, fmt.Errorf("failed to prepare query to retrieve cursor name: %w", err)
+	}
+	defer stmt.Close()
+
+	args := map[string]any{
+		"bucket_id": bucketID,
+		"cursor":    cursor.Int32,
+	}
+	err = stmt.GetContext(ctx, &row, args)
+	if errors.Is(err, sql.ErrNoRows) {
+		row, err = computeLazyValue(ctx, tx, words, bucketID, cursor.Int32)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return row, serverplate.ErrBucketExhausted
+		}
+		return row, fmt.Errorf("failed to retrieve name from the cursor: %w", err)
+	}
+
+	args = map[string]any{
+		"bucket_id":   bucketID,
+		"next_cursor": row.OrderID + 1,
+	}
+	if _, err := tx.NamedExecContext(ctx, advanceCursorSQL, args); err != nil {
+		return row, fmt.Errorf("failed to advance the cursor to the next position: %w", err)
+	}
+
+	args = map[string]any{
+		"bucket_id": bucketID,
+		"order_id":  row.OrderID,
+	}
+	if _, err := tx.NamedExecContext(ctx, markPoppedSQL, args); err != nil {
+		return row, fmt.Errorf("failed to record the pop time: %w", err)
+	}
+
+	return row, nil
+}
+
+const insertLazyValueSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+VALUES
+	(:bucket_id, :value, :order_id)
+RETURNING
+	id`
+
+const advanceLazyPositionSQL = `
+UPDATE
+	buckets
+SET
+	lazy_position = :lazy_position,
+	lazy_remaining = lazy_remaining - 1
+WHERE
+	id = :bucket_id`
+
+// computeLazyValue stores the next name of a lazy bucket at its cursor, for popNextValue to pop it like any other.
+// It is only called with the bucket locked and once it has no stored value left past the cursor, so the cursor is
+// free. It returns sql.ErrNoRows when the bucket is not lazy or has no name left.
+func computeLazyValue(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	words *wordsCache,
+	bucketID int32,
+	cursor int32,
+) (poppedValue, error) {
+	var row bucketRow
+	if err := namedGet(ctx, tx, &row, oneByIDSQL, map[string]any{"id": bucketID}); err != nil {
+		return poppedValue{}, err
+	}
+
+	b := rowToBucket(row)
+	if !b.Lazy || row.LazyRemaining.Int64 <= 0 || b.Seed == nil {
+		return poppedValue{}, sql.ErrNoRows
+	}
+
+	lists, err := words.get(ctx, tx, b.WordsVersion)
+	if err != nil {
+		return poppedValue{}, err
+	}
+
+	name, next, ok := lists.LazyValues(b.Filters(), *b.Seed).Next(row.LazyPosition.Int64)
+	if !ok {
+		return poppedValue{}, sql.ErrNoRows
+	}
+
+	v := poppedValue{Name: name, OrderID: cursor}
+	args := map[string]any{
+		"bucket_id": bucketID,
+		"value":     v.Name,
+		"order_id":  v.OrderID,
+	}
+	if err := namedGet(ctx, tx, &v.ID, insertLazyValueSQL, args); err != nil {
+		return poppedValue{}, fmt.Errorf("failed to store the computed name: %w", err)
+	}
+
+	args = map[string]any{
+		"bucket_id":     bucketID,
+		"lazy_position": next,
+	}
+	if _, err := tx.NamedExecContext(ctx, advanceLazyPositionSQL, args); err != nil {
+		return poppedValue{}, fmt.Errorf("failed to advance the lazy position: %w", err)
+	}
+
+	return v, nil
+}
+
+func (s *BucketStore) PopName(ctx context.Context, b serverplate.Bucket) (string, error) {
+	var row poppedValue
+
+	err := s.db.WithTx(
+		ctx,
+		&sql.TxOptions{},
+		func(ctx context.Context, tx *sqlx.Tx) error {
+			var err error
+			row, err = popNextValue(ctx, tx, &s.db.words, b.ID)
+			return err
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return row.Name, nil
+}
+
+const recentlyPoppedSQL = `
+SELECT
+	bv.value,
+	bv.popped_at
+FROM
+	bucket_values bv
+JOIN
+	buckets b ON b.id = bv.bucket_id
+WHERE
+	bv.bucket_id = :bucket_id
+AND
+	bv.order_id < b.cursor
+ORDER BY
+	bv.order_id DESC
+LIMIT :limit`
+
+func (s *BucketStore) RecentlyPopped(
+	ctx context.Context,
+	b serverplate.Bucket,
+	limit int,
+) ([]serverplate.PoppedName, error) {
+	stmt, err := s.db.PrepareNamedContext(ctx, recentlyPoppedSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rows []struct {
+		Value    string       `db:"value"`
+		PoppedAt sql.NullTime `db:"popped_at"`
+	}
+	args := map[string]any{
+		"bucket_id": b.ID,
+		"limit":     limit,
+	}
+	if err := stmt.SelectContext(ctx, &rows, args); err != nil {
+		return nil, err
+	}
+
+	names := make([]serverplate.PoppedName, 0, len(rows))
+	for _, r := range rows {
+		names = append(names, serverplate.PoppedName{
+			Name:     r.Value,
+			PoppedAt: sqlTimeToPtr(r.PoppedAt),
+		})
+	}
+
+	return names, nil
+}
+
+func lockBucketCursor(ctx context.Context, tx *sqlx.Tx, bucketID int32) (sql.NullInt32, error) {
+	stmt, err := tx.PrepareNamedContext(ctx, lockBucketCursorSQL)
+	if err != nil {
+		return sql.NullInt32{}, fmt.Errorf("failed to prepare query to lock the bucket: %w", err)
+	}
+	defer stmt.Close()
+
+	var cursor sql.NullInt32
+	if err := stmt.GetContext(ctx, &cursor, map[string]any{"bucket_id": bucketID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullInt32{}, serverplate.ErrBucketNotFound
+		}
+		return sql.NullInt32{}, fmt.Errorf("failed to lock the bucket: %w", err)
+	}
+
+	return cursor, nil
+}
+
+const removeRemainingValuesSQL = `
+DELETE FROM
+	bucket_values
+WHERE
+	bucket_id = :bucket_id
+AND
+	order_id >= :cursor`
+
+// refillBucketValuesSQL runs once the remaining values are removed, so the values left in the bucket are the popped
+// ones and must not be added again.
+const refillBucketValuesSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	:bucket_id AS bucket_id,
+	a.value || '-' || n.value AS value,
+	CAST(:cursor AS INTEGER) - 1 + ROW_NUMBER() OVER (ORDER BY RANDOM()) AS order_id
+FROM
+	adjectives a
+CROSS JOIN
+	nouns n
+WHERE
+	%s
+AND
+	NOT EXISTS (
+		SELECT
+			1
+		FROM
+			bucket_values bv
+		WHERE
+			bv.bucket_id = :bucket_id
+		AND
+			bv.value = a.value || '-' || n.value
+	)`
+
+// refillSeededBucketValuesSQL is refillBucketValuesSQL for the seeded buckets, the values are given in the order of
+// the seed so the bucket stays reproducible from it.
+const refillSeededBucketValuesSQL = `
+INSERT INTO bucket_values
+	(bucket_id, value, order_id)
+SELECT
+	:bucket_id AS bucket_id,
+	v.value,
+	CAST(:cursor AS INTEGER) - 1 + ROW_NUMBER() OVER (ORDER BY v.position) AS order_id
+FROM
+	unnest(CAST(:values AS TEXT[])) WITH ORDINALITY AS v(value, position)
+WHERE
+	NOT EXISTS (
+		SELECT
+			1
+		FROM
+			bucket_values bv
+		WHERE
+			bv.bucket_id = :bucket_id
+		AND
+			bv.value = v.value
+	)`
+
+const updateBucketFiltersSQL = `
+UPDATE
+	buckets
+SET
+	filter_length_enabled = :filter_length_enabled,
+	filter_length_mode = :filter_length_mode,
+	filter_length_value = :filter_length_value,
+	filter_min_length = :filter_min_length,
+	filter_adjective_initial = :filter_adjective_initial,
+	filter_noun_initial = :filter_noun_initial,
+	filter_alliterative = :filter_alliterative,
+	filter_prefix = :filter_prefix,
+	filter_suffix = :filter_suffix,
+	filter_excluded_chars = :filter_excluded_chars,
+	cursor = :cursor,
+	updated_at = NOW()
+WHERE
+	id = :bucket_id`
+
+func (s *BucketStore) UpdateFilters(ctx context.Context, b *serverplate.Bucket) (int64, error) {
+	var delta int64
+
+	err := s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		var err error
+		delta, err = updateFilters(ctx, tx, &s.db.words, b)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return delta, nil
+}
+
+// updateFilters persists the filters of b within tx and replaces the values past the cursor, see UpdateFilters.
+func updateFilters(ctx context.Context, tx *sqlx.Tx, words *wordsCache, b *serverplate.Bucket) (int64, error) {
+	locked, err := lockBucketCursor(ctx, tx, b.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	cursor := int32(1)
+	if locked.Valid {
+		cursor = locked.Int32
+	}
+
+	var delta int64
+	if b.Lazy {
+		delta, err = updateLazyFilters(ctx, tx, words, *b, cursor)
+	} else {
+		delta, err = refillBucketValues(ctx, tx, words, *b, cursor)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	updateArgs := filterArgs(*b)
+	updateArgs["bucket_id"] = b.ID
+	updateArgs["cursor"] = cursor
+	if _, err := tx.NamedExecContext(ctx, updateBucketFiltersSQL, updateArgs); err != nil {
+		return 0, fmt.Errorf("failed to update the filters: %w", err)
+	}
+
+	b.Cursor = cursor
+	return delta, nil
+}
+
+// refillBucketValues replaces the values past the cursor by every name matching the filters of b that was not
+// popped yet, shuffled or in the order of the seed of b. It returns the change in the remaining values.
+func refillBucketValues(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	words *wordsCache,
+	b serverplate.Bucket,
+	cursor int32,
+) (int64, error) {
+	args := map[string]any{
+		"bucket_id": b.ID,
+		"cursor":    cursor,
+	}
+	removed, err := tx.NamedExecContext(ctx, removeRemainingValuesSQL, args)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove the remaining values: %w", err)
+	}
+
+	var added sql.Result
+	if b.Seed != nil {
+		added, err = refillSeededBucketValues(ctx, tx, words, b, cursor)
+	} else {
+		whereSQL, fillArgs := buildPairFilterWhereSQL(b.Filters())
+		fillArgs["bucket_id"] = b.ID
+		fillArgs["cursor"] = cursor
+		added, err = tx.NamedExecContext(ctx, fmt.Sprintf(refillBucketValuesSQL, whereSQL), fillArgs)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to add the values matching the filters: %w", err)
+	}
+
+	removedCount, err := removed.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	addedCount, err := added.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return addedCount - removedCount, nil
+}
+
+// refillSeededBucketValues adds the names matching the filters of b that were not popped yet in the order of its
+// seed, the word lists must still be the ones of the bucket.
+func refillSeededBucketValues(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	words *wordsCache,
+	b serverplate.Bucket,
+	cursor int32,
+) (sql.Result, error) {
+	lists, err := words.get(ctx, tx, b.WordsVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]any{
+		"bucket_id": b.ID,
+		"cursor":    cursor,
+	}
+	args["values"] = pq.Array(lists.SeededNames(b.Filters(), *b.Seed))
+
+	return tx.NamedExecContext(ctx, refillSeededBucketValuesSQL, args)
+}
+
+const lazyStateSQL = `
+SELECT
+	lazy_position,
+	lazy_remaining
+FROM
+	buckets
+WHERE
+	id = :bucket_id`
+
+const storedRemainingValuesSQL = `
+SELECT
+	id,
+	value
+FROM
+	bucket_values
+WHERE
+	bucket_id = :bucket_id
+AND
+	order_id >= :cursor`
+
+const removeValueSQL = `DELETE FROM bucket_values WHERE id = :id`
+
+const setLazyRemainingSQL = `
+UPDATE
+	buckets
+SET
+	lazy_remaining = :lazy_remaining
+WHERE
+	id = :bucket_id`
+
+// updateLazyFilters counts the names matching the filters of b that the walk of the lazy bucket has not reached yet
+// and removes the stored values past the cursor, the names of expired leases, that do not match them. It returns
+// the change in the remaining values.
+func updateLazyFilters(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	words *wordsCache,
+	b serverplate.Bucket,
+	cursor int32,
+) (int64, error) {
+	if b.Seed == nil {
+		return 0, fmt.Errorf("lazy bucket %d has no seed", b.ID)
+	}
+
+	var state struct {
+		Position  int64 `db:"lazy_position"`
+		Remaining int64 `db:"lazy_remaining"`
+	}
+	if err := namedGet(ctx, tx, &state, lazyStateSQL, map[string]any{"bucket_id": b.ID}); err != nil {
+		return 0, fmt.Errorf("failed to retrieve the lazy state: %w", err)
+	}
+
+	lists, err := words.get(ctx, tx, b.WordsVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	l := lists.LazyValues(b.Filters(), *b.Seed)
+	remaining := l.Count(state.Position)
+	args := map[string]any{
+		"bucket_id":      b.ID,
+		"lazy_remaining": remaining,
+	}
+	if _, err := tx.NamedExecContext(ctx, setLazyRemainingSQL, args); err != nil {
+		return 0, fmt.Errorf("failed to update the lazy remaining values: %w", err)
+	}
+
+	stmt, err := tx.PrepareNamedContext(ctx, storedRemainingValuesSQL)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var stored []struct {
+		ID    int64  `db:"id"`
+		Value string `db:"value"`
+	}
+	if err := stmt.SelectContext(ctx, &stored, map[string]any{"bucket_id": b.ID, "cursor": cursor}); err != nil {
+		return 0, fmt.Errorf("failed to list the remaining values: %w", err)
+	}
+
+	delta := remaining - state.Remaining
+	for _, v := range stored {
+		if l.Match(v.Value) {
+			continue
+		}
+
+		if _, err := tx.NamedExecContext(ctx, removeValueSQL, map[string]any{"id": v.ID}); err != nil {
+			return 0, fmt.Errorf("failed to remove the value %q: %w", v.Value, err)
+		}
+		delta--
+	}
+
+	return delta, nil
+}
+
+// bucketColumnsSQL selects the columns of the bucket aliased as b, including its labels
+// aggregated in a JSON object.
+const bucketColumnsSQL = `
+	b.id,
+	b.name,
+	b.description,
+	b.cursor,
+	b.archived_at,
+	b.created_at,
+	b.updated_at,
+	b.filter_length_enabled,
+	b.filter_length_mode,
+	b.filter_length_value,
+	b.filter_min_length,
+	b.filter_adjective_initial,
+	b.filter_noun_initial,
+	b.filter_alliterative,
+	b.filter_prefix,
+	b.filter_suffix,
+	b.filter_excluded_chars,
+	b.retention_seconds,
+	b.expires_at,
+	b.archive_when_exhausted,
+	b.seed,
+	b.words_version,
+	b.lazy_position,
+	b.lazy_remaining,
+	b.fill_total,
+	b.fill_done,
+	b.fill_error,
+	COALESCE(
+		(
+			SELECT
+				json_object_agg(bl.key, bl.value)
+			FROM
+				bucket_labels bl
+			WHERE
+				bl.bucket_id = b.id
+		),
+		'{}'
+	) AS labels`
+
+const oneByNameSQL = `
+SELECT` + bucketColumnsSQL + `
+FROM
+	buckets b
+WHERE
+	b.name = :name`
+
+func (s *BucketStore) OneByName(ctx context.Context, name string) (serverplate.Bucket, error) {
+	return s.one(ctx, oneByNameSQL, map[string]any{"name": name})
+}
+
+const oneByIDSQL = `
+SELECT` + bucketColumnsSQL + `
+FROM
+	buckets b
+WHERE
+	b.id = :id`
+
+func (s *BucketStore) OneByID(ctx context.Context, id int32) (serverplate.Bucket, error) {
+	return s.one(ctx, oneByIDSQL, map[string]any{"id": id})
+}
+
+func (s *BucketStore) one(
+	ctx context.Context,
+	query string,
+	args map[string]any,
+) (serverplate.Bucket, error) {
+	stmt, err := s.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return serverplate.Bucket{}, err
+	}
+	defer stmt.Close()
+
+	var row bucketRow
+	if err := stmt.GetContext(ctx, &row, args); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return serverplate.Bucket{}, serverplate.ErrBucketNotFound
+		}
+		return serverplate.Bucket{}, err
+	}
+
+	return rowToBucket(row), nil
+}
+
+// listRemainingValuesSQL counts the remaining values of every listed bucket in the same query, the names of lazy
+// buckets that were not computed yet included.
+const listRemainingValuesSQL = `(
+	COALESCE(b.lazy_remaining, 0) + (
+		SELECT
+			count(*)
+		FROM
+			bucket_values bv
+		WHERE
+			bv.bucket_id = b.id
+		AND
+			bv.order_id >= b.cursor
+	)
+)`
+
+const labelSelectorSQLTpl = `EXISTS (
+	SELECT
+		1
+	FROM
+		bucket_labels bl
+	WHERE
+		bl.bucket_id = b.id
+	AND
+		bl.key = :label_key_%d
+	AND
+		bl.value = :label_value_%d
+)`
+
+var listSortColumns = map[serverplate.BucketSortField]string{
+	serverplate.BucketSortName:      "b.name",
+	serverplate.BucketSortCreatedAt: "b.created_at",
+	serverplate.BucketSortUpdatedAt: "COALESCE(b.updated_at, b.created_at)",
+}
+
+const listBucketsSQLTpl = `
+SELECT` + bucketColumnsSQL + `,
+	%s AS remaining_values
+FROM
+	buckets b
+WHERE
+	%s
+ORDER BY
+	%s
+%s`
+
+func (s *BucketStore) List(
+	ctx context.Context,
+	opts serverplate.ListOptions,
+) ([]serverplate.Bucket, error) {
+	wheres := []string{"1=1"}
+	args := map[string]any{}
+
+	if opts.ArchivedOnly {
+		wheres = append(wheres, "b.archived_at IS NOT NULL")
+	} else {
+		wheres = append(wheres, "b.archived_at IS NULL")
+	}
+
+	for i, k := range slices.Sorted(maps.Keys(opts.LabelSelector)) {
+		wheres = append(wheres, fmt.Sprintf(labelSelectorSQLTpl, i, i))
+		args[fmt.Sprintf("label_key_%d", i)] = k
+		args[fmt.Sprintf("label_value_%d", i)] = opts.LabelSelector[k]
+	}
+
+	if opts.Search != "" {
+		wheres = append(wheres, `(b.name ILIKE :search ESCAPE '\' OR b.description ILIKE :search ESCAPE '\')`)
+		args["search"] = containsPattern(opts.Search)
+	}
+
+	direction := "ASC"
+	if opts.SortDesc {
+		direction = "DESC"
+	}
+	orderBy := "b.id " + direction
+	if column, ok := listSortColumns[opts.SortBy]; ok {
+		orderBy = column + " " + direction + ", " + orderBy
+	}
+
+	var pagination []string
+	if opts.Limit > 0 {
+		pagination = append(pagination, "LIMIT :limit")
+		args["limit"] = opts.Limit
+	}
+	if opts.Offset > 0 {
+		pagination = append(pagination, "OFFSET :offset")
+		args["offset"] = opts.Offset
+	}
+
+	remainingSQL := "0"
+	if opts.IncludeRemaining {
+		remainingSQL = listRemainingValuesSQL
+	}
+
+	query := fmt.Sprintf(
+		listBucketsSQLTpl,
+		remainingSQL,
+		strings.Join(wheres, " AND "),
+		orderBy,
+		strings.Join(pagination, " "),
+	)
+	stmt, err := s.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rows []bucketRow
+	if err := stmt.SelectContext(ctx, &rows, args); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]serverplate.Bucket, 0, len(rows))
+	for _, r := range rows {
+		buckets = append(buckets, rowToBucket(r))
+	}
+
+	return buckets, nil
+}
+
+const saveBucketSQL = `
+UPDATE
+	buckets
+SET
+	name = :name,
+	description = :description,
+	archived_at = :archived_at,
+	retention_seconds = :retention_seconds,
+	expires_at = :expires_at,
+	archive_when_exhausted = :archive_when_exhausted,
+	updated_at = NOW()
+WHERE
+	id = :id`
+
+func (s *BucketStore) Save(ctx context.Context, b *serverplate.Bucket) error {
+	return saveBucket(ctx, s.db, b)
+}
+
+// saveBucket persists the fields of b written by Save through e, which can be the transaction of UpdateBucket.
+func saveBucket(ctx context.Context, e sqlx.ExtContext, b *serverplate.Bucket) error {
+	params := map[string]any{
+		"id":                     b.ID,
+		"name":                   b.Name,
+		"archived_at":            b.ArchivedAt,
+		"description":            b.Description,
+		"retention_seconds":      retentionSeconds(b.Retention),
+		"expires_at":             b.ExpiresAt,
+		"archive_when_exhausted": b.ArchiveWhenExhausted,
+	}
+	if _, err := sqlx.NamedExecContext(ctx, e, saveBucketSQL, params); err != nil {
+		if isUniqueViolation(err) {
+			return serverplate.ErrBucketNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *BucketStore) UpdateBucket(
+	ctx context.Context,
+	b *serverplate.Bucket,
+	opts serverplate.UpdateOptions,
+) (int64, error) {
+	var delta int64
+
+	err := s.db.WithTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := saveBucket(ctx, tx, b); err != nil {
+			return err
+		}
+
+		if opts.Labels {
+			if err := replaceLabels(ctx, tx, b.ID, b.Labels); err != nil {
+				return err
+			}
+		}
+
+		if opts.Filters {
+			var err error
+			delta, err = updateFilters(ctx, tx, &s.db.words, b)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return delta, nil
+}
+
+const archiveBucketSQL = `
+UPDATE
+	buckets
+SET
+	archived_at = :archived_at,
+	updated_at = NOW()
+WHERE
+	id = :id
+AND
+	archived_at IS NULL`
+
+func (s *BucketStore) Archive(ctx context.Context, id int32, at time.Time) error {
+	params := map[string]any{
+		"id":          id,
+		"archived_at": at,
+	}
+	result, err := s.db.NamedExecContext(ctx, archiveBucketSQL, params)
+	if err != nil {
+		return err
+	}
+
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if archived == 0 {
+		return serverplate.ErrBucketNotFound
+	}
+
+	return nil
+}
+
+// deleteArchivedBucketSQL relies on the foreign keys cascading the deletion of the bucket values and labels.
+const deleteArchivedBucketSQL = `DELETE FROM buckets WHERE id = :id AND archived_at IS NOT NULL`
+
+func (s *BucketStore) DeleteArchived(ctx context.Context, id int32) error {
+	result, err := s.db.NamedExecContext(ctx, deleteArchivedBucketSQL, map[string]any{"id": id})
+	if err != nil {
+		return err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return serverplate.ErrBucketNotFound
+	}
+
+	return nil
+}
+
+const remainingValuesSQL = `
+SELECT
+	COALESCE((SELECT lazy_remaining FROM buckets WHERE id = :id), 0) + (
+		SELECT
+			count(*)
+		FROM
+			bucket_values bv
+		JOIN
+			buckets b ON b.id = bv.bucket_id
+		WHERE
+			bv.bucket_id = :id
+		AND
+			bv.order_id >= b.cursor
+	) AS count`
+
+func (s *BucketStore) RemainingValuesTotal(
+	ctx context.Context,
+	b serverplate.Bucket,
+) (int64, error) {
+	stmt, err := s.db.PrepareNamedContext(ctx, remainingValuesSQL)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.GetContext(ctx, &count, map[string]any{"id": b.ID}); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func rowToBucket(row bucketRow) serverplate.Bucket {
+	return serverplate.Bucket{
+		ID:                  row.ID,
+		Name:                row.Name,
+		Description:         row.Description.String,
+		Cursor:              row.Cursor.Int32,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           sqlTimeToPtr(row.UpdatedAt),
+		ArchivedAt:          sqlTimeToPtr(row.ArchivedAt),
+		FilterLengthEnabled: row.FilterLengthEnabled,
+		FilterLengthMode:    serverplate.LengthMode(row.FilterLengthMode.String),
+		FilterLengthValue:   int(row.FilterLengthValue.Int32),
+
+		FilterMinLength:        int(row.FilterMinLength.Int32),
+		FilterAdjectiveInitial: row.FilterAdjInitial.String,
+		FilterNounInitial:      row.FilterNounInitial.String,
+		FilterAlliterative:     row.FilterAlliterative,
+		FilterPrefix:           row.FilterPrefix.String,
+		FilterSuffix:           row.FilterSuffix.String,
+		FilterExcludedChars:    row.FilterExcludedChars.String,
+		RemainingValues:        row.RemainingValues,
+		Labels:                 row.Labels,
+		Retention:              retentionFromSeconds(row.RetentionSeconds),
+		ExpiresAt:              sqlTimeToPtr(row.ExpiresAt),
+		ArchiveWhenExhausted:   row.ArchiveWhenExhausted,
+		Seed:                   sqlInt64ToPtr(row.Seed),
+		WordsVersion:           row.WordsVersion.String,
+		Lazy:                   row.LazyPosition.Valid,
+		Fill:                   rowToFill(row),
+	}
+}
+
+func rowToFill(row bucketRow) *serverplate.BucketFill {
+	if !row.FillTotal.Valid {
+		return nil
+	}
+
+	return &serverplate.BucketFill{
+		Total: row.FillTotal.Int64,
+		Done:  row.FillDone,
+		Error: row.FillError.String,
+	}
+}
@@ -0,0 +1,86 @@
+package pgstore_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/davidonium/serverplate/internal/dbtesting"
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/davidonium/serverplate/internal/store/pgstore"
+	"github.com/davidonium/serverplate/internal/store/storetest"
+)
+
+func TestBucketStore(t *testing.T) {
+	dbtesting.RunPostgres(t, func(t *testing.T, db *pgstore.DB) {
+		ctx := context.Background()
+		logger := slog.New(slog.DiscardHandler)
+		store := pgstore.NewBucketStore(logger, db)
+
+		b := &serverplate.Bucket{
+			Name: "test-bucket",
+		}
+
+		if err := store.Create(ctx, b); err != nil {
+			t.Errorf("Create() = expected to succeed but got err: %v", err)
+		}
+
+		if err := store.Create(ctx, b); err == nil {
+			t.Error(
+				"Create() = expected to fail when creating a bucket with an already existing name but succeeded",
+			)
+		}
+
+		bk, err := store.OneByName(ctx, "test-bucket")
+		if err != nil {
+			t.Errorf("OneByName() = expected to retrieve an existing bucket but failed: %v", err)
+		}
+
+		if bk.Name != "test-bucket" {
+			t.Errorf(
+				"OneByName() = unexpected bucket name retrieved. got '%s' want '%s'",
+				bk.Name,
+				"test-bucket",
+			)
+		}
+	})
+}
+
+func newConformanceStores(t *testing.T, adjectives, nouns []string) storetest.Stores {
+	db := dbtesting.NewPostgres(t)
+	dbtesting.SeedWords(t, db.DB, adjectives, nouns)
+
+	logger := slog.New(slog.DiscardHandler)
+	return storetest.Stores{
+		Buckets:  pgstore.NewBucketStore(logger, db),
+		Pairs:    pgstore.NewPairStore(db),
+		JobRuns:  pgstore.NewJobRunStore(db),
+		JobLocks: pgstore.NewJobLockStore(db),
+		Leases:   pgstore.NewLeaseStore(db),
+		Audit:    pgstore.NewAuditStore(db),
+	}
+}
+
+func TestBucketStoreConformance(t *testing.T) {
+	storetest.RunBucketStoreSuite(t, newConformanceStores)
+}
+
+func TestPairStoreConformance(t *testing.T) {
+	storetest.RunPairStoreSuite(t, newConformanceStores)
+}
+
+func TestJobRunStoreConformance(t *testing.T) {
+	storetest.RunJobRunStoreSuite(t, newConformanceStores)
+}
+
+func TestJobLockStoreConformance(t *testing.T) {
+	storetest.RunJobLockStoreSuite(t, newConformanceStores)
+}
+
+func TestLeaseStoreConformance(t *testing.T) {
+	storetest.RunLeaseStoreSuite(t, newConformanceStores)
+}
+
+func TestAuditStoreConformance(t *testing.T) {
+	storetest.RunAuditStoreSuite(t, newConformanceStores)
+}
@@ -0,0 +1,67 @@
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime"
+
+	"github.com/jmoiron/sqlx"
+	// register the postgres driver used by sqlx.
+	_ "github.com/lib/pq"
+)
+
+// Connect opens a connection pool to the postgres database pointed by connStr. The url must use the `postgres://`
+// or `postgresql://` scheme to make it compatible with both dbmate and the sql driver.
+func Connect(ctx context.Context, connStr string) (*DB, error) {
+	db, err := sqlx.ConnectContext(ctx, "postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+	}
+
+	// unlike sqlite, postgres handles concurrent writers so a single pool is shared by reads and writes.
+	db.SetMaxOpenConns(max(4, runtime.NumCPU()*2))
+	db.SetMaxIdleConns(max(2, runtime.NumCPU()))
+
+	return &DB{DB: db}, nil
+}
+
+// DB is a thin wrapper around *sqlx.DB that adds transaction helpers.
+type DB struct {
+	*sqlx.DB
+	// words is shared by the stores popping names of lazy buckets.
+	words wordsCache
+}
+
+// WithTx executes a function within a transaction, committing it if f succeeds and rolling it back otherwise.
+func (d *DB) WithTx(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	f func(context.Context, *sqlx.Tx) error,
+) (err error) {
+	tx, err := d.BeginTxx(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = fmt.Errorf(
+					"failed to rollback on tx error: %v - source: %w",
+					txErr,
+					err,
+				)
+			}
+			return
+		}
+
+		if txErr := tx.Commit(); txErr != nil {
+			err = fmt.Errorf("failed to commit tx: %w", txErr)
+		}
+	}()
+
+	err = f(ctx, tx)
+
+	return
+}
@@ -0,0 +1,57 @@
+package pgstore
+
+import (
+	"context"
+	"time"
+)
+
+type JobLockStore struct {
+	db *DB
+}
+
+func NewJobLockStore(db *DB) *JobLockStore {
+	return &JobLockStore{db: db}
+}
+
+// acquireJobLockSQL only replaces the lock of another owner once it expired, no row is affected otherwise. The clock of
+// the database is used so that the instances agree on the expiry regardless of their own clocks.
+const acquireJobLockSQL = `
+INSERT INTO job_locks
+	(job, owner, expires_at)
+VALUES
+	(:job, :owner, now() + make_interval(secs => :ttl_seconds))
+ON CONFLICT (job) DO UPDATE SET
+	owner = excluded.owner,
+	expires_at = excluded.expires_at
+WHERE
+	job_locks.owner = excluded.owner
+OR
+	job_locks.expires_at <= now()`
+
+func (s *JobLockStore) Acquire(ctx context.Context, job, owner string, ttl time.Duration) (bool, error) {
+	r, err := s.db.NamedExecContext(ctx, acquireJobLockSQL, map[string]any{
+		"job":         job,
+		"owner":       owner,
+		"ttl_seconds": ttl.Seconds(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	acquired, err := r.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return acquired > 0, nil
+}
+
+const releaseJobLockSQL = `DELETE FROM job_locks WHERE job = :job AND owner = :owner`
+
+func (s *JobLockStore) Release(ctx context.Context, job, owner string) error {
+	_, err := s.db.NamedExecContext(ctx, releaseJobLockSQL, map[string]any{
+		"job":   job,
+		"owner": owner,
+	})
+	return err
+}
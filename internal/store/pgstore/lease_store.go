@@ -0,0 +1,290 @@
+package pgstore
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type leaseRow struct {
+	ID          string       `db:"id"`
+	BucketID    int32        `db:"bucket_id"`
+	Name        string       `db:"value"`
+	ConfirmedAt sql.NullTime `db:"confirmed_at"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
+
+func rowToLease(r leaseRow) serverplate.Lease {
+	return serverplate.Lease{
+		ID:          r.ID,
+		BucketID:    r.BucketID,
+		Name:        r.Name,
+		ConfirmedAt: sqlTimeToPtr(r.ConfirmedAt),
+		ExpiresAt:   r.ExpiresAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+type LeaseStore struct {
+	db *DB
+}
+
+func NewLeaseStore(db *DB) *LeaseStore {
+	return &LeaseStore{db: db}
+}
+
+const createLeaseSQL = `
+INSERT INTO bucket_leases
+	(id, bucket_id, value_id, expires_at, created_at)
+VALUES
+	(:id, :bucket_id, :value_id, :expires_at, :created_at)`
+
+func (s *LeaseStore) Reserve(ctx context.Context, b serverplate.Bucket, ttl time.Duration) (serverplate.Lease, error) {
+	now := time.Now()
+	l := serverplate.Lease{
+		ID:        rand.Text(),
+		BucketID:  b.ID,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+
+	err := s.db.WithTx(
+		ctx,
+		&sql.TxOptions{},
+		func(ctx context.Context, tx *sqlx.Tx) error {
+			v, err := popNextValue(ctx, tx, &s.db.words, b.ID)
+			if err != nil {
+				return err
+			}
+			l.Name = v.Name
+
+			args := map[string]any{
+				"id":         l.ID,
+				"bucket_id":  l.BucketID,
+				"value_id":   v.ID,
+				"expires_at": l.ExpiresAt,
+				"created_at": l.CreatedAt,
+			}
+			if _, err := tx.NamedExecContext(ctx, createLeaseSQL, args); err != nil {
+				return fmt.Errorf("failed to create the lease: %w", err)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return serverplate.Lease{}, err
+	}
+
+	return l, nil
+}
+
+const leaseByIDSQL = `
+SELECT
+	bl.id,
+	bl.bucket_id,
+	bv.value,
+	bl.confirmed_at,
+	bl.expires_at,
+	bl.created_at
+FROM
+	bucket_leases bl
+JOIN
+	bucket_values bv ON bv.id = bl.value_id
+WHERE
+	bl.id = :id
+FOR UPDATE OF bl`
+
+const confirmLeaseSQL = `
+UPDATE
+	bucket_leases
+SET
+	confirmed_at = :confirmed_at
+WHERE
+	id = :id`
+
+func (s *LeaseStore) Confirm(ctx context.Context, id string) (serverplate.Lease, error) {
+	var l serverplate.Lease
+
+	err := s.db.WithTx(
+		ctx,
+		&sql.TxOptions{},
+		func(ctx context.Context, tx *sqlx.Tx) error {
+			stmt, err := tx.PrepareNamedContext(ctx, leaseByIDSQL)
+			if err != nil {
+				return fmt.Errorf("failed to prepare query to retrieve the lease: %w", err)
+			}
+			defer stmt.Close()
+
+			var row leaseRow
+			if err := stmt.GetContext(ctx, &row, map[string]any{"id": id}); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return serverplate.ErrLeaseNotFound
+				}
+				return fmt.Errorf("failed to retrieve the lease: %w", err)
+			}
+			l = rowToLease(row)
+
+			now := time.Now()
+			if l.Confirmed() {
+				return nil
+			}
+			if l.Expired(now) {
+				return serverplate.ErrLeaseExpired
+			}
+
+			args := map[string]any{
+				"id":           id,
+				"confirmed_at": now,
+			}
+			if _, err := tx.NamedExecContext(ctx, confirmLeaseSQL, args); err != nil {
+				return fmt.Errorf("failed to confirm the lease: %w", err)
+			}
+			l.ConfirmedAt = &now
+
+			return nil
+		},
+	)
+	if err != nil {
+		return serverplate.Lease{}, err
+	}
+
+	return l, nil
+}
+
+type expiredLeaseRow struct {
+	leaseRow
+	ValueID int64 `db:"value_id"`
+}
+
+const expiredLeasesSQL = `
+SELECT
+	bl.id,
+	bl.bucket_id,
+	bl.value_id,
+	bv.value,
+	bl.confirmed_at,
+	bl.expires_at,
+	bl.created_at
+FROM
+	bucket_leases bl
+JOIN
+	bucket_values bv ON bv.id = bl.value_id
+WHERE
+	bl.confirmed_at IS NULL
+AND
+	bl.expires_at <= :now
+FOR UPDATE OF bl`
+
+// returnLeasedValueSQL moves the value after the last one of the bucket, which is always past the cursor, so that it
+// is popped again once the stored names that were already waiting are handed out. Lazy buckets only store the names
+// they handed out, so it is popped next there.
+const returnLeasedValueSQL = `
+UPDATE
+	bucket_values
+SET
+	order_id = (SELECT MAX(order_id) + 1 FROM bucket_values WHERE bucket_id = :bucket_id),
+	popped_at = NULL,
+	updated_at = NOW()
+WHERE
+	id = :value_id`
+
+// removeExpiredLeasesSQL keeps the confirmed leases, confirming them again still returns them.
+const removeExpiredLeasesSQL = `
+DELETE FROM
+	bucket_leases
+WHERE
+	confirmed_at IS NULL
+AND
+	expires_at <= :now`
+
+func (s *LeaseStore) ReturnExpired(ctx context.Context) ([]serverplate.Lease, error) {
+	now := time.Now()
+
+	var returned []serverplate.Lease
+	err := s.db.WithTx(
+		ctx,
+		&sql.TxOptions{},
+		func(ctx context.Context, tx *sqlx.Tx) error {
+			stmt, err := tx.PrepareNamedContext(ctx, expiredLeasesSQL)
+			if err != nil {
+				return fmt.Errorf("failed to prepare query to list the expired leases: %w", err)
+			}
+			defer stmt.Close()
+
+			var rows []expiredLeaseRow
+			if err := stmt.SelectContext(ctx, &rows, map[string]any{"now": now}); err != nil {
+				return fmt.Errorf("failed to list the expired leases: %w", err)
+			}
+
+			for _, r := range rows {
+				// the bucket is locked like when popping so that the value does not move while a name is handed out.
+				if _, err := lockBucketCursor(ctx, tx, r.BucketID); err != nil {
+					return err
+				}
+
+				var br bucketRow
+				if err := namedGet(ctx, tx, &br, oneByIDSQL, map[string]any{"id": r.BucketID}); err != nil {
+					return fmt.Errorf("failed to retrieve the bucket of the lease: %w", err)
+				}
+				// the lease is forgotten all the same, the name stays handed out.
+				if b := rowToBucket(br); b.Archived() || !b.Filters().MatchName(r.Name) {
+					continue
+				}
+
+				args := map[string]any{
+					"bucket_id": r.BucketID,
+					"value_id":  r.ValueID,
+				}
+				if _, err := tx.NamedExecContext(ctx, returnLeasedValueSQL, args); err != nil {
+					return fmt.Errorf("failed to return the leased name to the bucket: %w", err)
+				}
+				returned = append(returned, rowToLease(r.leaseRow))
+			}
+
+			if _, err := tx.NamedExecContext(ctx, removeExpiredLeasesSQL, map[string]any{"now": now}); err != nil {
+				return fmt.Errorf("failed to remove the expired leases: %w", err)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return returned, nil
+}
+
+const countPendingLeasesSQL = `
+SELECT
+	count(*)
+FROM
+	bucket_leases
+WHERE
+	bucket_id = :bucket_id
+AND
+	confirmed_at IS NULL`
+
+func (s *LeaseStore) CountPending(ctx context.Context, bucketID int32) (int64, error) {
+	stmt, err := s.db.PrepareNamedContext(ctx, countPendingLeasesSQL)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.GetContext(ctx, &count, map[string]any{"bucket_id": bucketID}); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,324 @@
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type PairStore struct {
+	db *DB
+}
+
+func NewPairStore(db *DB) *PairStore {
+	return &PairStore{db: db}
+}
+
+// pairDraws is the number of pairs drawn by drawPairSQLTpl, the more selective the filters the more likely none of
+// them matches and OneRandom falls back to counting the pairs.
+const pairDraws = 512
+
+// drawPairSQLTpl draws pairs uniformly among every pair, matching or not, and returns the first one matching the
+// filters, which makes it uniform among the matching pairs too.
+const drawPairSQLTpl = `
+WITH
+	draws AS (
+		SELECT
+			i,
+			CAST(FLOOR(RANDOM() * (SELECT count(*) FROM adjectives)) AS BIGINT) AS adjective_pos,
+			CAST(FLOOR(RANDOM() * (SELECT count(*) FROM nouns)) AS BIGINT) AS noun_pos
+		FROM
+			generate_series(1, :draws) AS i
+	),
+	a AS (SELECT value, ROW_NUMBER() OVER (ORDER BY id) - 1 AS pos FROM adjectives),
+	n AS (SELECT value, ROW_NUMBER() OVER (ORDER BY id) - 1 AS pos FROM nouns)
+SELECT
+	a.value AS adjective,
+	n.value AS noun
+FROM
+	draws d
+JOIN
+	a ON a.pos = d.adjective_pos
+JOIN
+	n ON n.pos = d.noun_pos
+WHERE
+	%s
+ORDER BY
+	d.i
+LIMIT 1`
+
+const countPairsSQLTpl = `
+SELECT
+	count(*)
+FROM
+	adjectives a
+CROSS JOIN
+	nouns n
+WHERE
+	%s`
+
+// nthPairSQLTpl returns the matching pair at :offset, the order only has to be stable within the transaction that
+// counted the pairs.
+const nthPairSQLTpl = `
+SELECT
+	a.value AS adjective,
+	n.value AS noun
+FROM
+	adjectives a
+CROSS JOIN
+	nouns n
+WHERE
+	%s
+ORDER BY
+	a.id, n.id
+LIMIT 1 OFFSET :offset`
+
+type pairRow struct {
+	Adjective string `db:"adjective"`
+	Noun      string `db:"noun"`
+}
+
+// OneRandom returns a pair picked uniformly among the ones matching the filters. It first draws pairs at random and
+// keeps the first matching one, when none matches it counts the matching pairs and returns the k-th one for a k
+// picked uniformly, both queries see the same snapshot so that the count holds for the offset.
+func (s *PairStore) OneRandom(
+	ctx context.Context,
+	f serverplate.RandomPairFilters,
+) (serverplate.Pair, error) {
+	whereSQL, args := buildPairFilterWhereSQL(f)
+
+	var row pairRow
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := s.db.WithTx(ctx, opts, func(ctx context.Context, tx *sqlx.Tx) error {
+		args["draws"] = pairDraws
+		err := namedGet(ctx, tx, &row, fmt.Sprintf(drawPairSQLTpl, whereSQL), args)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to draw a pair: %w", err)
+		}
+
+		var total int
+		if err := namedGet(ctx, tx, &total, fmt.Sprintf(countPairsSQLTpl, whereSQL), args); err != nil {
+			return fmt.Errorf("failed to count the matching pairs: %w", err)
+		}
+
+		if total == 0 {
+			return serverplate.ErrNoMatchingPairs
+		}
+
+		args["offset"] = rand.IntN(total)
+		if err := namedGet(ctx, tx, &row, fmt.Sprintf(nthPairSQLTpl, whereSQL), args); err != nil {
+			return fmt.Errorf("failed to get the picked pair: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return serverplate.Pair{}, err
+	}
+
+	return serverplate.Pair{
+		Adjective: row.Adjective,
+		Noun:      row.Noun,
+	}, nil
+}
+
+func namedGet(ctx context.Context, tx *sqlx.Tx, dest any, query string, args map[string]any) error {
+	stmt, err := tx.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	return stmt.GetContext(ctx, dest, args)
+}
+
+const statsSQLTpl = `
+SELECT
+	(SELECT count(*) FROM nouns) AS noun_count,
+	(SELECT count(*) FROM adjectives) AS adjective_count,
+	(SELECT count(*) FROM adjectives a CROSS JOIN nouns n WHERE %s) AS pair_count`
+
+const dbSizeSQL = `SELECT pg_database_size(current_database()) AS size`
+
+func (s *PairStore) Stats(
+	ctx context.Context,
+	f serverplate.RandomPairFilters,
+) (serverplate.Stats, error) {
+	whereSQL, args := buildPairFilterWhereSQL(f)
+	sql := fmt.Sprintf(statsSQLTpl, whereSQL)
+	var row struct {
+		PairCount      int `db:"pair_count"`
+		AdjectiveCount int `db:"adjective_count"`
+		NounCount      int `db:"noun_count"`
+	}
+	stmt, err := s.db.PrepareNamedContext(ctx, sql)
+	if err != nil {
+		return serverplate.Stats{}, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &row, args); err != nil {
+		return serverplate.Stats{}, err
+	}
+
+	var size int64
+	if err := s.db.QueryRowContext(ctx, dbSizeSQL).Scan(&size); err != nil {
+		return serverplate.Stats{}, err
+	}
+
+	return serverplate.Stats{
+		DatabaseSizeBytes: size,
+		PairCount:         row.PairCount,
+		AdjectiveCount:    row.AdjectiveCount,
+		NounCount:         row.NounCount,
+	}, nil
+}
+
+const adjectivesSQL = `SELECT value FROM adjectives`
+
+const nounsSQL = `SELECT value FROM nouns`
+
+func (s *PairStore) Words(ctx context.Context) (serverplate.WordLists, error) {
+	return loadWords(ctx, s.db)
+}
+
+// loadWords lists the words through q, which lets the bucket store read them in the transaction of a pop.
+func loadWords(ctx context.Context, q sqlx.QueryerContext) (serverplate.WordLists, error) {
+	var w serverplate.WordLists
+	if err := sqlx.SelectContext(ctx, q, &w.Adjectives, adjectivesSQL); err != nil {
+		return serverplate.WordLists{}, fmt.Errorf("failed to list the adjectives: %w", err)
+	}
+
+	if err := sqlx.SelectContext(ctx, q, &w.Nouns, nounsSQL); err != nil {
+		return serverplate.WordLists{}, fmt.Errorf("failed to list the nouns: %w", err)
+	}
+
+	return w, nil
+}
+
+// wordsStamp changes along with the word lists, the migrations only ever add or remove words. It is much cheaper to
+// read than the lists themselves.
+type wordsStamp struct {
+	AdjectiveCount  int64 `db:"adjective_count"`
+	AdjectiveMaxID  int64 `db:"adjective_max_id"`
+	AdjectiveLength int64 `db:"adjective_length"`
+	NounCount       int64 `db:"noun_count"`
+	NounMaxID       int64 `db:"noun_max_id"`
+	NounLength      int64 `db:"noun_length"`
+}
+
+const wordsStampSQL = `
+SELECT
+	(SELECT count(*) FROM adjectives) AS adjective_count,
+	(SELECT COALESCE(MAX(id), 0) FROM adjectives) AS adjective_max_id,
+	(SELECT COALESCE(SUM(LENGTH(value)), 0) FROM adjectives) AS adjective_length,
+	(SELECT count(*) FROM nouns) AS noun_count,
+	(SELECT COALESCE(MAX(id), 0) FROM nouns) AS noun_max_id,
+	(SELECT COALESCE(SUM(LENGTH(value)), 0) FROM nouns) AS noun_length`
+
+// wordsCache keeps the word lists lazy buckets walk, loaded and versioned once instead of on every pop. Every get
+// checks the stamp of the lists in the database and loads them again once it changes, so every instance sharing
+// the database walks the same lists.
+type wordsCache struct {
+	mu    sync.Mutex
+	words *serverplate.LazyWords
+	stamp wordsStamp
+}
+
+// get returns the word lists of version, or the current ones when version is empty, loading them through q when
+// they changed. It returns ErrWordsVersionMismatch when the word lists in the database are not the ones of version.
+func (c *wordsCache) get(ctx context.Context, q sqlx.QueryerContext, version string) (serverplate.LazyWords, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var stamp wordsStamp
+	if err := sqlx.GetContext(ctx, q, &stamp, wordsStampSQL); err != nil {
+		return serverplate.LazyWords{}, fmt.Errorf("failed to check the word lists: %w", err)
+	}
+
+	if c.words == nil || stamp != c.stamp {
+		w, err := loadWords(ctx, q)
+		if err != nil {
+			return serverplate.LazyWords{}, err
+		}
+		c.words = new(serverplate.NewLazyWords(w))
+		c.stamp = stamp
+	}
+
+	if err := c.words.CheckVersion(version); err != nil {
+		return serverplate.LazyWords{}, err
+	}
+
+	return *c.words, nil
+}
+
+// buildPairFilterWhereSQL returns the sql based on the serverplate.RandomPairFilters. Assumes the query using the
+// resulting sql sets up aliases 'a' for adjectives table and 'n' for nouns table.
+func buildPairFilterWhereSQL(f serverplate.RandomPairFilters) (string, map[string]any) {
+	const nameSQL = "(a.value || '-' || n.value)"
+	const lengthSQL = "(LENGTH(a.value) + LENGTH(n.value) + 1)"
+
+	wheres := []string{"1=1"}
+	args := map[string]any{}
+
+	if f.Length > 0 {
+		args["length"] = f.Length
+		switch f.LengthMode {
+		case serverplate.LengthModeExactly:
+			wheres = append(wheres, lengthSQL+" = :length")
+		case serverplate.LengthModeUpto:
+			wheres = append(wheres, lengthSQL+" <= :length")
+		}
+	}
+
+	if f.MinLength > 0 {
+		args["min_length"] = f.MinLength
+		wheres = append(wheres, lengthSQL+" >= :min_length")
+	}
+
+	if f.AdjectiveInitial != "" {
+		args["adjective_initial"] = f.AdjectiveInitial
+		args["adjective_initial_length"] = len(f.AdjectiveInitial)
+		wheres = append(wheres, "SUBSTR(a.value, 1, :adjective_initial_length) = :adjective_initial")
+	}
+
+	if f.NounInitial != "" {
+		args["noun_initial"] = f.NounInitial
+		args["noun_initial_length"] = len(f.NounInitial)
+		wheres = append(wheres, "SUBSTR(n.value, 1, :noun_initial_length) = :noun_initial")
+	}
+
+	if f.Alliterative {
+		wheres = append(wheres, "SUBSTR(a.value, 1, 1) = SUBSTR(n.value, 1, 1)")
+	}
+
+	if f.Prefix != "" {
+		args["prefix"] = f.Prefix
+		args["prefix_length"] = len(f.Prefix)
+		wheres = append(wheres, "LEFT("+nameSQL+", :prefix_length) = :prefix")
+	}
+
+	if f.Suffix != "" {
+		args["suffix"] = f.Suffix
+		args["suffix_length"] = len(f.Suffix)
+		wheres = append(wheres, "RIGHT("+nameSQL+", :suffix_length) = :suffix")
+	}
+
+	for i, c := range f.ExcludedChars {
+		key := fmt.Sprintf("excluded_%d", i)
+		args[key] = string(c)
+		wheres = append(wheres, "STRPOS("+nameSQL+", :"+key+") = 0")
+	}
+
+	return strings.Join(wheres, " AND "), args
+}
@@ -0,0 +1,78 @@
+package pgstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func sqlTimeToPtr(t sql.NullTime) *time.Time {
+	if t.Valid {
+		return &t.Time
+	}
+
+	return nil
+}
+
+func sqlInt64ToPtr(i sql.NullInt64) *int64 {
+	if i.Valid {
+		return &i.Int64
+	}
+
+	return nil
+}
+
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullableInt(val int, enabled bool) sql.NullInt32 {
+	if !enabled {
+		return sql.NullInt32{Valid: false}
+	}
+	return sql.NullInt32{Int32: int32(val), Valid: true}
+}
+
+// retentionSeconds stores the retention of a bucket in whole seconds, NULL uses the default retention.
+func retentionSeconds(d *time.Duration) sql.NullInt64 {
+	if d == nil {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: int64(*d / time.Second), Valid: true}
+}
+
+func retentionFromSeconds(v sql.NullInt64) *time.Duration {
+	if !v.Valid {
+		return nil
+	}
+	return new(time.Duration(v.Int64) * time.Second)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern returns a LIKE pattern matching the values that contain s, to be used with ESCAPE '\'.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// labelsColumn scans the JSON object built by the labels subquery of the bucket selects.
+type labelsColumn map[string]string
+
+func (l *labelsColumn) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported labels column type %T", src)
+	}
+
+	return json.Unmarshal(raw, (*map[string]string)(l))
+}
@@ -0,0 +1,177 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type auditEventRow struct {
+	ID          int64          `db:"id"`
+	Action      string         `db:"action"`
+	BucketID    int32          `db:"bucket_id"`
+	BucketName  string         `db:"bucket_name"`
+	Actor       string         `db:"actor"`
+	Source      string         `db:"source"`
+	IP          sql.NullString `db:"ip"`
+	RequestID   sql.NullString `db:"request_id"`
+	BeforeState string         `db:"before_state"`
+	AfterState  string         `db:"after_state"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+func rowToAuditEvent(r auditEventRow) serverplate.AuditEvent {
+	return serverplate.AuditEvent{
+		ID:         r.ID,
+		Action:     serverplate.AuditAction(r.Action),
+		BucketID:   r.BucketID,
+		BucketName: r.BucketName,
+		AuditOrigin: serverplate.AuditOrigin{
+			Actor:     r.Actor,
+			Source:    serverplate.AuditSource(r.Source),
+			IP:        r.IP.String,
+			RequestID: r.RequestID.String,
+		},
+		Before:    json.RawMessage(r.BeforeState),
+		After:     json.RawMessage(r.AfterState),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// AuditStore only appends to the audit_events table, triggers reject updating or removing its rows.
+type AuditStore struct {
+	db *DBPool
+}
+
+func NewAuditStore(db *DBPool) *AuditStore {
+	return &AuditStore{db: db}
+}
+
+const recordAuditEventSQL = `
+INSERT INTO audit_events
+	(action, bucket_id, bucket_name, actor, source, ip, request_id, before_state, after_state, created_at)
+VALUES
+	(:action, :bucket_id, :bucket_name, :actor, :source, :ip, :request_id, :before_state, :after_state, :created_at)`
+
+func (s *AuditStore) Record(ctx context.Context, e *serverplate.AuditEvent) error {
+	// times are kept in UTC so that they compare correctly as text.
+	now := time.Now().UTC()
+
+	r, err := s.db.Write().NamedExecContext(ctx, recordAuditEventSQL, map[string]any{
+		"action":       e.Action,
+		"bucket_id":    e.BucketID,
+		"bucket_name":  e.BucketName,
+		"actor":        e.Actor,
+		"source":       e.Source,
+		"ip":           nullableString(e.IP),
+		"request_id":   nullableString(e.RequestID),
+		"before_state": auditState(e.Before),
+		"after_state":  auditState(e.After),
+		"created_at":   now,
+	})
+	if err != nil {
+		return err
+	}
+
+	id, err := r.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	e.ID = id
+	e.CreatedAt = now
+	return nil
+}
+
+// auditState stores the missing states as JSON null.
+func auditState(state json.RawMessage) string {
+	if len(state) == 0 {
+		return "null"
+	}
+	return string(state)
+}
+
+const listAuditEventsSQLTpl = `
+SELECT
+	id,
+	action,
+	bucket_id,
+	bucket_name,
+	actor,
+	source,
+	ip,
+	request_id,
+	before_state,
+	after_state,
+	created_at
+FROM
+	audit_events
+WHERE
+	%s
+ORDER BY
+	id DESC
+%s`
+
+func (s *AuditStore) List(ctx context.Context, opts serverplate.AuditListOptions) ([]serverplate.AuditEvent, error) {
+	wheres := []string{"1=1"}
+	args := map[string]any{}
+
+	if opts.BucketID != 0 {
+		wheres = append(wheres, "bucket_id = :bucket_id")
+		args["bucket_id"] = opts.BucketID
+	}
+	if opts.Action != "" {
+		wheres = append(wheres, "action = :action")
+		args["action"] = opts.Action
+	}
+	if opts.Actor != "" {
+		wheres = append(wheres, "actor = :actor")
+		args["actor"] = opts.Actor
+	}
+	if opts.Source != "" {
+		wheres = append(wheres, "source = :source")
+		args["source"] = opts.Source
+	}
+	if !opts.Since.IsZero() {
+		wheres = append(wheres, "created_at >= :since")
+		args["since"] = opts.Since.UTC()
+	}
+	if !opts.Until.IsZero() {
+		wheres = append(wheres, "created_at < :until")
+		args["until"] = opts.Until.UTC()
+	}
+	if opts.BeforeID > 0 {
+		wheres = append(wheres, "id < :before_id")
+		args["before_id"] = opts.BeforeID
+	}
+
+	var limit string
+	if opts.Limit > 0 {
+		limit = "LIMIT :limit"
+		args["limit"] = opts.Limit
+	}
+
+	query := fmt.Sprintf(listAuditEventsSQLTpl, strings.Join(wheres, " AND "), limit)
+	stmt, err := s.db.Read().PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rows []auditEventRow
+	if err := stmt.SelectContext(ctx, &rows, args); err != nil {
+		return nil, err
+	}
+
+	events := make([]serverplate.AuditEvent, 0, len(rows))
+	for _, r := range rows {
+		events = append(events, rowToAuditEvent(r))
+	}
+
+	return events, nil
+}
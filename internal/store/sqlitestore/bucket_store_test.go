@@ -2,12 +2,14 @@ package sqlitestore_test
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"testing"
 
 	"github.com/davidonium/serverplate/internal/dbtesting"
 	"github.com/davidonium/serverplate/internal/serverplate"
 	"github.com/davidonium/serverplate/internal/store/sqlitestore"
+	"github.com/davidonium/serverplate/internal/store/storetest"
 )
 
 func TestBucketStore(t *testing.T) {
@@ -44,3 +46,79 @@ func TestBucketStore(t *testing.T) {
 		}
 	})
 }
+
+func newConformanceStores(t *testing.T, adjectives, nouns []string) storetest.Stores {
+	pool := dbtesting.NewSQLite(t)
+	dbtesting.SeedWords(t, pool.Write().DB, adjectives, nouns)
+
+	logger := slog.New(slog.DiscardHandler)
+	return storetest.Stores{
+		Buckets:  sqlitestore.NewBucketStore(logger, pool),
+		Pairs:    sqlitestore.NewPairStore(pool),
+		JobRuns:  sqlitestore.NewJobRunStore(pool),
+		JobLocks: sqlitestore.NewJobLockStore(pool),
+		Leases:   sqlitestore.NewLeaseStore(pool),
+		Audit:    sqlitestore.NewAuditStore(pool),
+	}
+}
+
+func TestBucketStoreConformance(t *testing.T) {
+	storetest.RunBucketStoreSuite(t, newConformanceStores)
+}
+
+func TestPairStoreConformance(t *testing.T) {
+	storetest.RunPairStoreSuite(t, newConformanceStores)
+}
+
+func TestJobRunStoreConformance(t *testing.T) {
+	storetest.RunJobRunStoreSuite(t, newConformanceStores)
+}
+
+func TestJobLockStoreConformance(t *testing.T) {
+	storetest.RunJobLockStoreSuite(t, newConformanceStores)
+}
+
+func TestLeaseStoreConformance(t *testing.T) {
+	storetest.RunLeaseStoreSuite(t, newConformanceStores)
+}
+
+func TestAuditStoreConformance(t *testing.T) {
+	storetest.RunAuditStoreSuite(t, newConformanceStores)
+}
+
+func TestLazyPopWordsCache(t *testing.T) {
+	ctx := context.Background()
+	adjectives, nouns := []string{"brave", "calm"}, []string{"ant", "otter"}
+	pool := dbtesting.NewSQLite(t)
+	dbtesting.SeedWords(t, pool.Write().DB, adjectives, nouns)
+	store := sqlitestore.NewBucketStore(slog.New(slog.DiscardHandler), pool)
+
+	create := func(name string, words serverplate.WordLists) serverplate.Bucket {
+		b := serverplate.Bucket{Name: name, Seed: new(int64(42)), WordsVersion: words.Version()}
+		if err := store.Create(ctx, &b); err != nil {
+			t.Fatalf("Create() = unexpected error: %v", err)
+		}
+		count := int64(len(words.Adjectives) * len(words.Nouns))
+		if err := store.FillBucketLazily(ctx, b, count); err != nil {
+			t.Fatalf("FillBucketLazily() = unexpected error: %v", err)
+		}
+		return b
+	}
+
+	b := create("lazy-bucket", serverplate.WordLists{Adjectives: adjectives, Nouns: nouns})
+	if _, err := store.PopName(ctx, b); err != nil {
+		t.Fatalf("PopName() = unexpected error: %v", err)
+	}
+
+	// the cached lists are loaded again once the table changes, so the pops fail like on an instance that never
+	// cached them instead of walking the old lists.
+	dbtesting.SeedWords(t, pool.Write().DB, nil, []string{"river"})
+	if _, err := store.PopName(ctx, b); !errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+		t.Errorf("PopName() = unexpected error got %v want %v", err, serverplate.ErrWordsVersionMismatch)
+	}
+
+	newer := create("newer-bucket", serverplate.WordLists{Adjectives: adjectives, Nouns: append(nouns, "river")})
+	if _, err := store.PopName(ctx, newer); err != nil {
+		t.Errorf("PopName() = unexpected error with the new word lists: %v", err)
+	}
+}
@@ -89,6 +89,8 @@ func connectReadPool(ctx context.Context, connURL *url.URL) (*sqlx.DB, error) {
 type DBPool struct {
 	writeDB *DB
 	readDB  *DB
+	// words is shared by the stores popping names of lazy buckets.
+	words wordsCache
 }
 
 // NewDBPool creates a new connection pool with separate read and write pools.
@@ -0,0 +1,59 @@
+package sqlitestore
+
+import (
+	"context"
+	"time"
+)
+
+type JobLockStore struct {
+	db *DBPool
+}
+
+func NewJobLockStore(db *DBPool) *JobLockStore {
+	return &JobLockStore{db: db}
+}
+
+// acquireJobLockSQL only replaces the lock of another owner once it expired, no row is affected otherwise.
+const acquireJobLockSQL = `
+INSERT INTO job_locks
+	(job, owner, expires_at)
+VALUES
+	(:job, :owner, :expires_at)
+ON CONFLICT (job) DO UPDATE SET
+	owner = excluded.owner,
+	expires_at = excluded.expires_at
+WHERE
+	job_locks.owner = excluded.owner
+OR
+	job_locks.expires_at <= :now`
+
+func (s *JobLockStore) Acquire(ctx context.Context, job, owner string, ttl time.Duration) (bool, error) {
+	// times are kept in UTC so that they compare correctly as text.
+	now := time.Now().UTC()
+	r, err := s.db.Write().NamedExecContext(ctx, acquireJobLockSQL, map[string]any{
+		"job":        job,
+		"owner":      owner,
+		"expires_at": now.Add(ttl),
+		"now":        now,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	acquired, err := r.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return acquired > 0, nil
+}
+
+const releaseJobLockSQL = `DELETE FROM job_locks WHERE job = :job AND owner = :owner`
+
+func (s *JobLockStore) Release(ctx context.Context, job, owner string) error {
+	_, err := s.db.Write().NamedExecContext(ctx, releaseJobLockSQL, map[string]any{
+		"job":   job,
+		"owner": owner,
+	})
+	return err
+}
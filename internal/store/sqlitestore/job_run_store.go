@@ -0,0 +1,129 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type jobRunRow struct {
+	ID           int64          `db:"id"`
+	Job          string         `db:"job"`
+	StartedAt    time.Time      `db:"started_at"`
+	EndedAt      time.Time      `db:"ended_at"`
+	Error        sql.NullString `db:"error"`
+	AffectedRows int64          `db:"affected_rows"`
+}
+
+func rowToJobRun(r jobRunRow) serverplate.JobRun {
+	return serverplate.JobRun{
+		ID:           r.ID,
+		Job:          r.Job,
+		StartedAt:    r.StartedAt,
+		EndedAt:      r.EndedAt,
+		Error:        r.Error.String,
+		AffectedRows: r.AffectedRows,
+	}
+}
+
+type JobRunStore struct {
+	db *DBPool
+}
+
+func NewJobRunStore(db *DBPool) *JobRunStore {
+	return &JobRunStore{db: db}
+}
+
+const recordJobRunSQL = `
+INSERT INTO job_runs
+	(job, started_at, ended_at, error, affected_rows)
+VALUES
+	(:job, :started_at, :ended_at, :error, :affected_rows)`
+
+func (s *JobRunStore) Record(ctx context.Context, run *serverplate.JobRun) error {
+	r, err := s.db.Write().NamedExecContext(ctx, recordJobRunSQL, map[string]any{
+		"job":           run.Job,
+		"started_at":    run.StartedAt.UTC(),
+		"ended_at":      run.EndedAt.UTC(),
+		"error":         nullableString(run.Error),
+		"affected_rows": run.AffectedRows,
+	})
+	if err != nil {
+		return err
+	}
+
+	id, err := r.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	run.ID = id
+	return nil
+}
+
+const jobRunColumnsSQL = `
+	id,
+	job,
+	started_at,
+	ended_at,
+	error,
+	affected_rows`
+
+const lastJobRunSQL = `
+SELECT` + jobRunColumnsSQL + `
+FROM
+	job_runs
+WHERE
+	job = :job
+ORDER BY
+	started_at DESC, id DESC
+LIMIT 1`
+
+func (s *JobRunStore) Last(ctx context.Context, job string) (serverplate.JobRun, error) {
+	stmt, err := s.db.Read().PrepareNamedContext(ctx, lastJobRunSQL)
+	if err != nil {
+		return serverplate.JobRun{}, err
+	}
+	defer stmt.Close()
+
+	var row jobRunRow
+	if err := stmt.GetContext(ctx, &row, map[string]any{"job": job}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return serverplate.JobRun{}, serverplate.ErrJobRunNotFound
+		}
+		return serverplate.JobRun{}, err
+	}
+
+	return rowToJobRun(row), nil
+}
+
+const recentJobRunsSQL = `
+SELECT` + jobRunColumnsSQL + `
+FROM
+	job_runs
+ORDER BY
+	started_at DESC, id DESC
+LIMIT :limit`
+
+func (s *JobRunStore) Recent(ctx context.Context, limit int) ([]serverplate.JobRun, error) {
+	stmt, err := s.db.Read().PrepareNamedContext(ctx, recentJobRunsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rows []jobRunRow
+	if err := stmt.SelectContext(ctx, &rows, map[string]any{"limit": limit}); err != nil {
+		return nil, err
+	}
+
+	runs := make([]serverplate.JobRun, 0, len(rows))
+	for _, r := range rows {
+		runs = append(runs, rowToJobRun(r))
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,2393 @@
+// Package storetest provides conformance suites that every implementation of serverplate.BucketStore,
+// serverplate.PairStore, serverplate.JobRunStore, serverplate.JobLockStore, serverplate.LeaseStore and
+// serverplate.AuditStore must pass, so that the storage backends are interchangeable.
+package storetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+// Stores holds the store implementations exercised by the suites.
+type Stores struct {
+	Buckets  serverplate.BucketStore
+	Pairs    serverplate.PairStore
+	JobRuns  serverplate.JobRunStore
+	JobLocks serverplate.JobLockStore
+	Leases   serverplate.LeaseStore
+	Audit    serverplate.AuditStore
+}
+
+// Factory creates stores without any bucket whose word lists contain exactly the given adjectives and nouns. It is
+// called once per test so every test starts from a clean state.
+type Factory func(t *testing.T, adjectives, nouns []string) Stores
+
+var (
+	adjectives = []string{"brave", "calm", "eager", "fancy", "gentle", "jolly"}
+	nouns      = []string{"ant", "badger", "comet", "jetty", "otter", "pine", "river", "mountain"}
+)
+
+// allPairs returns every name that can be built from the suite word lists and match the filters.
+func allPairs(f serverplate.RandomPairFilters) []string {
+	var names []string
+	for _, a := range adjectives {
+		for _, n := range nouns {
+			if f.Match(a, n) {
+				names = append(names, a+"-"+n)
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// filterCases holds filters that match some but not all the names built from the suite word lists.
+var filterCases = []serverplate.RandomPairFilters{
+	{Length: 9, LengthMode: serverplate.LengthModeUpto},
+	{Length: 11, LengthMode: serverplate.LengthModeExactly},
+	{MinLength: 13},
+	{Length: 12, LengthMode: serverplate.LengthModeUpto, MinLength: 10},
+	{AdjectiveInitial: "c"},
+	{NounInitial: "r"},
+	{AdjectiveInitial: "j", NounInitial: "p"},
+	{Alliterative: true},
+	{Prefix: "gentle-r"},
+	{Suffix: "er"},
+	{ExcludedChars: "rt"},
+	{Alliterative: true, ExcludedChars: "r", MinLength: 9},
+}
+
+// RunBucketStoreSuite runs the serverplate.BucketStore conformance tests against the stores built by factory.
+func RunBucketStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"CreateAndRetrieve", testCreateAndRetrieve},
+		{"CreateDuplicateName", testCreateDuplicateName},
+		{"Rename", testRename},
+		{"CopyRemainingValues", testCopyRemainingValues},
+		{"NotFound", testNotFound},
+		{"FillWithoutFilters", testFillWithoutFilters},
+		{"FillWithFilters", testFillWithFilters},
+		{"FillInOrder", testFillInOrder},
+		{"Seed", testSeed},
+		{"PopOrder", testPopOrder},
+		{"PopExhaustion", testPopExhaustion},
+		{"PopUnfilled", testPopUnfilled},
+		{"ConcurrentPops", testConcurrentPops},
+		{"RemainingValuesAfterPops", testRemainingValuesAfterPops},
+		{"SetCursor", testSetCursor},
+		{"SaveAndList", testSaveAndList},
+		{"ListOptions", testListOptions},
+		{"Archive", testArchive},
+		{"DeleteArchived", testDeleteArchived},
+		{"Retention", testRetention},
+		{"AutoArchivePolicy", testAutoArchivePolicy},
+		{"RecentlyPopped", testRecentlyPopped},
+		{"UpdateFiltersKeepsPopped", testUpdateFiltersKeepsPopped},
+		{"UpdateFiltersSeeded", testUpdateFiltersSeeded},
+		{"UpdateFiltersUnfilled", testUpdateFiltersUnfilled},
+		{"UpdateFiltersNotFound", testUpdateFiltersNotFound},
+		{"UpdateBucket", testUpdateBucket},
+		{"UpdateBucketRollback", testUpdateBucketRollback},
+		{"Labels", testLabels},
+		{"ListLabelSelector", testListLabelSelector},
+		{"LazyPopAll", testLazyPopAll},
+		{"LazyWordsVersionMismatch", testLazyWordsVersionMismatch},
+		{"LazyUpdateFilters", testLazyUpdateFilters},
+		{"LazyCopyRemainingValues", testLazyCopyRemainingValues},
+		{"FillInBatches", testFillInBatches},
+		{"FillEmpty", testFillEmpty},
+		{"FailFill", testFailFill},
+		{"FailStalledFills", testFailStalledFills},
+		{"FillNotFilling", testFillNotFilling},
+		{"KeepFilling", testKeepFilling},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+// RunPairStoreSuite runs the serverplate.PairStore conformance tests against the stores built by factory.
+func RunPairStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"OneRandomWithFilters", testOneRandomWithFilters},
+		{"OneRandomEveryFilter", testOneRandomEveryFilter},
+		{"OneRandomNoMatches", testOneRandomNoMatches},
+		{"OneRandomUniform", testOneRandomUniform},
+		{"Stats", testStats},
+		{"Words", testWords},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+// RunJobRunStoreSuite runs the serverplate.JobRunStore conformance tests against the stores built by factory.
+func RunJobRunStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"RecordAndLast", testJobRunRecordAndLast},
+		{"Recent", testJobRunRecent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+// RunJobLockStoreSuite runs the serverplate.JobLockStore conformance tests against the stores built by factory.
+func RunJobLockStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"AcquireContention", testJobLockAcquireContention},
+		{"Handover", testJobLockHandover},
+		{"Release", testJobLockRelease},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+// RunLeaseStoreSuite runs the serverplate.LeaseStore conformance tests against the stores built by factory.
+func RunLeaseStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"ReserveAndConfirm", testLeaseReserveAndConfirm},
+		{"ReserveExhausted", testLeaseReserveExhausted},
+		{"ReturnExpired", testLeaseReturnExpired},
+		{"ConfirmedNotReturned", testLeaseConfirmedNotReturned},
+		{"ReturnExpiredSkipped", testLeaseReturnExpiredSkipped},
+		{"ReturnExpiredLazy", testLeaseReturnExpiredLazy},
+		{"CountPending", testLeaseCountPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+// RunAuditStoreSuite runs the serverplate.AuditStore conformance tests against the stores built by factory.
+func RunAuditStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(*testing.T, Stores)
+	}{
+		{"RecordAndList", testAuditRecordAndList},
+		{"ListFilters", testAuditListFilters},
+		{"ListPages", testAuditListPages},
+		{"ListTimeRange", testAuditListTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory(t, adjectives, nouns))
+		})
+	}
+}
+
+func testRecentlyPopped(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+
+	none, err := s.Buckets.RecentlyPopped(ctx, b, 10)
+	if err != nil {
+		t.Fatalf("RecentlyPopped() = unexpected error: %v", err)
+	}
+
+	if len(none) != 0 {
+		t.Errorf("RecentlyPopped() = got %d names before any pop, want 0", len(none))
+	}
+
+	start := time.Now().Add(-time.Minute)
+	var popped []string
+	for range 3 {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, b.ID))
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		popped = append(popped, name)
+	}
+
+	recent, err := s.Buckets.RecentlyPopped(ctx, reload(t, s, b.ID), 2)
+	if err != nil {
+		t.Fatalf("RecentlyPopped() = unexpected error: %v", err)
+	}
+
+	if len(recent) != 2 {
+		t.Fatalf("RecentlyPopped() = got %d names want 2", len(recent))
+	}
+
+	if recent[0].Name != popped[2] || recent[1].Name != popped[1] {
+		t.Errorf("RecentlyPopped() = unexpected order got %v want %v first", recent, popped[2])
+	}
+
+	for _, n := range recent {
+		if n.PoppedAt == nil || n.PoppedAt.Before(start) {
+			t.Errorf("RecentlyPopped() = unexpected pop time %v for %q", n.PoppedAt, n.Name)
+		}
+	}
+
+	all, err := s.Buckets.RecentlyPopped(ctx, reload(t, s, b.ID), 10)
+	if err != nil {
+		t.Fatalf("RecentlyPopped() = unexpected error: %v", err)
+	}
+
+	if len(all) != len(popped) {
+		t.Errorf("RecentlyPopped() = got %d names want %d", len(all), len(popped))
+	}
+}
+
+func testUpdateFiltersKeepsPopped(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+
+	var popped []string
+	for range 5 {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, b.ID))
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		popped = append(popped, name)
+	}
+	cursor := reload(t, s, b.ID).Cursor
+	before := remaining(t, s, b.ID)
+
+	f := serverplate.RandomPairFilters{Alliterative: true}
+	b = reload(t, s, b.ID)
+	b.SetFilters(f)
+	delta, err := s.Buckets.UpdateFilters(ctx, &b)
+	if err != nil {
+		t.Fatalf("UpdateFilters() = unexpected error: %v", err)
+	}
+
+	var want []string
+	for _, name := range allPairs(f) {
+		if !slices.Contains(popped, name) {
+			want = append(want, name)
+		}
+	}
+
+	after := remaining(t, s, b.ID)
+	if after != int64(len(want)) {
+		t.Errorf("RemainingValuesTotal() = got %d want %d", after, len(want))
+	}
+
+	if delta != after-before {
+		t.Errorf("UpdateFilters() = unexpected delta got %d want %d", delta, after-before)
+	}
+
+	updated := reload(t, s, b.ID)
+	if updated.Cursor != cursor {
+		t.Errorf("UpdateFilters() = cursor moved got %d want %d", updated.Cursor, cursor)
+	}
+
+	if updated.Filters() != f {
+		t.Errorf("UpdateFilters() = filters were not persisted got %+v want %+v", updated.Filters(), f)
+	}
+
+	got := popAll(t, s, b.ID)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("popped names after updating the filters got %v want %v", got, want)
+	}
+}
+
+func testUpdateFiltersSeeded(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "seeded-bucket", Seed: new(int64(7)), WordsVersion: suiteWords.Version()}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if err := s.Buckets.FillBucketValuesInOrder(ctx, b, suiteWords.SeededNames(b.Filters(), *b.Seed)); err != nil {
+		t.Fatalf("FillBucketValuesInOrder() = unexpected error: %v", err)
+	}
+
+	var popped []string
+	for range 5 {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, b.ID))
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		popped = append(popped, name)
+	}
+
+	f := serverplate.RandomPairFilters{Alliterative: true}
+	b = reload(t, s, b.ID)
+	b.SetFilters(f)
+	if _, err := s.Buckets.UpdateFilters(ctx, &b); err != nil {
+		t.Fatalf("UpdateFilters() = unexpected error: %v", err)
+	}
+
+	// the names left follow the seed for the new filters, like a bucket created with them.
+	var want []string
+	for _, name := range suiteWords.SeededNames(f, *b.Seed) {
+		if !slices.Contains(popped, name) {
+			want = append(want, name)
+		}
+	}
+
+	if got := popAll(t, s, b.ID); !slices.Equal(got, want) {
+		t.Errorf("PopName() = got %v after updating the filters, want the seeded order %v", got, want)
+	}
+}
+
+func testUpdateFiltersUnfilled(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "test-bucket"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	f := serverplate.RandomPairFilters{NounInitial: "r"}
+	b.SetFilters(f)
+	delta, err := s.Buckets.UpdateFilters(ctx, &b)
+	if err != nil {
+		t.Fatalf("UpdateFilters() = unexpected error: %v", err)
+	}
+
+	want := allPairs(f)
+	if delta != int64(len(want)) {
+		t.Errorf("UpdateFilters() = unexpected delta got %d want %d", delta, len(want))
+	}
+
+	got := popAll(t, s, b.ID)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("popped names after updating the filters got %v want %v", got, want)
+	}
+}
+
+func testUpdateFiltersNotFound(t *testing.T, s Stores) {
+	b := serverplate.Bucket{ID: 42}
+	if _, err := s.Buckets.UpdateFilters(context.Background(), &b); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("UpdateFilters() = unexpected error got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+}
+
+func testUpdateBucket(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	before := remaining(t, s, b.ID)
+
+	f := serverplate.RandomPairFilters{Alliterative: true}
+	b.Name = "renamed-bucket"
+	b.Labels = map[string]string{"env": "prod"}
+	b.SetFilters(f)
+	delta, err := s.Buckets.UpdateBucket(ctx, &b, serverplate.UpdateOptions{Labels: true, Filters: true})
+	if err != nil {
+		t.Fatalf("UpdateBucket() = unexpected error: %v", err)
+	}
+
+	after := remaining(t, s, b.ID)
+	if after != int64(len(allPairs(f))) || delta != after-before {
+		t.Errorf("UpdateBucket() = got %d remaining and delta %d, want %d remaining", after, delta, len(allPairs(f)))
+	}
+
+	updated := reload(t, s, b.ID)
+	if updated.Name != "renamed-bucket" || updated.Labels["env"] != "prod" || updated.Filters() != f {
+		t.Errorf("UpdateBucket() = unexpected bucket %+v", updated)
+	}
+
+	// the labels and filters are only written when asked.
+	updated.Labels = nil
+	updated.SetFilters(serverplate.RandomPairFilters{})
+	if _, err := s.Buckets.UpdateBucket(ctx, &updated, serverplate.UpdateOptions{}); err != nil {
+		t.Fatalf("UpdateBucket() = unexpected error: %v", err)
+	}
+	if got := reload(t, s, b.ID); got.Labels["env"] != "prod" || got.Filters() != f {
+		t.Errorf("UpdateBucket() = got labels %v and filters %+v, want them untouched", got.Labels, got.Filters())
+	}
+}
+
+func testUpdateBucketRollback(t *testing.T, s Stores) {
+	ctx := context.Background()
+	createFilledBucket(t, s, "other-bucket", serverplate.RandomPairFilters{})
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	before := remaining(t, s, b.ID)
+
+	taken := b
+	taken.Name = "other-bucket"
+	taken.Labels = map[string]string{"env": "prod"}
+	taken.SetFilters(serverplate.RandomPairFilters{Alliterative: true})
+	_, err := s.Buckets.UpdateBucket(ctx, &taken, serverplate.UpdateOptions{Labels: true, Filters: true})
+	if !errors.Is(err, serverplate.ErrBucketNameTaken) {
+		t.Errorf("UpdateBucket() = unexpected error got %v want %v", err, serverplate.ErrBucketNameTaken)
+	}
+
+	// the filters are written after the name, failing them must not keep the new name either.
+	lazy := createLazyBucket(t, s, "lazy-bucket", serverplate.RandomPairFilters{}, 42)
+	mismatch := lazy
+	mismatch.Name = "renamed-bucket"
+	mismatch.WordsVersion = "0123456789abcdef"
+	_, err = s.Buckets.UpdateBucket(ctx, &mismatch, serverplate.UpdateOptions{Filters: true})
+	if !errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+		t.Errorf("UpdateBucket() = unexpected error got %v want %v", err, serverplate.ErrWordsVersionMismatch)
+	}
+
+	if got := reload(t, s, b.ID); got.Name != "test-bucket" || len(got.Labels) != 0 || got.FilterAlliterative {
+		t.Errorf("UpdateBucket() = got %+v, want the bucket left as it was", got)
+	}
+	if got := remaining(t, s, b.ID); got != before {
+		t.Errorf("RemainingValuesTotal() = got %d want %d", got, before)
+	}
+	if got := reload(t, s, lazy.ID); got.Name != "lazy-bucket" {
+		t.Errorf("UpdateBucket() = got name %q, want the lazy bucket left as it was", got.Name)
+	}
+}
+
+func createFilledBucket(
+	t *testing.T,
+	s Stores,
+	name string,
+	f serverplate.RandomPairFilters,
+) serverplate.Bucket {
+	t.Helper()
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: name}
+	b.SetFilters(f)
+
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if err := s.Buckets.FillBucketValues(ctx, b, f); err != nil {
+		t.Fatalf("FillBucketValues() = unexpected error: %v", err)
+	}
+
+	return reload(t, s, b.ID)
+}
+
+// suiteWords are the word lists of the stores built by the factories.
+var suiteWords = serverplate.WordLists{Adjectives: adjectives, Nouns: nouns}
+
+func createLazyBucket(
+	t *testing.T,
+	s Stores,
+	name string,
+	f serverplate.RandomPairFilters,
+	seed int64,
+) serverplate.Bucket {
+	t.Helper()
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: name, Seed: new(seed), WordsVersion: suiteWords.Version()}
+	b.SetFilters(f)
+
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if err := s.Buckets.FillBucketLazily(ctx, b, int64(len(allPairs(f)))); err != nil {
+		t.Fatalf("FillBucketLazily() = unexpected error: %v", err)
+	}
+
+	return reload(t, s, b.ID)
+}
+
+func reload(t *testing.T, s Stores, id int32) serverplate.Bucket {
+	t.Helper()
+
+	b, err := s.Buckets.OneByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("OneByID() = unexpected error reloading bucket %d: %v", id, err)
+	}
+
+	return b
+}
+
+// popAll pops names until the bucket is exhausted, reloading the bucket before every pop like the handlers do.
+func popAll(t *testing.T, s Stores, id int32) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	var names []string
+	for {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, id))
+		if errors.Is(err, serverplate.ErrBucketExhausted) {
+			return names
+		}
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+
+		names = append(names, name)
+		if len(names) > len(adjectives)*len(nouns) {
+			t.Fatalf("PopName() = popped more names than the bucket can hold")
+		}
+	}
+}
+
+func remaining(t *testing.T, s Stores, id int32) int64 {
+	t.Helper()
+
+	count, err := s.Buckets.RemainingValuesTotal(context.Background(), reload(t, s, id))
+	if err != nil {
+		t.Fatalf("RemainingValuesTotal() = unexpected error: %v", err)
+	}
+
+	return count
+}
+
+func testCreateAndRetrieve(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{
+		Name:                   "test-bucket",
+		Description:            "a description",
+		FilterLengthEnabled:    true,
+		FilterLengthMode:       serverplate.LengthModeUpto,
+		FilterLengthValue:      16,
+		FilterMinLength:        8,
+		FilterAdjectiveInitial: "b",
+		FilterNounInitial:      "b",
+		FilterAlliterative:     true,
+		FilterPrefix:           "br",
+		FilterSuffix:           "er",
+		FilterExcludedChars:    "l1",
+	}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = expected to succeed but got err: %v", err)
+	}
+
+	if b.ID == 0 {
+		t.Errorf("Create() = expected the bucket id to be set")
+	}
+
+	byName, err := s.Buckets.OneByName(ctx, "test-bucket")
+	if err != nil {
+		t.Fatalf("OneByName() = expected to retrieve an existing bucket but failed: %v", err)
+	}
+
+	byID, err := s.Buckets.OneByID(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("OneByID() = expected to retrieve an existing bucket but failed: %v", err)
+	}
+
+	for _, got := range []serverplate.Bucket{byName, byID} {
+		if got.ID != b.ID || got.Name != b.Name || got.Description != b.Description {
+			t.Errorf("retrieved bucket does not match the created one. got %+v want %+v", got, b)
+		}
+
+		if got.Filters() != b.Filters() {
+			t.Errorf(
+				"retrieved bucket filters do not match. got %+v want %+v",
+				got.Filters(),
+				b.Filters(),
+			)
+		}
+
+		if got.CreatedAt.IsZero() {
+			t.Errorf("retrieved bucket is missing its creation time")
+		}
+
+		if got.Archived() {
+			t.Errorf("a new bucket must not be archived")
+		}
+	}
+}
+
+func testCreateDuplicateName(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	if err := s.Buckets.Create(ctx, &serverplate.Bucket{Name: "test-bucket"}); err != nil {
+		t.Fatalf("Create() = expected to succeed but got err: %v", err)
+	}
+
+	if err := s.Buckets.Create(ctx, &serverplate.Bucket{Name: "test-bucket"}); err == nil {
+		t.Error(
+			"Create() = expected to fail when creating a bucket with an already existing name but succeeded",
+		)
+	} else if !errors.Is(err, serverplate.ErrBucketNameTaken) {
+		t.Errorf("Create() = unexpected error got %v want %v", err, serverplate.ErrBucketNameTaken)
+	}
+}
+
+func testRename(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "old-name", Description: "kept"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if err := s.Buckets.Create(ctx, &serverplate.Bucket{Name: "taken-name"}); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	b.Name = "new-name"
+	if err := s.Buckets.Save(ctx, &b); err != nil {
+		t.Fatalf("Save() = unexpected error renaming: %v", err)
+	}
+
+	got, err := s.Buckets.OneByName(ctx, "new-name")
+	if err != nil {
+		t.Fatalf("OneByName() = unexpected error: %v", err)
+	}
+	if got.ID != b.ID || got.Description != "kept" {
+		t.Errorf("OneByName() = unexpected bucket after the rename %+v", got)
+	}
+
+	if _, err := s.Buckets.OneByName(ctx, "old-name"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("OneByName() = old name got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+
+	b.Name = "taken-name"
+	if err := s.Buckets.Save(ctx, &b); !errors.Is(err, serverplate.ErrBucketNameTaken) {
+		t.Errorf("Save() = renaming to a taken name got %v want %v", err, serverplate.ErrBucketNameTaken)
+	}
+	if got := reload(t, s, b.ID); got.Name != "new-name" {
+		t.Errorf("OneByID() = name after the failed rename got %q want %q", got.Name, "new-name")
+	}
+}
+
+func testCopyRemainingValues(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	src := createFilledBucket(t, s, "copy-source", serverplate.RandomPairFilters{})
+	all := len(allPairs(serverplate.RandomPairFilters{}))
+
+	var popped []string
+	for range 3 {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, src.ID))
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		popped = append(popped, name)
+	}
+
+	dst := serverplate.Bucket{Name: "copy-target"}
+	if err := s.Buckets.Create(ctx, &dst); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if err := s.Buckets.CopyRemainingValues(ctx, reload(t, s, src.ID), dst); err != nil {
+		t.Fatalf("CopyRemainingValues() = unexpected error: %v", err)
+	}
+
+	if got := remaining(t, s, dst.ID); got != int64(all-len(popped)) {
+		t.Errorf("RemainingValuesTotal() = copy got %d want %d", got, all-len(popped))
+	}
+
+	// both buckets hand out the same names in the same order from now on.
+	want := popAll(t, s, src.ID)
+	got := popAll(t, s, dst.ID)
+	if !slices.Equal(got, want) {
+		t.Errorf("PopName() = copy order got %v want %v", got, want)
+	}
+	for _, name := range popped {
+		if slices.Contains(got, name) {
+			t.Errorf("PopName() = copy handed out %q which the source already popped", name)
+		}
+	}
+}
+
+func testLazyPopAll(t *testing.T, s Stores) {
+	ctx := context.Background()
+	f := serverplate.RandomPairFilters{Suffix: "er"}
+	b := createLazyBucket(t, s, "lazy-bucket", f, 42)
+
+	if !b.Lazy {
+		t.Errorf("OneByID() = got an eager bucket, want a lazy one")
+	}
+
+	want := allPairs(f)
+	if got := remaining(t, s, b.ID); got != int64(len(want)) {
+		t.Errorf("RemainingValuesTotal() = got %d want %d", got, len(want))
+	}
+
+	// the names come in the order of the walk, every matching name once.
+	l := suiteWords.LazyValues(f, 42)
+	var walk []string
+	for position := int64(0); ; {
+		name, next, ok := l.Next(position)
+		if !ok {
+			break
+		}
+		walk = append(walk, name)
+		position = next
+	}
+
+	got := popAll(t, s, b.ID)
+	if !slices.Equal(got, walk) {
+		t.Errorf("PopName() = got %v, want the walk order %v", got, walk)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("PopName() = got %v, want every matching name once %v", got, want)
+	}
+
+	if got := remaining(t, s, b.ID); got != 0 {
+		t.Errorf("RemainingValuesTotal() = got %d once exhausted, want 0", got)
+	}
+
+	recent, err := s.Buckets.RecentlyPopped(ctx, reload(t, s, b.ID), 1)
+	if err != nil {
+		t.Fatalf("RecentlyPopped() = unexpected error: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Name != walk[len(walk)-1] {
+		t.Errorf("RecentlyPopped() = got %+v, want the last name of the walk %q", recent, walk[len(walk)-1])
+	}
+}
+
+func testLazyWordsVersionMismatch(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "lazy-bucket", Seed: new(int64(42)), WordsVersion: "0123456789abcdef"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	err := s.Buckets.FillBucketLazily(ctx, b, int64(len(allPairs(b.Filters()))))
+	if !errors.Is(err, serverplate.ErrWordsVersionMismatch) {
+		t.Errorf("FillBucketLazily() = unexpected error got %v want %v", err, serverplate.ErrWordsVersionMismatch)
+	}
+}
+
+func testLazyUpdateFilters(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createLazyBucket(t, s, "lazy-bucket", serverplate.RandomPairFilters{}, 7)
+
+	var popped []string
+	for range 5 {
+		name, err := s.Buckets.PopName(ctx, reload(t, s, b.ID))
+		if err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+		popped = append(popped, name)
+	}
+	before := remaining(t, s, b.ID)
+
+	// every name walked past so far was popped, the remaining ones are the matching names not popped yet.
+	f := serverplate.RandomPairFilters{Alliterative: true}
+	b = reload(t, s, b.ID)
+	b.SetFilters(f)
+	delta, err := s.Buckets.UpdateFilters(ctx, &b)
+	if err != nil {
+		t.Fatalf("UpdateFilters() = unexpected error: %v", err)
+	}
+
+	var want []string
+	for _, name := range allPairs(f) {
+		if !slices.Contains(popped, name) {
+			want = append(want, name)
+		}
+	}
+
+	after := remaining(t, s, b.ID)
+	if after != int64(len(want)) {
+		t.Errorf("RemainingValuesTotal() = got %d want %d", after, len(want))
+	}
+	if delta != after-before {
+		t.Errorf("UpdateFilters() = unexpected delta got %d want %d", delta, after-before)
+	}
+
+	got := popAll(t, s, b.ID)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("popped names after updating the filters got %v want %v", got, want)
+	}
+}
+
+func testLazyCopyRemainingValues(t *testing.T, s Stores) {
+	ctx := context.Background()
+	src := createLazyBucket(t, s, "copy-source", serverplate.RandomPairFilters{}, 7)
+	all := len(allPairs(serverplate.RandomPairFilters{}))
+
+	for range 3 {
+		if _, err := s.Buckets.PopName(ctx, reload(t, s, src.ID)); err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+	}
+
+	dst := serverplate.Bucket{Name: "copy-target", Seed: src.Seed, WordsVersion: src.WordsVersion}
+	if err := s.Buckets.Create(ctx, &dst); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+	if err := s.Buckets.CopyRemainingValues(ctx, reload(t, s, src.ID), dst); err != nil {
+		t.Fatalf("CopyRemainingValues() = unexpected error: %v", err)
+	}
+
+	if !reload(t, s, dst.ID).Lazy {
+		t.Errorf("OneByID() = got an eager copy, want a lazy one")
+	}
+	if got := remaining(t, s, dst.ID); got != int64(all-3) {
+		t.Errorf("RemainingValuesTotal() = copy got %d want %d", got, all-3)
+	}
+
+	want := popAll(t, s, src.ID)
+	if got := popAll(t, s, dst.ID); !slices.Equal(got, want) {
+		t.Errorf("PopName() = copy order got %v want %v", got, want)
+	}
+}
+
+func testNotFound(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	if _, err := s.Buckets.OneByID(ctx, 4242); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("OneByID() = expected ErrBucketNotFound, got: %v", err)
+	}
+
+	if _, err := s.Buckets.OneByName(ctx, "missing"); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("OneByName() = expected ErrBucketNotFound, got: %v", err)
+	}
+}
+
+func testFillWithoutFilters(t *testing.T, s Stores) {
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+
+	want := allPairs(serverplate.RandomPairFilters{})
+	if got := remaining(t, s, b.ID); got != int64(len(want)) {
+		t.Errorf("RemainingValuesTotal() = got %d want %d", got, len(want))
+	}
+
+	got := popAll(t, s, b.ID)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("popped names do not match every combination. got %v want %v", got, want)
+	}
+}
+
+func testFillWithFilters(t *testing.T, s Stores) {
+	for i, f := range filterCases {
+		b := createFilledBucket(t, s, fmt.Sprintf("test-bucket-%d", i), f)
+
+		want := allPairs(f)
+		if len(want) == 0 {
+			t.Fatalf("invalid test case, filters %+v do not match any name", f)
+		}
+
+		if got := remaining(t, s, b.ID); got != int64(len(want)) {
+			t.Errorf("RemainingValuesTotal() = filters %+v got %d want %d", f, got, len(want))
+		}
+
+		got := popAll(t, s, b.ID)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("popped names do not match the filters %+v. got %v want %v", f, got, want)
+		}
+	}
+}
+
+func testFillInOrder(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "test-bucket"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	values := allPairs(serverplate.RandomPairFilters{AdjectiveInitial: "c"})
+	slices.Reverse(values)
+
+	if err := s.Buckets.FillBucketValuesInOrder(ctx, b, values); err != nil {
+		t.Fatalf("FillBucketValuesInOrder() = unexpected error: %v", err)
+	}
+
+	if got := popAll(t, s, b.ID); !slices.Equal(got, values) {
+		t.Errorf("PopName() = got %v, want the values in the order they were filled %v", got, values)
+	}
+}
+
+func testSeed(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	seeded := serverplate.Bucket{Name: "seeded-bucket", Seed: new(int64(-42)), WordsVersion: "0123456789abcdef"}
+	if err := s.Buckets.Create(ctx, &seeded); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	got := reload(t, s, seeded.ID)
+	if got.Seed == nil || *got.Seed != -42 || got.WordsVersion != seeded.WordsVersion {
+		t.Errorf("OneByID() = got seed %v and words version %q, want -42 and %q",
+			got.Seed,
+			got.WordsVersion,
+			seeded.WordsVersion,
+		)
+	}
+
+	random := createFilledBucket(t, s, "random-bucket", serverplate.RandomPairFilters{})
+	if random.Seed != nil || random.WordsVersion != "" {
+		t.Errorf("OneByID() = got seed %v and words version %q, want none", random.Seed, random.WordsVersion)
+	}
+}
+
+func testPopOrder(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+
+	// popping with a stale copy of the bucket must still advance, the store is the source of truth for the cursor.
+	first, err := s.Buckets.PopName(ctx, b)
+	if err != nil {
+		t.Fatalf("PopName() = unexpected error: %v", err)
+	}
+
+	second, err := s.Buckets.PopName(ctx, b)
+	if err != nil {
+		t.Fatalf("PopName() = unexpected error: %v", err)
+	}
+
+	if first == second {
+		t.Errorf("PopName() = consecutive pops returned the same name %q", first)
+	}
+
+	rest := popAll(t, s, b.ID)
+	names := append([]string{first, second}, rest...)
+
+	seen := map[string]bool{}
+	for _, n := range names {
+		if seen[n] {
+			t.Errorf("PopName() = name %q was returned twice", n)
+		}
+		seen[n] = true
+	}
+
+	if want := len(allPairs(serverplate.RandomPairFilters{})); len(names) != want {
+		t.Errorf("PopName() = popped %d names, want %d", len(names), want)
+	}
+}
+
+func testPopExhaustion(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(
+		t,
+		s,
+		"test-bucket",
+		serverplate.RandomPairFilters{Length: 9, LengthMode: serverplate.LengthModeUpto},
+	)
+
+	popAll(t, s, b.ID)
+
+	if got := remaining(t, s, b.ID); got != 0 {
+		t.Errorf("RemainingValuesTotal() = expected an exhausted bucket to have 0 values, got %d", got)
+	}
+
+	for range 2 {
+		if _, err := s.Buckets.PopName(ctx, reload(t, s, b.ID)); !errors.Is(err, serverplate.ErrBucketExhausted) {
+			t.Errorf("PopName() = expected ErrBucketExhausted once exhausted, got: %v", err)
+		}
+	}
+}
+
+func testPopUnfilled(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "test-bucket"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if got := remaining(t, s, b.ID); got != 0 {
+		t.Errorf("RemainingValuesTotal() = expected an unfilled bucket to have 0 values, got %d", got)
+	}
+
+	if _, err := s.Buckets.PopName(ctx, reload(t, s, b.ID)); !errors.Is(err, serverplate.ErrBucketExhausted) {
+		t.Errorf("PopName() = expected ErrBucketExhausted for an unfilled bucket, got: %v", err)
+	}
+}
+
+func testConcurrentPops(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+
+	const workers = 8
+
+	var (
+		mu    sync.Mutex
+		names []string
+		wg    sync.WaitGroup
+	)
+
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Go(func() {
+			for {
+				// every worker pops with the same snapshot of the bucket, as concurrent requests would.
+				name, err := s.Buckets.PopName(ctx, b)
+				if errors.Is(err, serverplate.ErrBucketExhausted) {
+					return
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				mu.Lock()
+				names = append(names, name)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("PopName() = unexpected error popping concurrently: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, n := range names {
+		if seen[n] {
+			t.Errorf("PopName() = name %q was returned to more than one concurrent pop", n)
+		}
+		seen[n] = true
+	}
+
+	slices.Sort(names)
+	if want := allPairs(serverplate.RandomPairFilters{}); !slices.Equal(names, want) {
+		t.Errorf("concurrent pops did not drain the bucket. got %d names want %d", len(names), len(want))
+	}
+}
+
+func testRemainingValuesAfterPops(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	total := int64(len(allPairs(serverplate.RandomPairFilters{})))
+
+	for i := range int64(5) {
+		if got := remaining(t, s, b.ID); got != total-i {
+			t.Errorf("RemainingValuesTotal() = after %d pops got %d want %d", i, got, total-i)
+		}
+
+		if _, err := s.Buckets.PopName(ctx, reload(t, s, b.ID)); err != nil {
+			t.Fatalf("PopName() = unexpected error: %v", err)
+		}
+	}
+
+	other := createFilledBucket(t, s, "other-bucket", serverplate.RandomPairFilters{})
+	if got := remaining(t, s, other.ID); got != total {
+		t.Errorf("RemainingValuesTotal() = pops must not affect other buckets. got %d want %d", got, total)
+	}
+}
+
+func testSetCursor(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	total := int64(len(allPairs(serverplate.RandomPairFilters{})))
+
+	if err := s.Buckets.SetCursor(ctx, b.ID, 11); err != nil {
+		t.Fatalf("SetCursor() = unexpected error: %v", err)
+	}
+
+	got := reload(t, s, b.ID)
+	if got.Cursor != 11 {
+		t.Errorf("SetCursor() = cursor was not persisted. got %d want %d", got.Cursor, 11)
+	}
+
+	if count := remaining(t, s, b.ID); count != total-10 {
+		t.Errorf("RemainingValuesTotal() = after moving the cursor got %d want %d", count, total-10)
+	}
+}
+
+func testSaveAndList(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	active := createFilledBucket(t, s, "active-bucket", serverplate.RandomPairFilters{})
+	archived := createFilledBucket(t, s, "archived-bucket", serverplate.RandomPairFilters{})
+
+	active.Description = "updated description"
+	if err := s.Buckets.Save(ctx, &active); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	archived.MarkArchived()
+	if err := s.Buckets.Save(ctx, &archived); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	if got := reload(t, s, active.ID); got.Description != "updated description" || got.UpdatedAt == nil {
+		t.Errorf("Save() = description or updated_at were not persisted, got %+v", got)
+	}
+
+	if got := reload(t, s, archived.ID); !got.Archived() {
+		t.Errorf("Save() = archived_at was not persisted")
+	}
+
+	list, err := s.Buckets.List(ctx, serverplate.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	if len(list) != 1 || list[0].ID != active.ID {
+		t.Errorf("List() = expected only the active bucket, got %+v", list)
+	}
+
+	list, err = s.Buckets.List(ctx, serverplate.ListOptions{ArchivedOnly: true})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	if len(list) != 1 || list[0].ID != archived.ID {
+		t.Errorf("List() = expected only the archived bucket, got %+v", list)
+	}
+
+	archived.Recover()
+	if err := s.Buckets.Save(ctx, &archived); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	if got := reload(t, s, archived.ID); got.Archived() {
+		t.Errorf("Save() = recovering the bucket was not persisted")
+	}
+}
+
+func testListOptions(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	buckets := []serverplate.Bucket{
+		{Name: "delta-db", Description: "Database servers"},
+		{Name: "alpha-web", Description: "uses 100% of the names"},
+		{Name: "charlie-db", Description: ""},
+		{Name: "bravo-cache", Description: "cache servers for the db"},
+	}
+	for i := range buckets {
+		if err := s.Buckets.Create(ctx, &buckets[i]); err != nil {
+			t.Fatalf("Create() = unexpected error: %v", err)
+		}
+	}
+
+	if err := s.Buckets.FillBucketValues(ctx, buckets[0], serverplate.RandomPairFilters{}); err != nil {
+		t.Fatalf("FillBucketValues() = unexpected error: %v", err)
+	}
+	if _, err := s.Buckets.PopName(ctx, reload(t, s, buckets[0].ID)); err != nil {
+		t.Fatalf("PopName() = unexpected error: %v", err)
+	}
+
+	names := func(list []serverplate.Bucket) []string {
+		var names []string
+		for _, b := range list {
+			names = append(names, b.Name)
+		}
+		return names
+	}
+
+	cases := []struct {
+		opts serverplate.ListOptions
+		want []string
+	}{
+		{
+			opts: serverplate.ListOptions{},
+			want: []string{"delta-db", "alpha-web", "charlie-db", "bravo-cache"},
+		},
+		{
+			opts: serverplate.ListOptions{Search: "DB"},
+			want: []string{"delta-db", "charlie-db", "bravo-cache"},
+		},
+		{
+			opts: serverplate.ListOptions{Search: "%"},
+			want: []string{"alpha-web"},
+		},
+		{
+			opts: serverplate.ListOptions{Search: "_"},
+			want: nil,
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortName},
+			want: []string{"alpha-web", "bravo-cache", "charlie-db", "delta-db"},
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortName, SortDesc: true},
+			want: []string{"delta-db", "charlie-db", "bravo-cache", "alpha-web"},
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortCreatedAt, SortDesc: true},
+			want: []string{"bravo-cache", "charlie-db", "alpha-web", "delta-db"},
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortName, Limit: 2},
+			want: []string{"alpha-web", "bravo-cache"},
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortName, Limit: 2, Offset: 3},
+			want: []string{"delta-db"},
+		},
+		{
+			opts: serverplate.ListOptions{SortBy: serverplate.BucketSortName, Offset: 1},
+			want: []string{"bravo-cache", "charlie-db", "delta-db"},
+		},
+	}
+
+	for _, tt := range cases {
+		list, err := s.Buckets.List(ctx, tt.opts)
+		if err != nil {
+			t.Fatalf("List() = unexpected error with %+v: %v", tt.opts, err)
+		}
+
+		if got := names(list); !slices.Equal(got, tt.want) {
+			t.Errorf("List() = options %+v got %v want %v", tt.opts, got, tt.want)
+		}
+	}
+
+	list, err := s.Buckets.List(ctx, serverplate.ListOptions{IncludeRemaining: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	want := int64(len(allPairs(serverplate.RandomPairFilters{})) - 1)
+	if len(list) != 2 || list[0].RemainingValues != want || list[1].RemainingValues != 0 {
+		t.Errorf("List() = unexpected remaining values got %+v want %d and 0", list, want)
+	}
+}
+
+func testLabels(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{
+		Name:   "labeled",
+		Labels: map[string]string{"env": "prod", "team": "infra"},
+	}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if got := reload(t, s, b.ID).Labels; !maps.Equal(got, b.Labels) {
+		t.Errorf("OneByID() = labels got %v want %v", got, b.Labels)
+	}
+
+	updated := map[string]string{"env": "staging", "owner": "alice"}
+	if err := s.Buckets.SetLabels(ctx, b.ID, updated); err != nil {
+		t.Fatalf("SetLabels() = unexpected error: %v", err)
+	}
+
+	got, err := s.Buckets.OneByName(ctx, b.Name)
+	if err != nil {
+		t.Fatalf("OneByName() = unexpected error: %v", err)
+	}
+	if !maps.Equal(got.Labels, updated) {
+		t.Errorf("OneByName() = labels after SetLabels got %v want %v", got.Labels, updated)
+	}
+
+	if err := s.Buckets.SetLabels(ctx, b.ID, nil); err != nil {
+		t.Fatalf("SetLabels() = unexpected error clearing: %v", err)
+	}
+	if got := reload(t, s, b.ID).Labels; len(got) != 0 {
+		t.Errorf("OneByID() = labels after clearing got %v want none", got)
+	}
+}
+
+func testListLabelSelector(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	buckets := []serverplate.Bucket{
+		{Name: "web-prod", Labels: map[string]string{"env": "prod", "team": "web"}},
+		{Name: "db-prod", Labels: map[string]string{"env": "prod", "team": "db"}},
+		{Name: "web-staging", Labels: map[string]string{"env": "staging", "team": "web"}},
+		{Name: "unlabeled"},
+	}
+	for i := range buckets {
+		if err := s.Buckets.Create(ctx, &buckets[i]); err != nil {
+			t.Fatalf("Create() = unexpected error: %v", err)
+		}
+	}
+
+	cases := []struct {
+		selector map[string]string
+		want     []string
+	}{
+		{selector: nil, want: []string{"web-prod", "db-prod", "web-staging", "unlabeled"}},
+		{selector: map[string]string{"env": "prod"}, want: []string{"web-prod", "db-prod"}},
+		{selector: map[string]string{"env": "prod", "team": "web"}, want: []string{"web-prod"}},
+		{selector: map[string]string{"team": "web"}, want: []string{"web-prod", "web-staging"}},
+		{selector: map[string]string{"env": "dev"}, want: nil},
+		{selector: map[string]string{"owner": "alice"}, want: nil},
+	}
+
+	for _, tt := range cases {
+		list, err := s.Buckets.List(ctx, serverplate.ListOptions{LabelSelector: tt.selector})
+		if err != nil {
+			t.Fatalf("List() = unexpected error with selector %v: %v", tt.selector, err)
+		}
+
+		var got []string
+		for _, b := range list {
+			got = append(got, b.Name)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("List() = selector %v got %v want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func testArchive(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := createFilledBucket(t, s, "archive-bucket", serverplate.RandomPairFilters{})
+
+	// a rename made after the bucket was read is kept by the archival.
+	renamed := b
+	renamed.Name = "renamed-bucket"
+	if err := s.Buckets.Save(ctx, &renamed); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+	if err := s.Buckets.Archive(ctx, b.ID, at); err != nil {
+		t.Fatalf("Archive() = unexpected error: %v", err)
+	}
+
+	got := reload(t, s, b.ID)
+	if got.Name != "renamed-bucket" {
+		t.Errorf("Archive() = got name %q, want the rename kept", got.Name)
+	}
+	if got.ArchivedAt == nil || !got.ArchivedAt.Equal(at) {
+		t.Errorf("Archive() = got archived at %v, want %v", got.ArchivedAt, at)
+	}
+
+	// archiving again keeps the first archival time.
+	if err := s.Buckets.Archive(ctx, b.ID, time.Now()); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("Archive() = archived bucket got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+	if got := reload(t, s, b.ID); got.ArchivedAt == nil || !got.ArchivedAt.Equal(at) {
+		t.Errorf("Archive() = got archived at %v after archiving again, want %v", got.ArchivedAt, at)
+	}
+
+	if err := s.Buckets.Archive(ctx, 99999, time.Now()); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("Archive() = missing bucket got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+}
+
+func testDeleteArchived(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	archived := createFilledBucket(t, s, "archived-bucket", serverplate.RandomPairFilters{})
+	if err := s.Buckets.SetLabels(ctx, archived.ID, map[string]string{"env": "prod"}); err != nil {
+		t.Fatalf("SetLabels() = unexpected error: %v", err)
+	}
+	archived.MarkArchived()
+	if err := s.Buckets.Save(ctx, &archived); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	active := createFilledBucket(t, s, "active-bucket", serverplate.RandomPairFilters{})
+
+	if err := s.Buckets.DeleteArchived(ctx, active.ID); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("DeleteArchived() = active bucket got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+	if _, err := s.Buckets.OneByID(ctx, active.ID); err != nil {
+		t.Errorf("OneByID() = expected the active bucket to be kept, got: %v", err)
+	}
+
+	if err := s.Buckets.DeleteArchived(ctx, archived.ID); err != nil {
+		t.Fatalf("DeleteArchived() = unexpected error: %v", err)
+	}
+	if _, err := s.Buckets.OneByID(ctx, archived.ID); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("OneByID() = expected the archived bucket to be removed, got: %v", err)
+	}
+
+	if err := s.Buckets.DeleteArchived(ctx, archived.ID); !errors.Is(err, serverplate.ErrBucketNotFound) {
+		t.Errorf("DeleteArchived() = removed bucket got %v want %v", err, serverplate.ErrBucketNotFound)
+	}
+
+	// the name is free again once the bucket is removed.
+	if err := s.Buckets.Create(ctx, &serverplate.Bucket{Name: "archived-bucket"}); err != nil {
+		t.Errorf("Create() = unexpected error reusing the name of a removed bucket: %v", err)
+	}
+}
+
+func testRetention(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "kept-bucket", Retention: new(time.Duration(0))}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if got := reload(t, s, b.ID).Retention; got == nil || *got != 0 {
+		t.Errorf("OneByID() = retention got %v want 0", got)
+	}
+
+	b.Retention = new(36 * time.Hour)
+	if err := s.Buckets.Save(ctx, &b); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+	if got := reload(t, s, b.ID).Retention; got == nil || *got != 36*time.Hour {
+		t.Errorf("OneByID() = retention got %v want %v", got, 36*time.Hour)
+	}
+
+	b.Retention = nil
+	if err := s.Buckets.Save(ctx, &b); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+	if got := reload(t, s, b.ID).Retention; got != nil {
+		t.Errorf("OneByID() = retention got %v want nil", *got)
+	}
+}
+
+func testAutoArchivePolicy(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	b := serverplate.Bucket{Name: "temporary-bucket", ExpiresAt: &expiresAt, ArchiveWhenExhausted: true}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	got := reload(t, s, b.ID)
+	if got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) || !got.ArchiveWhenExhausted {
+		t.Errorf(
+			"OneByID() = got expiry %v and archive when exhausted %v, want %v and true",
+			got.ExpiresAt,
+			got.ArchiveWhenExhausted,
+			expiresAt,
+		)
+	}
+
+	b.ExpiresAt = nil
+	b.ArchiveWhenExhausted = false
+	if err := s.Buckets.Save(ctx, &b); err != nil {
+		t.Fatalf("Save() = unexpected error: %v", err)
+	}
+
+	got = reload(t, s, b.ID)
+	if got.ExpiresAt != nil || got.ArchiveWhenExhausted {
+		t.Errorf(
+			"OneByID() = got expiry %v and archive when exhausted %v, want none",
+			got.ExpiresAt,
+			got.ArchiveWhenExhausted,
+		)
+	}
+}
+
+func testOneRandomWithFilters(t *testing.T, s Stores) {
+	ctx := context.Background()
+	f := serverplate.RandomPairFilters{Length: 10, LengthMode: serverplate.LengthModeUpto}
+	valid := allPairs(f)
+
+	for range 20 {
+		p, err := s.Pairs.OneRandom(ctx, f)
+		if err != nil {
+			t.Fatalf("OneRandom() = unexpected error: %v", err)
+		}
+
+		if name := p.Adjective + "-" + p.Noun; !slices.Contains(valid, name) {
+			t.Errorf("OneRandom() = %q does not match the filters %+v", name, f)
+		}
+	}
+}
+
+func testOneRandomEveryFilter(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	for _, f := range filterCases {
+		p, err := s.Pairs.OneRandom(ctx, f)
+		if err != nil {
+			t.Fatalf("OneRandom() = filters %+v unexpected error: %v", f, err)
+		}
+
+		if !f.Match(p.Adjective, p.Noun) {
+			t.Errorf("OneRandom() = %s-%s does not match the filters %+v", p.Adjective, p.Noun, f)
+		}
+	}
+}
+
+// testOneRandomUniform samples enough names for every matching pair to come up and checks the chi-squared statistic of
+// the words against a bound a uniform pick exceeds with a negligible probability.
+func testOneRandomUniform(t *testing.T, s Stores) {
+	ctx := context.Background()
+	g := serverplate.NewGenerator(s.Pairs)
+
+	for _, f := range []serverplate.RandomPairFilters{{}, {Suffix: "er"}, {ExcludedChars: "t"}} {
+		stats, err := g.Sample(ctx, f, 960)
+		if err != nil {
+			t.Fatalf("Sample() = filters %+v unexpected error: %v", f, err)
+		}
+
+		if want := len(allPairs(f)); stats.PairCount != want || stats.DistinctPairs != want {
+			t.Errorf("Sample() = filters %+v got %d pairs and %d distinct picks, want %d",
+				f,
+				stats.PairCount,
+				stats.DistinctPairs,
+				want,
+			)
+		}
+
+		for _, d := range []serverplate.WordDistribution{stats.Adjectives, stats.Nouns} {
+			if bound := float64(4*d.DegreesOfFreedom + 20); d.ChiSquare > bound {
+				t.Errorf("Sample() = filters %+v chi-squared %.1f over %.0f, the pick is biased: %+v",
+					f,
+					d.ChiSquare,
+					bound,
+					d.Words,
+				)
+			}
+		}
+	}
+}
+
+func testOneRandomNoMatches(t *testing.T, s Stores) {
+	_, err := s.Pairs.OneRandom(
+		context.Background(),
+		serverplate.RandomPairFilters{Length: 3, LengthMode: serverplate.LengthModeExactly},
+	)
+	if !errors.Is(err, serverplate.ErrNoMatchingPairs) {
+		t.Errorf("OneRandom() = expected ErrNoMatchingPairs, got: %v", err)
+	}
+}
+
+func testWords(t *testing.T, s Stores) {
+	words, err := s.Pairs.Words(context.Background())
+	if err != nil {
+		t.Fatalf("Words() = unexpected error: %v", err)
+	}
+
+	suite := serverplate.WordLists{Adjectives: adjectives, Nouns: nouns}
+	if got, want := words.Version(), suite.Version(); got != want {
+		t.Errorf("Words() = got version %q, want %q of the suite word lists", got, want)
+	}
+
+	for _, f := range append([]serverplate.RandomPairFilters{{}}, filterCases...) {
+		names := words.SeededNames(f, 42)
+		slices.Sort(names)
+		if want := allPairs(f); !slices.Equal(names, want) {
+			t.Errorf("SeededNames() = filters %+v got %v, want %v", f, names, want)
+		}
+	}
+}
+
+func testStats(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	for _, f := range append([]serverplate.RandomPairFilters{{}}, filterCases...) {
+		stats, err := s.Pairs.Stats(ctx, f)
+		if err != nil {
+			t.Fatalf("Stats() = unexpected error: %v", err)
+		}
+
+		if stats.AdjectiveCount != len(adjectives) || stats.NounCount != len(nouns) {
+			t.Errorf(
+				"Stats() = unexpected word counts. got %d adjectives and %d nouns",
+				stats.AdjectiveCount,
+				stats.NounCount,
+			)
+		}
+
+		if want := len(allPairs(f)); stats.PairCount != want {
+			t.Errorf("Stats() = filters %+v got pair count %d want %d", f, stats.PairCount, want)
+		}
+	}
+}
+
+func recordJobRun(t *testing.T, s Stores, job string, startedAt time.Time, errMsg string) serverplate.JobRun {
+	t.Helper()
+
+	run := serverplate.JobRun{
+		Job:          job,
+		StartedAt:    startedAt,
+		EndedAt:      startedAt.Add(1500 * time.Millisecond),
+		Error:        errMsg,
+		AffectedRows: 3,
+	}
+	if err := s.JobRuns.Record(context.Background(), &run); err != nil {
+		t.Fatalf("Record() = unexpected error: %v", err)
+	}
+
+	if run.ID == 0 {
+		t.Fatal("Record() = expected the run id to be set")
+	}
+
+	return run
+}
+
+func testJobRunRecordAndLast(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	if _, err := s.JobRuns.Last(ctx, "test_job"); !errors.Is(err, serverplate.ErrJobRunNotFound) {
+		t.Errorf("Last() = expected ErrJobRunNotFound before any run, got: %v", err)
+	}
+
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	recordJobRun(t, s, "test_job", startedAt, "")
+	want := recordJobRun(t, s, "test_job", startedAt.Add(time.Minute), "boom")
+	recordJobRun(t, s, "other_job", startedAt.Add(2*time.Minute), "")
+
+	got, err := s.JobRuns.Last(ctx, "test_job")
+	if err != nil {
+		t.Fatalf("Last() = unexpected error: %v", err)
+	}
+
+	if got.ID != want.ID || got.Job != want.Job || got.Error != want.Error || got.AffectedRows != want.AffectedRows {
+		t.Errorf("Last() = got %+v, want %+v", got, want)
+	}
+
+	if !got.StartedAt.Equal(want.StartedAt) || got.Duration() != want.Duration() {
+		t.Errorf(
+			"Last() = got started at %v taking %v, want %v taking %v",
+			got.StartedAt,
+			got.Duration(),
+			want.StartedAt,
+			want.Duration(),
+		)
+	}
+
+	if !got.Failed() {
+		t.Error("Last() = expected the run to be failed")
+	}
+}
+
+func testJobRunRecent(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	none, err := s.JobRuns.Recent(ctx, 10)
+	if err != nil {
+		t.Fatalf("Recent() = unexpected error: %v", err)
+	}
+
+	if len(none) != 0 {
+		t.Errorf("Recent() = got %d runs before any run, want 0", len(none))
+	}
+
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var want []int64
+	for i := range 4 {
+		run := recordJobRun(t, s, fmt.Sprintf("job_%d", i%2), startedAt.Add(time.Duration(i)*time.Minute), "")
+		want = append([]int64{run.ID}, want...)
+	}
+
+	got, err := s.JobRuns.Recent(ctx, 3)
+	if err != nil {
+		t.Fatalf("Recent() = unexpected error: %v", err)
+	}
+
+	ids := make([]int64, 0, len(got))
+	for _, r := range got {
+		ids = append(ids, r.ID)
+	}
+
+	if !slices.Equal(ids, want[:3]) {
+		t.Errorf("Recent() = got run ids %v, want %v", ids, want[:3])
+	}
+}
+
+func acquireJobLock(t *testing.T, s Stores, job, owner string, ttl time.Duration) bool {
+	t.Helper()
+
+	acquired, err := s.JobLocks.Acquire(context.Background(), job, owner, ttl)
+	if err != nil {
+		t.Fatalf("Acquire() = unexpected error: %v", err)
+	}
+
+	return acquired
+}
+
+func testJobLockAcquireContention(t *testing.T, s Stores) {
+	if !acquireJobLock(t, s, "test_job", "owner-a", time.Minute) {
+		t.Fatal("Acquire() = expected to take a free lock")
+	}
+
+	if acquireJobLock(t, s, "test_job", "owner-b", time.Minute) {
+		t.Error("Acquire() = expected to fail while another owner holds the lock")
+	}
+
+	if !acquireJobLock(t, s, "test_job", "owner-a", time.Minute) {
+		t.Error("Acquire() = expected the owner to extend its own lock")
+	}
+
+	if !acquireJobLock(t, s, "other_job", "owner-b", time.Minute) {
+		t.Error("Acquire() = expected the locks of different jobs to be independent")
+	}
+}
+
+func testJobLockHandover(t *testing.T, s Stores) {
+	if !acquireJobLock(t, s, "test_job", "owner-a", 100*time.Millisecond) {
+		t.Fatal("Acquire() = expected to take a free lock")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if !acquireJobLock(t, s, "test_job", "owner-b", time.Minute) {
+		t.Fatal("Acquire() = expected an expired lock to be handed over")
+	}
+
+	if acquireJobLock(t, s, "test_job", "owner-a", time.Minute) {
+		t.Error("Acquire() = expected the previous owner to lose the lock once handed over")
+	}
+}
+
+func testJobLockRelease(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	if !acquireJobLock(t, s, "test_job", "owner-a", time.Minute) {
+		t.Fatal("Acquire() = expected to take a free lock")
+	}
+
+	if err := s.JobLocks.Release(ctx, "test_job", "owner-b"); err != nil {
+		t.Fatalf("Release() = unexpected error: %v", err)
+	}
+
+	if acquireJobLock(t, s, "test_job", "owner-b", time.Minute) {
+		t.Error("Release() = expected a release by another owner to keep the lock")
+	}
+
+	if err := s.JobLocks.Release(ctx, "test_job", "owner-a"); err != nil {
+		t.Fatalf("Release() = unexpected error: %v", err)
+	}
+
+	if !acquireJobLock(t, s, "test_job", "owner-b", time.Minute) {
+		t.Error("Acquire() = expected a released lock to be free")
+	}
+}
+
+func reserve(t *testing.T, s Stores, id int32, ttl time.Duration) serverplate.Lease {
+	t.Helper()
+
+	l, err := s.Leases.Reserve(context.Background(), reload(t, s, id), ttl)
+	if err != nil {
+		t.Fatalf("Reserve() = unexpected error: %v", err)
+	}
+
+	return l
+}
+
+func returnExpired(t *testing.T, s Stores) int64 {
+	t.Helper()
+
+	returned, err := s.Leases.ReturnExpired(context.Background())
+	if err != nil {
+		t.Fatalf("ReturnExpired() = unexpected error: %v", err)
+	}
+
+	return int64(len(returned))
+}
+
+func testLeaseReserveAndConfirm(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	total := remaining(t, s, b.ID)
+
+	l := reserve(t, s, b.ID, time.Minute)
+	if l.ID == "" || l.BucketID != b.ID || l.Name == "" || l.Confirmed() {
+		t.Fatalf("Reserve() = unexpected lease %+v", l)
+	}
+
+	if got := remaining(t, s, b.ID); got != total-1 {
+		t.Errorf("RemainingValuesTotal() = got %d, want %d after reserving", got, total-1)
+	}
+
+	confirmed, err := s.Leases.Confirm(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("Confirm() = unexpected error: %v", err)
+	}
+	if !confirmed.Confirmed() || confirmed.Name != l.Name {
+		t.Errorf("Confirm() = got %+v, want the confirmed lease of %q", confirmed, l.Name)
+	}
+
+	again, err := s.Leases.Confirm(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("Confirm() = expected confirming twice to succeed but got err: %v", err)
+	}
+	if !again.ConfirmedAt.Equal(*confirmed.ConfirmedAt) {
+		t.Errorf("Confirm() = got confirmation time %v, want %v", again.ConfirmedAt, confirmed.ConfirmedAt)
+	}
+
+	if _, err := s.Leases.Confirm(ctx, "unknown"); !errors.Is(err, serverplate.ErrLeaseNotFound) {
+		t.Errorf("Confirm() = unexpected error got %v want %v", err, serverplate.ErrLeaseNotFound)
+	}
+
+	if slices.Contains(popAll(t, s, b.ID), l.Name) {
+		t.Errorf("PopName() = popped the leased name %q", l.Name)
+	}
+}
+
+func testLeaseReserveExhausted(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	b := serverplate.Bucket{Name: "unfilled-bucket"}
+	if err := s.Buckets.Create(ctx, &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	if _, err := s.Leases.Reserve(ctx, b, time.Minute); !errors.Is(err, serverplate.ErrBucketExhausted) {
+		t.Errorf("Reserve() = unexpected error got %v want %v", err, serverplate.ErrBucketExhausted)
+	}
+
+	filled := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	popAll(t, s, filled.ID)
+
+	_, err := s.Leases.Reserve(ctx, reload(t, s, filled.ID), time.Minute)
+	if !errors.Is(err, serverplate.ErrBucketExhausted) {
+		t.Errorf("Reserve() = unexpected error got %v want %v", err, serverplate.ErrBucketExhausted)
+	}
+}
+
+func testLeaseReturnExpired(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	total := remaining(t, s, b.ID)
+
+	expiring := reserve(t, s, b.ID, 100*time.Millisecond)
+	pending := reserve(t, s, b.ID, time.Minute)
+
+	time.Sleep(200 * time.Millisecond)
+
+	if _, err := s.Leases.Confirm(ctx, expiring.ID); !errors.Is(err, serverplate.ErrLeaseExpired) {
+		t.Errorf("Confirm() = unexpected error got %v want %v", err, serverplate.ErrLeaseExpired)
+	}
+
+	returned, err := s.Leases.ReturnExpired(ctx)
+	if err != nil {
+		t.Fatalf("ReturnExpired() = unexpected error: %v", err)
+	}
+	if len(returned) != 1 || returned[0].ID != expiring.ID || returned[0].Name != expiring.Name ||
+		returned[0].BucketID != b.ID {
+		t.Errorf("ReturnExpired() = got %+v, want only the expired lease %+v", returned, expiring)
+	}
+
+	if got := remaining(t, s, b.ID); got != total-1 {
+		t.Errorf("RemainingValuesTotal() = got %d, want %d once the expired name is back", got, total-1)
+	}
+
+	if _, err := s.Leases.Confirm(ctx, expiring.ID); !errors.Is(err, serverplate.ErrLeaseNotFound) {
+		t.Errorf("Confirm() = unexpected error got %v want %v", err, serverplate.ErrLeaseNotFound)
+	}
+
+	if _, err := s.Leases.Confirm(ctx, pending.ID); err != nil {
+		t.Errorf("Confirm() = expected the pending lease to be kept but got err: %v", err)
+	}
+
+	popped := popAll(t, s, b.ID)
+	if len(popped) != int(total-1) {
+		t.Fatalf("PopName() = got %d names, want %d", len(popped), total-1)
+	}
+	if last := popped[len(popped)-1]; last != expiring.Name {
+		t.Errorf("PopName() = got %q last, want the returned name %q", last, expiring.Name)
+	}
+	if slices.Contains(popped, pending.Name) {
+		t.Errorf("PopName() = popped the name %q of the pending lease", pending.Name)
+	}
+}
+
+// testLeaseReturnExpiredLazy checks lazy buckets hand out the names of expired leases before computing new ones.
+func testLeaseReturnExpiredLazy(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createLazyBucket(t, s, "lazy-bucket", serverplate.RandomPairFilters{}, 42)
+	total := remaining(t, s, b.ID)
+
+	expiring := reserve(t, s, b.ID, 100*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+
+	if got := returnExpired(t, s); got != 1 {
+		t.Errorf("ReturnExpired() = got %d returned names, want 1", got)
+	}
+	if got := remaining(t, s, b.ID); got != total {
+		t.Errorf("RemainingValuesTotal() = got %d, want %d once the expired name is back", got, total)
+	}
+
+	name, err := s.Buckets.PopName(ctx, reload(t, s, b.ID))
+	if err != nil {
+		t.Fatalf("PopName() = unexpected error: %v", err)
+	}
+	if name != expiring.Name {
+		t.Errorf("PopName() = got %q, want the returned name %q first", name, expiring.Name)
+	}
+
+	popped := popAll(t, s, b.ID)
+	if len(popped) != int(total-1) || slices.Contains(popped, expiring.Name) {
+		t.Errorf("PopName() = got %d names, want the %d other names once", len(popped), total-1)
+	}
+}
+
+func testLeaseConfirmedNotReturned(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	total := remaining(t, s, b.ID)
+
+	l := reserve(t, s, b.ID, 100*time.Millisecond)
+	if _, err := s.Leases.Confirm(ctx, l.ID); err != nil {
+		t.Fatalf("Confirm() = unexpected error: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if got := returnExpired(t, s); got != 0 {
+		t.Errorf("ReturnExpired() = got %d returned names, want 0", got)
+	}
+
+	if got := remaining(t, s, b.ID); got != total-1 {
+		t.Errorf("RemainingValuesTotal() = got %d, want %d", got, total-1)
+	}
+
+	again, err := s.Leases.Confirm(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("Confirm() = expected the confirmed lease to be kept but got err: %v", err)
+	}
+	if !again.Confirmed() || again.Name != l.Name {
+		t.Errorf("Confirm() = got %+v, want the confirmed lease of %q", again, l.Name)
+	}
+}
+
+// testLeaseReturnExpiredSkipped checks the names of expired leases do not go back to archived buckets or to buckets
+// whose filters no longer match them.
+func testLeaseReturnExpiredSkipped(t *testing.T, s Stores) {
+	ctx := context.Background()
+	archived := createFilledBucket(t, s, "archived-bucket", serverplate.RandomPairFilters{})
+	filtered := createFilledBucket(t, s, "filtered-bucket", serverplate.RandomPairFilters{})
+
+	archivedLease := reserve(t, s, archived.ID, 100*time.Millisecond)
+	filteredLease := reserve(t, s, filtered.ID, 100*time.Millisecond)
+
+	if err := s.Buckets.Archive(ctx, archived.ID, time.Now()); err != nil {
+		t.Fatalf("Archive() = unexpected error: %v", err)
+	}
+
+	prefix := "brave"
+	if strings.HasPrefix(filteredLease.Name, prefix) {
+		prefix = "calm"
+	}
+	filtered = reload(t, s, filtered.ID)
+	filtered.SetFilters(serverplate.RandomPairFilters{Prefix: prefix})
+	if _, err := s.Buckets.UpdateFilters(ctx, &filtered); err != nil {
+		t.Fatalf("UpdateFilters() = unexpected error: %v", err)
+	}
+
+	archivedTotal := remaining(t, s, archived.ID)
+	filteredTotal := remaining(t, s, filtered.ID)
+	time.Sleep(200 * time.Millisecond)
+
+	if got := returnExpired(t, s); got != 0 {
+		t.Errorf("ReturnExpired() = got %d returned names, want 0", got)
+	}
+
+	if got := remaining(t, s, archived.ID); got != archivedTotal {
+		t.Errorf("RemainingValuesTotal() = got %d for the archived bucket, want %d", got, archivedTotal)
+	}
+	if got := remaining(t, s, filtered.ID); got != filteredTotal {
+		t.Errorf("RemainingValuesTotal() = got %d for the filtered bucket, want %d", got, filteredTotal)
+	}
+
+	for _, l := range []serverplate.Lease{archivedLease, filteredLease} {
+		if _, err := s.Leases.Confirm(ctx, l.ID); !errors.Is(err, serverplate.ErrLeaseNotFound) {
+			t.Errorf("Confirm() = unexpected error got %v want %v", err, serverplate.ErrLeaseNotFound)
+		}
+	}
+
+	if slices.Contains(popAll(t, s, filtered.ID), filteredLease.Name) {
+		t.Errorf("PopName() = popped %q, which no longer matches the filters", filteredLease.Name)
+	}
+}
+
+func testLeaseCountPending(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "test-bucket", serverplate.RandomPairFilters{})
+	other := createFilledBucket(t, s, "other-bucket", serverplate.RandomPairFilters{})
+
+	confirmed := reserve(t, s, b.ID, time.Minute)
+	if _, err := s.Leases.Confirm(ctx, confirmed.ID); err != nil {
+		t.Fatalf("Confirm() = unexpected error: %v", err)
+	}
+	reserve(t, s, b.ID, 100*time.Millisecond)
+	reserve(t, s, b.ID, time.Minute)
+	time.Sleep(200 * time.Millisecond)
+
+	countPending := func(id int32, want int64) {
+		t.Helper()
+
+		count, err := s.Leases.CountPending(ctx, id)
+		if err != nil {
+			t.Fatalf("CountPending() = unexpected error: %v", err)
+		}
+		if count != want {
+			t.Errorf("CountPending() = got %d pending leases for bucket %d, want %d", count, id, want)
+		}
+	}
+
+	// the expired lease is pending until its name is returned.
+	countPending(b.ID, 2)
+	countPending(other.ID, 0)
+
+	returnExpired(t, s)
+	countPending(b.ID, 1)
+}
+
+// createFillingBucket creates a bucket that waits for its values to be written by a fill.
+func createFillingBucket(t *testing.T, s Stores, name string) serverplate.Bucket {
+	t.Helper()
+
+	b := serverplate.Bucket{Name: name, Fill: &serverplate.BucketFill{}}
+	if err := s.Buckets.Create(context.Background(), &b); err != nil {
+		t.Fatalf("Create() = unexpected error: %v", err)
+	}
+
+	return reload(t, s, b.ID)
+}
+
+func testFillInBatches(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFillingBucket(t, s, "filling-bucket")
+
+	if !b.Filling() || b.Fill.Total != 0 || b.Fill.Done != 0 {
+		t.Fatalf("OneByID() = got fill %+v, want a fill that has not started", b.Fill)
+	}
+
+	names := allPairs(serverplate.RandomPairFilters{Suffix: "er"})
+	slices.Reverse(names)
+	if err := s.Buckets.StartFill(ctx, b, int64(len(names))); err != nil {
+		t.Fatalf("StartFill() = unexpected error: %v", err)
+	}
+
+	if err := s.Buckets.AppendFillValues(ctx, b, names[:3]); err != nil {
+		t.Fatalf("AppendFillValues() = unexpected error: %v", err)
+	}
+
+	b = reload(t, s, b.ID)
+	if !b.Filling() || b.Fill.Total != int64(len(names)) || b.Fill.Done != 3 {
+		t.Errorf("OneByID() = got fill %+v, want 3 of %d names written", b.Fill, len(names))
+	}
+
+	if err := s.Buckets.AppendFillValues(ctx, b, names[3:]); err != nil {
+		t.Fatalf("AppendFillValues() = unexpected error: %v", err)
+	}
+
+	b = reload(t, s, b.ID)
+	if b.Filling() {
+		t.Errorf("OneByID() = got fill %+v once every name was written, want none", b.Fill)
+	}
+
+	// the names are popped in the order the batches were written.
+	if got := popAll(t, s, b.ID); !slices.Equal(got, names) {
+		t.Errorf("PopName() = got %v want %v", got, names)
+	}
+
+	err := s.Buckets.AppendFillValues(ctx, b, names[:1])
+	if !errors.Is(err, serverplate.ErrBucketNotFilling) {
+		t.Errorf("AppendFillValues() = unexpected error once filled got %v want %v", err, serverplate.ErrBucketNotFilling)
+	}
+}
+
+func testFillEmpty(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFillingBucket(t, s, "filling-bucket")
+
+	if err := s.Buckets.StartFill(ctx, b, 0); err != nil {
+		t.Fatalf("StartFill() = unexpected error: %v", err)
+	}
+
+	if b = reload(t, s, b.ID); b.Filling() {
+		t.Errorf("OneByID() = got fill %+v for a fill without names, want none", b.Fill)
+	}
+	if got := remaining(t, s, b.ID); got != 0 {
+		t.Errorf("RemainingValuesTotal() = got %d want 0", got)
+	}
+}
+
+func testFailFill(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFillingBucket(t, s, "filling-bucket")
+
+	if err := s.Buckets.StartFill(ctx, b, 10); err != nil {
+		t.Fatalf("StartFill() = unexpected error: %v", err)
+	}
+	if err := s.Buckets.FailFill(ctx, b, "out of disk"); err != nil {
+		t.Fatalf("FailFill() = unexpected error: %v", err)
+	}
+
+	b = reload(t, s, b.ID)
+	if !b.Filling() || !b.Fill.Failed() || b.Fill.Error != "out of disk" {
+		t.Errorf("OneByID() = got fill %+v, want a failed fill", b.Fill)
+	}
+
+	// a worker still writing the failed fill is stopped on its next batch.
+	err := s.Buckets.AppendFillValues(ctx, b, []string{"brave-ant"})
+	if !errors.Is(err, serverplate.ErrBucketNotFilling) {
+		t.Errorf("AppendFillValues() = unexpected error got %v want %v", err, serverplate.ErrBucketNotFilling)
+	}
+	if got := remaining(t, s, b.ID); got != 0 {
+		t.Errorf("RemainingValuesTotal() = got %d for a failed fill, want 0", got)
+	}
+}
+
+func testFailStalledFills(t *testing.T, s Stores) {
+	ctx := context.Background()
+	stalled := createFillingBucket(t, s, "stalled-bucket")
+	ready := createFilledBucket(t, s, "ready-bucket", serverplate.RandomPairFilters{})
+
+	count, err := s.Buckets.FailStalledFills(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("FailStalledFills() = unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("FailStalledFills() = got %d fills failed, want none before they stall", count)
+	}
+
+	count, err = s.Buckets.FailStalledFills(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("FailStalledFills() = unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("FailStalledFills() = got %d fills failed want 1", count)
+	}
+
+	if b := reload(t, s, stalled.ID); !b.Filling() || !b.Fill.Failed() {
+		t.Errorf("OneByID() = got fill %+v, want the stalled fill failed", b.Fill)
+	}
+	if b := reload(t, s, ready.ID); b.Filling() {
+		t.Errorf("OneByID() = got fill %+v for a filled bucket, want none", b.Fill)
+	}
+}
+
+func testFillNotFilling(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFilledBucket(t, s, "ready-bucket", serverplate.RandomPairFilters{})
+
+	if err := s.Buckets.StartFill(ctx, b, 1); !errors.Is(err, serverplate.ErrBucketNotFilling) {
+		t.Errorf("StartFill() = unexpected error got %v want %v", err, serverplate.ErrBucketNotFilling)
+	}
+	if err := s.Buckets.FailFill(ctx, b, "failed"); !errors.Is(err, serverplate.ErrBucketNotFilling) {
+		t.Errorf("FailFill() = unexpected error got %v want %v", err, serverplate.ErrBucketNotFilling)
+	}
+	if err := s.Buckets.KeepFilling(ctx, b); !errors.Is(err, serverplate.ErrBucketNotFilling) {
+		t.Errorf("KeepFilling() = unexpected error got %v want %v", err, serverplate.ErrBucketNotFilling)
+	}
+}
+
+func testKeepFilling(t *testing.T, s Stores) {
+	ctx := context.Background()
+	b := createFillingBucket(t, s, "queued-bucket")
+
+	if err := s.Buckets.KeepFilling(ctx, b); err != nil {
+		t.Fatalf("KeepFilling() = unexpected error: %v", err)
+	}
+
+	got := reload(t, s, b.ID)
+	if got.UpdatedAt == nil {
+		t.Fatalf("OneByID() = got no update time, want the time the fill was kept")
+	}
+	if !got.Filling() || got.Fill.Failed() || got.Fill.Total != 0 {
+		t.Errorf("OneByID() = got fill %+v, want the fill untouched", got.Fill)
+	}
+
+	count, err := s.Buckets.FailStalledFills(ctx, got.UpdatedAt.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("FailStalledFills() = unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("FailStalledFills() = got %d fills failed, want none for a kept fill", count)
+	}
+}
+
+func recordAudit(t *testing.T, s Stores, e serverplate.AuditEvent) serverplate.AuditEvent {
+	t.Helper()
+
+	if err := s.Audit.Record(context.Background(), &e); err != nil {
+		t.Fatalf("Record() = unexpected error: %v", err)
+	}
+
+	return e
+}
+
+func listAudit(t *testing.T, s Stores, opts serverplate.AuditListOptions) []int64 {
+	t.Helper()
+
+	events, err := s.Audit.List(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	return ids
+}
+
+func testAuditRecordAndList(t *testing.T, s Stores) {
+	if ids := listAudit(t, s, serverplate.AuditListOptions{}); len(ids) != 0 {
+		t.Errorf("List() = got %v before any event, want none", ids)
+	}
+
+	created := recordAudit(t, s, serverplate.AuditEvent{
+		Action:     serverplate.AuditBucketCreated,
+		BucketID:   7,
+		BucketName: "test-bucket",
+		AuditOrigin: serverplate.AuditOrigin{
+			Actor:     "alice",
+			Source:    serverplate.AuditSourceAPI,
+			IP:        "10.0.0.1",
+			RequestID: "req-1",
+		},
+		After: []byte(`{"name":"test-bucket"}`),
+	})
+	if created.ID == 0 || created.CreatedAt.IsZero() {
+		t.Errorf("Record() = got id %d created at %v, want them set", created.ID, created.CreatedAt)
+	}
+
+	popped := recordAudit(t, s, serverplate.AuditEvent{
+		Action:      serverplate.AuditBucketPopped,
+		BucketID:    7,
+		BucketName:  "test-bucket",
+		AuditOrigin: serverplate.AuditOrigin{Actor: "remove_archived_buckets", Source: serverplate.AuditSourceJob},
+		After:       []byte(`{"name":"brave-river"}`),
+	})
+
+	events, err := s.Audit.List(context.Background(), serverplate.AuditListOptions{})
+	if err != nil {
+		t.Fatalf("List() = unexpected error: %v", err)
+	}
+
+	if len(events) != 2 || events[0].ID != popped.ID || events[1].ID != created.ID {
+		t.Fatalf("List() = got %+v, want the popped then the created event", events)
+	}
+
+	got := events[1]
+	if got.Action != created.Action || got.BucketID != 7 || got.BucketName != "test-bucket" ||
+		got.AuditOrigin != created.AuditOrigin {
+		t.Errorf("List() = got %+v, want %+v", got, created)
+	}
+
+	if string(got.Before) != "null" {
+		t.Errorf("List() = got the before state %s, want null", got.Before)
+	}
+
+	var after map[string]string
+	if err := json.Unmarshal(got.After, &after); err != nil || after["name"] != "test-bucket" {
+		t.Errorf("List() = got the after state %s (%v), want the recorded one", got.After, err)
+	}
+
+	// the databases keep the times with less precision than Go.
+	if got.CreatedAt.Sub(created.CreatedAt).Abs() > time.Millisecond {
+		t.Errorf("List() = got created at %v, want %v", got.CreatedAt, created.CreatedAt)
+	}
+
+	if o := events[0].AuditOrigin; o.IP != "" || o.RequestID != "" {
+		t.Errorf("List() = got the origin %+v, want no ip nor request id", o)
+	}
+}
+
+func testAuditListFilters(t *testing.T, s Stores) {
+	web := serverplate.AuditOrigin{Actor: "alice", Source: serverplate.AuditSourceWeb}
+	job := serverplate.AuditOrigin{Actor: "auto_archive_buckets", Source: serverplate.AuditSourceJob}
+
+	e1 := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketCreated, BucketID: 1, AuditOrigin: web})
+	e2 := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketCreated, BucketID: 2, AuditOrigin: web})
+	e3 := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketArchived, BucketID: 1, AuditOrigin: job})
+
+	tests := []struct {
+		name string
+		opts serverplate.AuditListOptions
+		want []int64
+	}{
+		{"bucket", serverplate.AuditListOptions{BucketID: 1}, []int64{e3.ID, e1.ID}},
+		{"action", serverplate.AuditListOptions{Action: serverplate.AuditBucketCreated}, []int64{e2.ID, e1.ID}},
+		{"actor", serverplate.AuditListOptions{Actor: "auto_archive_buckets"}, []int64{e3.ID}},
+		{"source", serverplate.AuditListOptions{Source: serverplate.AuditSourceWeb}, []int64{e2.ID, e1.ID}},
+		{
+			"combined",
+			serverplate.AuditListOptions{BucketID: 2, Action: serverplate.AuditBucketArchived},
+			[]int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := listAudit(t, s, tt.opts); !slices.Equal(got, tt.want) {
+			t.Errorf("List() = got %v filtering by %s, want %v", got, tt.name, tt.want)
+		}
+	}
+}
+
+func testAuditListPages(t *testing.T, s Stores) {
+	var want []int64
+	for i := range 5 {
+		e := recordAudit(t, s, serverplate.AuditEvent{
+			Action:      serverplate.AuditBucketPopped,
+			BucketID:    int32(i),
+			AuditOrigin: serverplate.AuditOrigin{Actor: "alice", Source: serverplate.AuditSourceAPI},
+		})
+		want = append([]int64{e.ID}, want...)
+	}
+
+	first := listAudit(t, s, serverplate.AuditListOptions{Limit: 2})
+	if !slices.Equal(first, want[:2]) {
+		t.Fatalf("List() = got the first page %v, want %v", first, want[:2])
+	}
+
+	second := listAudit(t, s, serverplate.AuditListOptions{Limit: 2, BeforeID: first[1]})
+	if !slices.Equal(second, want[2:4]) {
+		t.Errorf("List() = got the second page %v, want %v", second, want[2:4])
+	}
+
+	last := listAudit(t, s, serverplate.AuditListOptions{Limit: 2, BeforeID: want[3]})
+	if !slices.Equal(last, want[4:]) {
+		t.Errorf("List() = got the last page %v, want %v", last, want[4:])
+	}
+}
+
+func testAuditListTimeRange(t *testing.T, s Stores) {
+	origin := serverplate.AuditOrigin{Actor: "alice", Source: serverplate.AuditSourceAPI}
+
+	before := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketCreated, AuditOrigin: origin})
+	time.Sleep(20 * time.Millisecond)
+	since := time.Now()
+	within := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketUpdated, AuditOrigin: origin})
+	time.Sleep(20 * time.Millisecond)
+	until := time.Now()
+	time.Sleep(20 * time.Millisecond)
+	after := recordAudit(t, s, serverplate.AuditEvent{Action: serverplate.AuditBucketDeleted, AuditOrigin: origin})
+
+	if got := listAudit(t, s, serverplate.AuditListOptions{Since: since, Until: until}); !slices.Equal(
+		got,
+		[]int64{within.ID},
+	) {
+		t.Errorf("List() = got %v within the range, want %v", got, []int64{within.ID})
+	}
+
+	if got := listAudit(t, s, serverplate.AuditListOptions{Since: since}); !slices.Equal(
+		got,
+		[]int64{after.ID, within.ID},
+	) {
+		t.Errorf("List() = got %v since the time, want %v", got, []int64{after.ID, within.ID})
+	}
+
+	if got := listAudit(t, s, serverplate.AuditListOptions{Until: until}); !slices.Equal(
+		got,
+		[]int64{within.ID, before.ID},
+	) {
+		t.Errorf("List() = got %v until the time, want %v", got, []int64{within.ID, before.ID})
+	}
+}
@@ -0,0 +1,20 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// auditState indents the JSON state of an audit event, a missing state reads as none.
+func auditState(state json.RawMessage) string {
+	if len(state) == 0 || string(state) == "null" {
+		return "none"
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, state, "", "  "); err != nil {
+		return string(state)
+	}
+
+	return buf.String()
+}
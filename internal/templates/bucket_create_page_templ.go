@@ -8,7 +8,31 @@ package templates
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
-type BucketCreatePageViewModel struct{}
+import (
+	"strconv"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+)
+
+type BucketCreatePageViewModel struct {
+	// the submitted values, the form is rendered again with them along with the Errors of the rejected fields.
+	Name                 string
+	Description          string
+	Labels               string
+	Retention            string
+	ExpiresAt            string
+	ArchiveWhenExhausted bool
+	Filters              serverplate.GenerateOptions
+	Errors               FormErrors
+}
+
+// lengthValue returns the value of the length slider, which starts in the middle of its range.
+func (vm BucketCreatePageViewModel) lengthValue() string {
+	if vm.Filters.LengthValue == 0 {
+		return "14"
+	}
+	return strconv.Itoa(vm.Filters.LengthValue)
+}
 
 func BucketCreatePage(vm BucketCreatePageViewModel) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
@@ -59,37 +83,295 @@ func BucketCreatePage(vm BucketCreatePageViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</a></div><div class=\"text-4xl\">Create a Bucket</div><div class=\"flex flex-col gap-4 items-center\"><form method=\"post\" action=\"/buckets\"><div class=\"flex flex-col gap-6 w-lg\"><div class=\"flex flex-col gap-2\"><label for=\"name\" class=\"text-sm font-semibold\">Bucket Name <span class=\"text-red-600\">*</span></label><div class=\"flex\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</a></div><div class=\"text-4xl\">Create a Bucket</div><div class=\"flex flex-col gap-4 items-center\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = BucketNameInput("").Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = BucketCreateForm(vm).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<button type=\"button\" hx-get=\"/generate?component=bucket-input\" hx-target=\"#name\" hx-swap=\"outerHTML\" class=\"border cursor-pointer border-l-0 border-primary-200 rounded-r-lg px-3 bg-primary-50 text-primary-600 hover:bg-primary-100 hover:text-primary-700 focus:outline-none focus:ring-2 focus:ring-primary-400 transition-all\" title=\"Generate random bucket name\" aria-label=\"Generate random bucket name\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = DiceIcon().Render(ctx, templ_7745c5c3_Buffer)
+			return nil
+		})
+		templ_7745c5c3_Err = Layout().Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// BucketCreateForm renders the form on its own, it replaces itself with the rejected fields when submitted.
+func BucketCreateForm(vm BucketCreatePageViewModel) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var3 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var3 == nil {
+			templ_7745c5c3_Var3 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<form method=\"post\" action=\"/buckets\" hx-post=\"/buckets\" hx-target=\"this\" hx-swap=\"outerHTML\"><div class=\"flex flex-col gap-6 w-lg\"><div class=\"flex flex-col gap-2\"><label for=\"name\" class=\"text-sm font-semibold\">Bucket Name <span class=\"text-red-600\">*</span></label><div class=\"flex\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = BucketNameInput(vm.Name).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<button type=\"button\" hx-get=\"/generate?component=bucket-input\" hx-target=\"#name\" hx-swap=\"outerHTML\" class=\"border cursor-pointer border-l-0 border-primary-200 rounded-r-lg px-3 bg-primary-50 text-primary-600 hover:bg-primary-100 hover:text-primary-700 focus:outline-none focus:ring-2 focus:ring-primary-400 transition-all\" title=\"Generate random bucket name\" aria-label=\"Generate random bucket name\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = DiceIcon().Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</button></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["name"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</div><div class=\"flex flex-col gap-2\"><label for=\"description\" class=\"text-sm font-semibold\">Description</label> <textarea id=\"description\" class=\"w-full border border-primary-200 rounded-lg p-4 bg-primary-50 text-sm font-medium transition-all focus:outline-none focus:ring-2 focus:ring-primary-400 focus:border-transparent hover:border-primary-300 resize-none\" name=\"description\" placeholder=\"What will the bucket be used for?\" rows=\"5\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(vm.Description)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 78, Col: 21}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</textarea>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["description"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</div><div class=\"flex flex-col gap-2\"><label for=\"labels\" class=\"text-sm font-semibold\">Labels</label> <input id=\"labels\" type=\"text\" name=\"labels\" placeholder=\"team=payments,env=prod\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(vm.Labels)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 88, Col: 22}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "\" class=\"w-full border border-primary-200 rounded-lg px-4 py-2 bg-primary-50 text-sm font-mono transition-all focus:outline-none focus:ring-2 focus:ring-primary-400 focus:border-transparent hover:border-primary-300\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["labels"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "<div class=\"text-xs text-gray-600\">Comma separated key=value pairs used to find the bucket later</div></div><div class=\"flex flex-col gap-2\"><label for=\"retention\" class=\"text-sm font-semibold\">Retention</label> <input id=\"retention\" type=\"text\" name=\"retention\" placeholder=\"72h\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(vm.Retention)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 101, Col: 25}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "\" class=\"w-full border border-primary-200 rounded-lg px-4 py-2 bg-primary-50 text-sm font-mono transition-all focus:outline-none focus:ring-2 focus:ring-primary-400 focus:border-transparent hover:border-primary-300\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["retention"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "<div class=\"text-xs text-gray-600\">How long the bucket is kept once archived, empty uses the server default and 0 keeps it forever</div></div><div class=\"flex flex-col gap-2\"><label for=\"expires_at\" class=\"text-sm font-semibold\">Expires at (UTC)</label> <input id=\"expires_at\" type=\"datetime-local\" name=\"expires_at\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(vm.ExpiresAt)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 113, Col: 25}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "\" class=\"w-full border border-primary-200 rounded-lg px-4 py-2 bg-primary-50 text-sm font-mono transition-all focus:outline-none focus:ring-2 focus:ring-primary-400 focus:border-transparent hover:border-primary-300\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["expires_at"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "<div class=\"flex gap-2 items-center\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = Toggle(ToggleAttrs{Name: "archive_when_exhausted", Checked: vm.ArchiveWhenExhausted}).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "<span class=\"text-sm text-gray-800\">Archive once every name has been popped</span></div><div class=\"text-xs text-gray-600\">Archive the bucket automatically, handy for hackathons and load tests</div></div><div class=\"flex flex-col gap-2 border border-primary-200 rounded-lg p-4\"><div class=\"text-sm font-semibold\">Name Generation Filters</div><div class=\"text-xs text-gray-600\">Configure constraints for generated names in this bucket</div><div class=\"flex flex-col gap-3 pt-2\"><div class=\"flex gap-2 items-center\"><span class=\"text-sm font-medium text-gray-800\">Length Filter</span>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = Toggle(ToggleAttrs{
+			Name:    "filter_length_enabled",
+			Class:   "js-filter-length-toggle",
+			Checked: vm.Filters.LengthEnabled,
+		}).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "</div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 = []any{"js-filter-length-controls flex flex-col gap-3",
+			templ.KV("opacity-40", !vm.Filters.LengthEnabled),
+		}
+		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var8...)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "<div class=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var9 string
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var8).String())
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 1, Col: 0}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "\"><div><div class=\"inline-flex rounded-md shadow-sm\" role=\"group\"><label><input type=\"radio\" name=\"filter_length_mode\" value=\"upto\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if vm.Filters.LengthMode != serverplate.LengthModeExactly {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, " checked")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</button></div></div><div class=\"flex flex-col gap-2\"><label for=\"description\" class=\"text-sm font-semibold\">Description</label> <textarea id=\"description\" class=\"w-full border border-primary-200 rounded-lg p-4 bg-primary-50 text-sm font-medium transition-all focus:outline-none focus:ring-2 focus:ring-primary-400 focus:border-transparent hover:border-primary-300 resize-none\" name=\"description\" placeholder=\"What will the bucket be used for?\" rows=\"5\"></textarea></div><div class=\"flex flex-col gap-2 border border-primary-200 rounded-lg p-4\"><div class=\"text-sm font-semibold\">Name Generation Filters</div><div class=\"text-xs text-gray-600\">Configure constraints for generated names in this bucket</div><div class=\"flex flex-col gap-3 pt-2\"><div class=\"flex gap-2 items-center\"><span class=\"text-sm font-medium text-gray-800\">Length Filter</span>")
+		}
+		if !vm.Filters.LengthEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " disabled")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = Toggle(ToggleAttrs{Name: "filter_length_enabled", Class: "js-filter-length-toggle"}).Render(ctx, templ_7745c5c3_Buffer)
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " class=\"sr-only peer js-filter-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-s cursor-pointer hover:bg-secondary/10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Up to</div></label> <label><input type=\"radio\" name=\"filter_length_mode\" value=\"exactly\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if vm.Filters.LengthMode == serverplate.LengthModeExactly {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " checked")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</div><div class=\"js-filter-length-controls opacity-40 flex flex-col gap-3\"><div><div class=\"inline-flex rounded-md shadow-sm\" role=\"group\"><label><input type=\"radio\" name=\"filter_length_mode\" value=\"upto\" checked=\"checked\" disabled=\"disabled\" class=\"sr-only peer js-filter-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-s cursor-pointer hover:bg-secondary/10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Up to</div></label> <label><input type=\"radio\" name=\"filter_length_mode\" value=\"exactly\" disabled=\"disabled\" class=\"sr-only peer js-filter-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-e cursor-pointer hover:bg-secondary/10 peer-focus:z-10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Exactly</div></label></div></div><div class=\"js-filter-length-range-container pb-8\"><div class=\"flex justify-center\"><div class=\"js-filter-length-range-value text-sm font-semibold\">14</div></div><div class=\"relative\"><input name=\"filter_length_value\" type=\"range\" value=\"14\" min=\"7\" max=\"19\" disabled=\"disabled\" class=\"js-filter-length-range-slider js-filter-length-linked accent-secondary disabled:accent-gray-400 bg-primary w-full h-2 rounded-lg appearance-none cursor-pointer\"> <span class=\"text-sm text-gray-500 absolute start-0 -bottom-6\">7</span> <span class=\"text-sm text-gray-500 absolute start-1/2 -translate-x-1/2 rtl:translate-x-1/2 -bottom-6\">12</span> <span class=\"text-sm text-gray-500 absolute end-0 -bottom-6\">19</span></div></div></div></div></div><div><button class=\"cursor-pointer rounded-full bg-primary text-white px-8 py-3 text-sm font-medium hover:bg-primary-600 focus:outline-none focus:ring active:text-opacity-75 hover:shadow-lg\" type=\"submit\">Create</button></div></div></form></div></div>")
+		}
+		if !vm.Filters.LengthEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, " disabled")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			return nil
-		})
-		templ_7745c5c3_Err = Layout().Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " class=\"sr-only peer js-filter-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-e cursor-pointer hover:bg-secondary/10 peer-focus:z-10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Exactly</div></label></div></div><div class=\"js-filter-length-range-container pb-8\"><div class=\"flex justify-center\"><div class=\"js-filter-length-range-value text-sm font-semibold\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var10 string
+		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(vm.lengthValue())
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 173, Col: 90}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "</div></div><div class=\"relative\"><input name=\"filter_length_value\" type=\"range\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var11 string
+		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(vm.lengthValue())
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `bucket_create_page.templ`, Line: 179, Col: 33}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\" min=\"7\" max=\"19\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if !vm.Filters.LengthEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, " disabled")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, " class=\"js-filter-length-range-slider js-filter-length-linked accent-secondary disabled:accent-gray-400 bg-primary w-full h-2 rounded-lg appearance-none cursor-pointer\"> <span class=\"text-sm text-gray-500 absolute start-0 -bottom-6\">7</span> <span class=\"text-sm text-gray-500 absolute start-1/2 -translate-x-1/2 rtl:translate-x-1/2 -bottom-6\">12</span> <span class=\"text-sm text-gray-500 absolute end-0 -bottom-6\">19</span></div></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["filters.length"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["filters.length_mode"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "</div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = NameFilterFields(NameFilterFieldsViewModel{Prefix: "filter_", Values: vm.Filters, Errors: vm.Errors}).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = FieldErrorMessage(vm.Errors["filters"]).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "</div></div><div><button class=\"cursor-pointer rounded-full bg-primary text-white px-8 py-3 text-sm font-medium hover:bg-primary-600 focus:outline-none focus:ring active:text-opacity-75 hover:shadow-lg\" type=\"submit\">Create</button></div></div></form>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
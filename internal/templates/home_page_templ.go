@@ -69,7 +69,23 @@ func HomePage(vm HomeViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</a><div class=\"js-drawer-open p-4 cursor-pointer\" title=\"Configuration\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</a> <a href=\"/jobs\" class=\"inline-block p-4\" title=\"Background jobs\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = JobsIcon().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</a> <a href=\"/audit\" class=\"inline-block p-4\" title=\"Audit log\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = AuditIcon().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</a><div class=\"js-drawer-open p-4 cursor-pointer\" title=\"Configuration\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -77,7 +93,7 @@ func HomePage(vm HomeViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div></div><h1 class=\"text-3xl\">Generate a server name</h1><div id=\"generate-name-container\" class=\"w-full flex justify-center\"><span class=\"text-gray-400\">The name will be here</span></div><button hx-get=\"/generate\" hx-target=\"#generate-name-container\" hx-include=\".js-generate-configuration\" class=\"cursor-pointer rounded-full border-2 border-primary bg-primary text-white px-8 py-3 text-sm font-medium hover:bg-primary-600 focus:outline-none focus:ring active:text-opacity-75\" type=\"button\">Generate</button></div><div id=\"drawer\" class=\"fixed top-0 right-0 z-20 w-60 h-screen p-4 bg-white overflow-y-auto transition-transform translate-x-full opacity-0 shadow-xl\" tabindex=\"-1\" aria-hiden=\"true\"><div class=\"relative flex flex-col w-full h-full\"><button type=\"button\" class=\"js-drawer-close text-gray-400 bg-transparent hover:bg-slate-300 hover:text-gray-900 rounded-lg cursor-pointer text-sm w-6 h-6 absolute top-0 end-0 flex items-center justify-center\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div></div><h1 class=\"text-3xl\">Generate a server name</h1><div id=\"generate-name-container\" class=\"w-full flex justify-center\"><span class=\"text-gray-400\">The name will be here</span></div><button hx-get=\"/generate\" hx-target=\"#generate-name-container\" hx-include=\".js-generate-configuration\" class=\"cursor-pointer rounded-full border-2 border-primary bg-primary text-white px-8 py-3 text-sm font-medium hover:bg-primary-600 focus:outline-none focus:ring active:text-opacity-75\" type=\"button\">Generate</button></div><div id=\"drawer\" class=\"fixed top-0 right-0 z-20 w-60 h-screen p-4 bg-white overflow-y-auto transition-transform translate-x-full opacity-0 shadow-xl\" tabindex=\"-1\" aria-hiden=\"true\"><div class=\"relative flex flex-col w-full h-full\"><button type=\"button\" class=\"js-drawer-close text-gray-400 bg-transparent hover:bg-slate-300 hover:text-gray-900 rounded-lg cursor-pointer text-sm w-6 h-6 absolute top-0 end-0 flex items-center justify-center\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -85,7 +101,7 @@ func HomePage(vm HomeViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<span class=\"sr-only\">Close menu</span></button><div class=\"text-xl font-semibold\">Configuration</div><div class=\"js-generate-configuration mt-4 flex flex-col gap-4\" hx-get=\"/config/stats\" hx-trigger=\"change delay:100ms\" hx-include=\"this\" hx-target=\".js-config-stats\"><div class=\"flex flex-col gap-2\"><div class=\"flex gap-1 items-center\"><span class=\"text-sm font-medium text-gray-800\">Length</span>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<span class=\"sr-only\">Close menu</span></button><div class=\"text-xl font-semibold\">Configuration</div><div class=\"js-generate-configuration mt-4 flex flex-col gap-4\" hx-get=\"/config/stats\" hx-trigger=\"change delay:100ms\" hx-include=\"this\" hx-target=\".js-config-stats\"><div class=\"flex flex-col gap-2\"><div class=\"flex gap-1 items-center\"><span class=\"text-sm font-medium text-gray-800\">Length</span>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -93,7 +109,15 @@ func HomePage(vm HomeViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div><div class=\"js-config-length-opacity opacity-40 flex flex-col gap-2\"><div><div class=\"inline-flex rounded-md shadow-sm\" role=\"group\"><label><input type=\"radio\" name=\"length_mode\" value=\"upto\" checked=\"checked\" disabled=\"disabled\" class=\"sr-only peer js-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-s cursor-pointer hover:bg-secondary/10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Up to</div></label> <label><input type=\"radio\" name=\"length_mode\" value=\"exactly\" disabled=\"disabled\" class=\"sr-only peer js-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-e cursor-pointer hover:bg-secondary/10 peer-focus:z-10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Exactly</div></label></div></div><div class=\"js-length-range-container\"><div class=\"flex justify-center\"><div class=\"js-length-range-value text-sm font-semibold\">14</div></div><div class=\"relative\"><input name=\"length_value\" type=\"range\" value=\"14\" min=\"7\" max=\"19\" disabled=\"disabled\" class=\"js-length-range-slider js-length-linked accent-secondary disabled:accent-gray-400 bg-primary w-full h-2 rounded-lg appearance-none cursor-pointer\"> <span class=\"text-sm text-gray-500 absolute start-0 -bottom-6\">7</span> <span class=\"text-sm text-gray-500 absolute start-1/2 -translate-x-1/2 rtl:translate-x-1/2 -bottom-6\">12</span> <span class=\"text-sm text-gray-500 absolute end-0 -bottom-6\">19</span></div></div></div></div><div class=\"js-config-stats pt-4\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</div><div class=\"js-config-length-opacity opacity-40 flex flex-col gap-2\"><div><div class=\"inline-flex rounded-md shadow-sm\" role=\"group\"><label><input type=\"radio\" name=\"length_mode\" value=\"upto\" checked=\"checked\" disabled=\"disabled\" class=\"sr-only peer js-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-s cursor-pointer hover:bg-secondary/10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Up to</div></label> <label><input type=\"radio\" name=\"length_mode\" value=\"exactly\" disabled=\"disabled\" class=\"sr-only peer js-length-linked\"><div class=\"px-2 py-1 text-xs font-medium text-slate-800 bg-white border border-secondary/20 rounded-e cursor-pointer hover:bg-secondary/10 peer-focus:z-10 peer-checked:bg-secondary peer-checked:text-white peer-checked:hover:bg-secondary peer-checked:hover:text-white\">Exactly</div></label></div></div><div class=\"js-length-range-container\"><div class=\"flex justify-center\"><div class=\"js-length-range-value text-sm font-semibold\">14</div></div><div class=\"relative\"><input name=\"length_value\" type=\"range\" value=\"14\" min=\"7\" max=\"19\" disabled=\"disabled\" class=\"js-length-range-slider js-length-linked accent-secondary disabled:accent-gray-400 bg-primary w-full h-2 rounded-lg appearance-none cursor-pointer\"> <span class=\"text-sm text-gray-500 absolute start-0 -bottom-6\">7</span> <span class=\"text-sm text-gray-500 absolute start-1/2 -translate-x-1/2 rtl:translate-x-1/2 -bottom-6\">12</span> <span class=\"text-sm text-gray-500 absolute end-0 -bottom-6\">19</span></div></div></div></div><div class=\"flex flex-col gap-2 pt-4\"><span class=\"text-sm font-medium text-gray-800\">Filters</span>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = NameFilterFields(NameFilterFieldsViewModel{}).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</div><div class=\"js-config-stats pt-4\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -101,7 +125,7 @@ func HomePage(vm HomeViewModel) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</div></div></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</div></div></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -0,0 +1,49 @@
+package templates
+
+import (
+	"time"
+
+	"github.com/davidonium/serverplate/internal/serverplate"
+	"github.com/dustin/go-humanize"
+)
+
+// bucketRetention returns the retention of b for display, falling back to the server default when it has none.
+func bucketRetention(b serverplate.Bucket, defaultRetention time.Duration) string {
+	retention := defaultRetention
+	suffix := " (default)"
+	if b.Retention != nil {
+		retention = *b.Retention
+		suffix = ""
+	}
+
+	if retention <= 0 {
+		return "forever" + suffix
+	}
+	return serverplate.FormatRetention(retention) + suffix
+}
+
+// bucketRemoval describes when the archived bucket b is going to be removed.
+func bucketRemoval(b serverplate.Bucket, defaultRetention time.Duration) string {
+	at, ok := b.RemovalTime(defaultRetention)
+	if !ok {
+		return "it will be kept until it is deleted manually"
+	}
+	if at.Before(time.Now()) {
+		return "it will be removed shortly"
+	}
+	return "it will be removed " + humanize.Time(at)
+}
+
+// archiveRemoval describes when b is going to be removed if it were archived now.
+func archiveRemoval(b serverplate.Bucket, defaultRetention time.Duration) string {
+	b.ArchivedAt = new(time.Now())
+	return bucketRemoval(b, defaultRetention)
+}
+
+// expiryInputValue formats the expiry of b for a datetime-local input, in UTC.
+func expiryInputValue(b serverplate.Bucket) string {
+	if b.ExpiresAt == nil {
+		return ""
+	}
+	return b.ExpiresAt.UTC().Format("2006-01-02T15:04")
+}